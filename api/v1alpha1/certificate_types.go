@@ -12,50 +12,519 @@ type IssuerRef struct {
 	// Name of the issuer
 	Name string `json:"name"`
 
-	// Kind of the issuer (SelfSigned, CA, External)
+	// Kind of the issuer (SelfSigned, CA, ExternalKey, External, Issuer). For CA, Name is a Secret in the
+	// Certificate's namespace holding the CA keypair as tls.crt/tls.key and optionally ca.crt;
+	// an empty Name uses the CA files configured on the controller.
+	// For ExternalKey, Name references a key held by the controller's key manager, e.g. an AWS KMS key ID or alias with --external-key-manager=aws-kms;
+	// the certificate is self-signed with that key, which is never rotated or stored in the secret.
+	// For Issuer, Name is an Issuer in the Certificate's namespace configuring the backend
 	// +optional
 	// +kubebuilder:default=SelfSigned
 	Kind string `json:"kind,omitempty"`
+
+	// CertKey is the data key of a CA issuer secret holding the CA certificate, for CA secrets
+	// created by other tools. When neither CertKey nor PrivateKeyKey is set, tls.crt/tls.key is
+	// used, falling back to ca.crt/ca.key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	CertKey string `json:"certKey,omitempty"`
+
+	// PrivateKeyKey is the data key of a CA issuer secret holding the CA private key. Defaults to tls.key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	PrivateKeyKey string `json:"privateKeyKey,omitempty"`
+}
+
+// IngressRef references an Ingress in the secret's namespace: SecretNamespace when set, otherwise
+// the Certificate's
+type IngressRef struct {
+	// Name of the Ingress
+	Name string `json:"name"`
+}
+
+// GatewayRef references a Gateway API Gateway in the secret's namespace: SecretNamespace when set,
+// otherwise the Certificate's
+type GatewayRef struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+
+	// SectionName is the listener to configure. All HTTPS/TLS listeners are updated when empty
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ServiceRef references a Service in the Certificate's namespace, even when SecretNamespace is set
+type ServiceRef struct {
+	// Name of the Service
+	Name string `json:"name"`
+
+	// ClusterDomain is the cluster DNS domain. Defaults to cluster.local
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+}
+
+// Subject holds distinguished name fields for the certificate subject
+type Subject struct {
+	// Organizations to be used on the certificate
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Countries to be used on the certificate
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+
+	// OrganizationalUnits to be used on the certificate
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+}
+
+// SerialNumberSource selects where the certificate serial number comes from
+type SerialNumberSource struct {
+	// Type of source: Random (default), Provided or Sequential
+	// +optional
+	// +kubebuilder:default=Random
+	// +kubebuilder:validation:Enum=Random;Provided;Sequential
+	Type string `json:"type,omitempty"`
+
+	// Value is the hex encoded serial number used when Type is Provided.
+	// Serial numbers must be unique per issuer and keeping them unique is the user's responsibility;
+	// renewals reuse the same value, which some clients reject
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ConfigMapName is the counter ConfigMap used when Type is Sequential. Its "next" key holds
+	// the hex encoded serial number to issue next and is incremented on every issuance
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// CSRSecretRef references a PEM-encoded certificate signing request in a Secret
+type CSRSecretRef struct {
+	// Name of the Secret in the Certificate's namespace, even when SecretNamespace is set
+	Name string `json:"name"`
+
+	// Key holding the CSR
+	// +optional
+	// +kubebuilder:default=tls.csr
+	Key string `json:"key,omitempty"`
+}
+
+// SecretTemplate holds metadata copied onto the certificate secret
+type SecretTemplate struct {
+	// Labels added to the secret
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the secret
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RenewalWindow is a daily maintenance window in UTC
+type RenewalWindow struct {
+	// Start is the time of day the window opens, as HH:MM in UTC
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open, e.g. "2h"
+	Duration string `json:"duration"`
+}
+
+// RawExtension is an X.509 extension added to the certificate as-is
+type RawExtension struct {
+	// OID of the extension in dotted form, e.g. 1.3.6.1.4.1.99999.1
+	// +kubebuilder:validation:Pattern=`^[0-2](\.[0-9]+)+$`
+	OID string `json:"oid"`
+
+	// Critical marks the extension critical, so relying parties that don't understand it reject the certificate
+	// +optional
+	Critical bool `json:"critical,omitempty"`
+
+	// Value is the base64-encoded DER value of the extension
+	Value string `json:"value"`
+}
+
+// SecretKeys overrides the data keys the certificate secret is written under
+type SecretKeys struct {
+	// CA is the key holding the CA bundle, for consumers that expect e.g. ca-bundle.crt or
+	// root.pem. tls.crt and tls.key keep their standard names
+	// +optional
+	// +kubebuilder:default="ca.crt"
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	CA string `json:"ca,omitempty"`
+}
+
+// PEMFormat selects the byte layout of the PEM data in the secret
+type PEMFormat struct {
+	// LineEnding is LF (default) or CRLF
+	// +optional
+	// +kubebuilder:validation:Enum=LF;CRLF
+	// +kubebuilder:default=LF
+	LineEnding string `json:"lineEnding,omitempty"`
+
+	// OmitTrailingNewline drops the line ending after the last END line
+	// +optional
+	OmitTrailingNewline bool `json:"omitTrailingNewline,omitempty"`
+}
+
+// AdditionalCertificate is a related certificate issued alongside the main one. It inherits the
+// issuer, subject, key and validity settings of the Certificate and is renewed on its own schedule
+type AdditionalCertificate struct {
+	// CommonName of the certificate
+	CommonName string `json:"commonName"`
+
+	// DNSNames of the certificate
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses of the certificate
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// SecretName where the certificate will be stored. Must differ from every other secret of the Certificate
+	SecretName string `json:"secretName"`
+
+	// Duration overrides the Certificate's duration
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// RenewBefore overrides the Certificate's renewBefore
+	// +optional
+	RenewBefore string `json:"renewBefore,omitempty"`
+}
+
+// AdditionalCertificateStatus is the observed state of an AdditionalCertificates entry
+type AdditionalCertificateStatus struct {
+	// SecretName identifies the entry
+	SecretName string `json:"secretName"`
+
+	// SerialNumber of the current certificate
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// NotAfter is the certificate expiry time
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is when the certificate should be renewed
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// SpecHash is a hash of the fields the current certificate was issued from
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+}
+
+// DependentSecret is a secret rendered from Go templates each time the certificate rotates
+type DependentSecret struct {
+	// Name of the secret in the Certificate's namespace
+	Name string `json:"name"`
+
+	// Data maps secret keys to Go templates. Templates see .Certificate (the PEM chain),
+	// .PrivateKey, .CA, .SerialNumber, .NotAfter, .Name and .Namespace, and can base64 encode
+	// with b64enc. Other keys in the secret are left alone
+	Data map[string]string `json:"data"`
+}
+
+// Kubeconfig configures the cluster entry of a rendered kubeconfig
+type Kubeconfig struct {
+	// Server is the URL of the server, e.g. https://kubernetes.default.svc
+	// +kubebuilder:validation:Pattern=`^https://`
+	Server string `json:"server"`
+
+	// CABundle is the PEM bundle verifying the server. Defaults to the issuing CA, or the system
+	// roots for self-signed certificates
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
 }
 
 // CertificateSpec defines the desired state of Certificate
+// +kubebuilder:validation:XValidation:rule="(has(self.commonName) && size(self.commonName) > 0) || (has(self.dnsNames) && size(self.dnsNames) > 0) || (has(self.ipAddresses) && size(self.ipAddresses) > 0) || has(self.serviceRef) || has(self.importFromSecret)",message="at least one of commonName, dnsNames, ipAddresses or serviceRef is required"
+// +kubebuilder:validation:XValidation:rule="has(self.keyAlgorithm) == has(oldSelf.keyAlgorithm) && (!has(self.keyAlgorithm) || self.keyAlgorithm == oldSelf.keyAlgorithm)",message="keyAlgorithm is immutable; recreate the Certificate to change it"
+// +kubebuilder:validation:XValidation:rule="(has(self.keySize) ? self.keySize : 0) == (has(oldSelf.keySize) ? oldSelf.keySize : 0)",message="keySize is immutable; recreate the Certificate to change it"
+// +kubebuilder:validation:XValidation:rule="(has(self.isCA) && self.isCA) == (has(oldSelf.isCA) && oldSelf.isCA)",message="isCA is immutable; recreate the Certificate to change it"
 type CertificateSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// CommonName is the CN for the certificate
-	// +kubebuilder:validation:Required
-	CommonName string `json:"commonName"`
+	// TemplateRef applies a CertificateTemplate's values under this spec
+	// +optional
+	TemplateRef *TemplateRef `json:"templateRef,omitempty"`
+
+	// CommonName is the CN for the certificate. It may be left empty for SAN-only certificates,
+	// which then need DNSNames, IPAddresses or ServiceRef
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Subject fields for the certificate. Unset fields fall back to the controller defaults
+	// +optional
+	Subject *Subject `json:"subject,omitempty"`
 
 	// DNSNames is a list of DNS subject alternative names
 	// +optional
 	DNSNames []string `json:"dnsNames,omitempty"`
 
+	// ServiceRef adds the cluster DNS names of a Service (svc, svc.ns, svc.ns.svc and
+	// svc.ns.svc.<cluster domain>) to DNSNames. The Service is in the Certificate's namespace
+	// +optional
+	ServiceRef *ServiceRef `json:"serviceRef,omitempty"`
+
 	// IPAddresses is a list of IP subject alternative names
 	// +optional
 	IPAddresses []string `json:"ipAddresses,omitempty"`
 
+	// KeyAlgorithm of the private key: RSA (default), ECDSA or Ed25519. Immutable, so an existing
+	// certificate can't be silently weakened; recreate the Certificate to change it.
+	// When neither it nor KeySize is set, the controller's default key applies
+	// +optional
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// KeySize of the private key: 2048 (default), 3072 or 4096 for RSA and 256 (default), 384 or 521
+	// for ECDSA. Ignored for Ed25519. Immutable like KeyAlgorithm
+	// +optional
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// CSRSecretRef signs an externally generated CSR instead of generating a key, so the
+	// private key never reaches the operator. Requires a CA issuer; the CSR's SANs are added to
+	// DNSNames and IPAddresses, and the secret holds only tls.crt and ca.crt
+	// +optional
+	CSRSecretRef *CSRSecretRef `json:"csrSecretRef,omitempty"`
+
+	// IsCA issues a CA certificate, e.g. to bootstrap a root for the CA issuer. CA certificates
+	// default to a 10 year duration and to the controller's CA subject defaults. Immutable
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+
+	// Usages replaces the default key usages. Cert sign and crl sign require IsCA, and server
+	// auth and client auth on a CA certificate require AllowCALeafUsages
+	// +optional
+	// +kubebuilder:validation:items:Enum="digital signature";"key encipherment";"cert sign";"crl sign";"server auth";"client auth";"code signing";"email protection"
+	Usages []string `json:"usages,omitempty"`
+
+	// AllowCALeafUsages permits server auth and client auth usages on a CA certificate, which
+	// many validators reject
+	// +optional
+	AllowCALeafUsages bool `json:"allowCALeafUsages,omitempty"`
+
+	// MaxPathLen limits the number of intermediate CAs below a CA certificate. Unlimited when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPathLen *int32 `json:"maxPathLen,omitempty"`
+
+	// OCSPServers are the OCSP responder URLs written to the Authority Information Access extension
+	// +optional
+	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// IssuingCertificateURLs are the CA Issuers URLs written to the Authority Information Access extension
+	// +optional
+	IssuingCertificateURLs []string `json:"issuingCertificateURLs,omitempty"`
+
+	// ExtraExtensions are added to the certificate verbatim, for OIDs the operator has no field for.
+	// An extension replaces any the operator would otherwise generate with the same OID
+	// +optional
+	ExtraExtensions []RawExtension `json:"extraExtensions,omitempty"`
+
 	// SecretName where the certificate will be stored
 	// +kubebuilder:validation:Required
 	SecretName string `json:"secretName"`
 
-	// Duration for certificate validity (e.g., "2160h" for 90 days)
+	// SecretNamespace writes SecretName to another namespace, e.g. an app namespace when the
+	// Certificate lives in an ops namespace. Owner references can't cross namespaces, so the secret
+	// is tracked by label and deleted with the Certificate. The operator needs secret access in that
+	// namespace, which the default cluster-wide role grants, and the namespace must be listed in the
+	// operator's --allowed-secret-namespaces. Consumers, Ingresses and Gateways are looked up there
+	// too. Empty means the Certificate's namespace
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// ClientCertSecretName splits issuance for mutual TLS: SecretName then gets a server-auth-only
+	// certificate, and a client-auth certificate with the same subject and SANs is written here.
+	// Both are renewed together. Not supported for CA certificates or provided serial numbers
+	// +optional
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+
+	// PublicSecretName writes a second secret with only the certificate, for sidecars such as
+	// Envoy SDS that must not be granted access to the private key. It holds tls.crt (PEM chain),
+	// tls.der (the leaf in DER) and ca.crt when there is one
+	// +optional
+	PublicSecretName string `json:"publicSecretName,omitempty"`
+
+	// KubeconfigSecretName writes a kubeconfig authenticating with the issued certificate and key
+	// to this secret under kubeconfig, for clients of the Kubernetes API or other servers accepting
+	// client certificates. With ClientCertSecretName the client certificate is embedded. Requires
+	// Kubeconfig
+	// +optional
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+
+	// Kubeconfig configures the server the kubeconfig written to KubeconfigSecretName points at
+	// +optional
+	Kubeconfig *Kubeconfig `json:"kubeconfig,omitempty"`
+
+	// DependentSecrets are secrets derived from the certificate, such as a kubeconfig, that are
+	// re-rendered whenever the certificate is issued or renewed
+	// +optional
+	DependentSecrets []DependentSecret `json:"dependentSecrets,omitempty"`
+
+	// Duration for certificate validity (e.g., "2160h" for 90 days). Defaults to the template's, then
+	// 2160h, or 87600h for CA certificates
 	// +optional
-	// +kubebuilder:default="2160h"
 	Duration string `json:"duration,omitempty"`
 
-	// RenewBefore specifies when to renew (e.g., "720h" for 30 days before expiry)
+	// ExpiresAt issues the certificate to expire at this exact time, overriding Duration. It
+	// must be in the future when the certificate is issued
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// RenewBefore specifies when to renew (e.g., "720h" for 30 days before expiry). Defaults to the template's, then 720h
 	// +optional
-	// +kubebuilder:default="720h"
 	RenewBefore string `json:"renewBefore,omitempty"`
 
+	// RenewalWindow holds scheduled renewals until the daily maintenance window opens, unless the
+	// certificate would expire first. Renewals are not held when unset
+	// +optional
+	RenewalWindow *RenewalWindow `json:"renewalWindow,omitempty"`
+
+	// ExpiredRenewalPolicy decides when a certificate that has already expired, e.g. because the
+	// operator was down, is renewed: Immediate (default) ignores RenewalWindow, Window waits for it
+	// +optional
+	// +kubebuilder:validation:Enum=Immediate;Window
+	// +kubebuilder:default=Immediate
+	ExpiredRenewalPolicy string `json:"expiredRenewalPolicy,omitempty"`
+
+	// PEMHeaders adds descriptive Issuer, Serial and Not-After headers to the certificate's PEM
+	// block for legacy tooling. Off by default because strict parsers reject PEM headers
+	// +optional
+	PEMHeaders bool `json:"pemHeaders,omitempty"`
+
+	// PEMFormat controls the exact bytes of the PEM data written to the secret, for strict parsers
+	// that choke on LF line endings or a trailing newline. Unset writes LF with a trailing newline
+	// +optional
+	PEMFormat *PEMFormat `json:"pemFormat,omitempty"`
+
+	// IncludePKCS7 also writes the certificate and its chain to the secret as a DER PKCS#7
+	// bundle under bundle.p7b, for Windows and email clients that import .p7b files
+	// +optional
+	IncludePKCS7 bool `json:"includePKCS7,omitempty"`
+
+	// Profile packages the secret for a specific platform. KafkaTLS adds a JKS keystore and
+	// truststore under keystore.jks and truststore.jks, with their password under
+	// keystore.password and truststore.password, next to the PEM files
+	// +optional
+	// +kubebuilder:validation:Enum=KafkaTLS
+	Profile string `json:"profile,omitempty"`
+
+	// ImmutableSecret marks the secret immutable to prevent accidental edits. Renewals replace
+	// the secret, since an immutable secret can't be updated
+	// +optional
+	ImmutableSecret bool `json:"immutableSecret,omitempty"`
+
+	// SecretTemplate adds labels and annotations to the secret. Changes apply without re-issuing;
+	// keys under cert.example.com/ are reserved for the operator
+	// +optional
+	SecretTemplate *SecretTemplate `json:"secretTemplate,omitempty"`
+
+	// SecretKeys overrides the data keys of the secret
+	// +optional
+	SecretKeys *SecretKeys `json:"secretKeys,omitempty"`
+
 	// IssuerRef references the certificate issuer
 	// +optional
 	IssuerRef IssuerRef `json:"issuerRef,omitempty"`
 
+	// IssuanceTimeout bounds a single issuance, including calls to the issuer (e.g., "30s")
+	// +optional
+	IssuanceTimeout string `json:"issuanceTimeout,omitempty"`
+
 	// RestartDeployments triggers restart of deployments using this cert
 	// +optional
 	RestartDeployments bool `json:"restartDeployments,omitempty"`
+
+	// RestartOnlyOnKeyChange limits RestartDeployments to renewals that changed the private key,
+	// as tracked by Status.PublicKeyPin, for consumers that hot-reload a re-issued certificate.
+	// The key is kept across renewals when it comes from CSRSecretRef
+	// +optional
+	RestartOnlyOnKeyChange bool `json:"restartOnlyOnKeyChange,omitempty"`
+
+	// RestartMinLifetimeGain limits RestartDeployments to renewals whose certificate expires at least
+	// this much later than the one it replaces (e.g. "24h"), so near no-op re-issues don't bounce
+	// consumers. First issuances always restart
+	// +optional
+	RestartMinLifetimeGain string `json:"restartMinLifetimeGain,omitempty"`
+
+	// RestartStrategy decides how RestartDeployments restarts consumers: RollingAnnotation (default)
+	// annotates the pod template so the deployment controller rolls it, DeletePods evicts the
+	// deployment's pods mounting the secret one at a time, waiting for each replacement to become
+	// ready and retrying evictions refused by PodDisruptionBudgets
+	// +optional
+	// +kubebuilder:validation:Enum=RollingAnnotation;DeletePods
+	// +kubebuilder:default=RollingAnnotation
+	RestartStrategy string `json:"restartStrategy,omitempty"`
+
+	// IngressRef points the referenced Ingress's TLS block at SecretName after issuance. An Ingress
+	// can only use secrets in its own namespace, so it is looked up in SecretNamespace when set
+	// +optional
+	IngressRef *IngressRef `json:"ingressRef,omitempty"`
+
+	// GatewayRef points the referenced Gateway's listeners at SecretName after issuance. The
+	// listeners reference the secret without a namespace, so it is looked up in SecretNamespace when set
+	// +optional
+	GatewayRef *GatewayRef `json:"gatewayRef,omitempty"`
+
+	// SerialNumberSource overrides the default random 128-bit serial number
+	// +optional
+	SerialNumberSource *SerialNumberSource `json:"serialNumberSource,omitempty"`
+
+	// SubmitToCTLogs submits each issued certificate to the certificate transparency logs the
+	// controller is configured with (--ct-log-urls) and reports the outcome in the CTSubmitted condition. The returned
+	// SCTs are not embedded in the certificate
+	// +optional
+	SubmitToCTLogs bool `json:"submitToCTLogs,omitempty"`
+
+	// SelfTest verifies the stored key pair (and chain against the CA bundle, when present) after writing the secret
+	// +optional
+	SelfTest bool `json:"selfTest,omitempty"`
+
+	// AdditionalCertificates issues related certificates, each into its own secret, so a small set of
+	// tightly coupled certificates can be managed by one object. Ignored when ImportFromSecret is set
+	// +optional
+	AdditionalCertificates []AdditionalCertificate `json:"additionalCertificates,omitempty"`
+
+	// ImportFromSecret names an externally managed TLS secret in the Certificate's namespace.
+	// When set, nothing is issued: the controller only tracks the imported certificate's expiry
+	// and restarts consumers when it is rotated or enters its renewal window
+	// +optional
+	ImportFromSecret string `json:"importFromSecret,omitempty"`
+
+	// HostPath marks the secret for a node-level distribution DaemonSet, which syncs
+	// tls.crt, tls.key and ca.crt into this absolute directory on every node
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	HostPath string `json:"hostPath,omitempty"`
+
+	// ExternalSecretPath mirrors tls.crt, tls.key and ca.crt as JSON to this path of the external
+	// secret store configured on the controller, e.g. an AWS Secrets Manager secret name, for
+	// consumers outside Kubernetes. The path is prefixed with the Certificate's namespace, e.g.
+	// default/prod/app/tls. The material is mirrored again whenever the certificate changes
+	// +optional
+	ExternalSecretPath string `json:"externalSecretPath,omitempty"`
+
+	// StatusConfigMapName mirrors key status fields into a ConfigMap of this name for dashboards
+	// +optional
+	StatusConfigMapName string `json:"statusConfigMapName,omitempty"`
+
+	// PausedUntil stops reconciliation of this certificate until the given time, after which it
+	// resumes on its own and renewal is re-evaluated
+	// +optional
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty"`
+
+	// RequireApproval holds issuance until an approver sets the Approved status condition to True
+	// with its observedGeneration at the current generation, so a spec change needs a new approval.
+	// Updating status needs RBAC on certificates/status, which the editor role doesn't grant
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
 }
 
 // CertificateStatus defines the observed state of Certificate
@@ -83,9 +552,70 @@ type CertificateStatus struct {
 	// +optional
 	SerialNumber string `json:"serialNumber,omitempty"`
 
+	// Fingerprint is the SHA-256 fingerprint of the current certificate
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// PublicKeyPin is the base64-encoded SHA-256 of the current certificate's SubjectPublicKeyInfo,
+	// for key pinning and detecting unexpected key rotation
+	// +optional
+	PublicKeyPin string `json:"publicKeyPin,omitempty"`
+
+	// CommonName is the CN of the current certificate, used to detect subject changes on re-issue
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// IssuerCommonName is the CN of the issuer that signed the current certificate
+	// +optional
+	IssuerCommonName string `json:"issuerCommonName,omitempty"`
+
+	// ChainLength is the number of certificates in the stored chain, including the leaf
+	// +optional
+	ChainLength int32 `json:"chainLength,omitempty"`
+
+	// SecretName is the secret the current certificate was written to
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretNamespace is the namespace of SecretName when it differs from the Certificate's
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// ExternalSecretPath is the namespaced external secret store path the certificate was last mirrored to
+	// +optional
+	ExternalSecretPath string `json:"externalSecretPath,omitempty"`
+
+	// ExternalSecretFingerprint is the fingerprint of the certificate last mirrored to ExternalSecretPath
+	// +optional
+	ExternalSecretFingerprint string `json:"externalSecretFingerprint,omitempty"`
+
+	// SpecHash is a hash of the spec fields the current certificate was issued from
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// IssuanceWindowStart is when the current one hour issuance budget window started
+	// +optional
+	IssuanceWindowStart *metav1.Time `json:"issuanceWindowStart,omitempty"`
+
+	// IssuancesInWindow is the number of issuances since IssuanceWindowStart
+	// +optional
+	IssuancesInWindow int32 `json:"issuancesInWindow,omitempty"`
+
+	// AdditionalCertificates reports the certificates issued for spec.additionalCertificates
+	// +optional
+	AdditionalCertificates []AdditionalCertificateStatus `json:"additionalCertificates,omitempty"`
+
 	// LastRenewalTime is when the certificate was last renewed
 	// +optional
 	LastRenewalTime *metav1.Time `json:"lastRenewalTime,omitempty"`
+
+	// RestartedWorkloads names the Deployments restarted by the most recent restart pass
+	// +optional
+	RestartedWorkloads []string `json:"restartedWorkloads,omitempty"`
+
+	// LastRestartTime is when RestartedWorkloads were restarted
+	// +optional
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemplateRef references a CertificateTemplate in the Certificate's namespace
+type TemplateRef struct {
+	// Name of the CertificateTemplate
+	Name string `json:"name"`
+}
+
+// CertificateTemplateSpec defines values shared by the Certificates referencing the template.
+// Fields set on a Certificate take precedence over the template
+type CertificateTemplateSpec struct {
+	// Subject fields for the certificate, merged field by field under the Certificate's subject
+	// +optional
+	Subject *Subject `json:"subject,omitempty"`
+
+	// Duration for certificate validity (e.g., "2160h" for 90 days)
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// RenewBefore specifies when to renew (e.g., "720h" for 30 days before expiry)
+	// +optional
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// IssuerRef references the certificate issuer
+	// +optional
+	IssuerRef *IssuerRef `json:"issuerRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=certtemplate
+
+// CertificateTemplate is the Schema for the certificatetemplates API
+type CertificateTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateTemplateSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CertificateTemplateList contains a list of CertificateTemplate
+type CertificateTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CertificateTemplate{}, &CertificateTemplateList{})
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IssuerSpec configures the backend that signs the certificates referencing the Issuer with
+// IssuerRef.Kind=Issuer. Exactly one backend is set
+// +kubebuilder:validation:XValidation:rule="[has(self.selfSigned), has(self.ca), has(self.acme), has(self.vault)].filter(x, x).size() == 1",message="exactly one of selfSigned, ca, acme or vault is required"
+type IssuerSpec struct {
+	// SelfSigned signs each certificate with its own key
+	// +optional
+	SelfSigned *SelfSignedIssuer `json:"selfSigned,omitempty"`
+
+	// CA signs certificates with a CA keypair stored in a secret
+	// +optional
+	CA *CAIssuer `json:"ca,omitempty"`
+
+	// ACME requests certificates from an ACME server such as Let's Encrypt
+	// +optional
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+
+	// Vault requests certificates from a HashiCorp Vault PKI secrets engine
+	// +optional
+	Vault *VaultIssuer `json:"vault,omitempty"`
+}
+
+// SelfSignedIssuer has no configuration
+type SelfSignedIssuer struct{}
+
+// CAIssuer references the CA keypair signing certificates
+type CAIssuer struct {
+	// SecretName is a Secret in the Issuer's namespace holding the CA keypair as tls.crt/tls.key
+	// and optionally ca.crt
+	SecretName string `json:"secretName"`
+
+	// CertKey is the data key of the secret holding the CA certificate, for CA secrets created
+	// by other tools. When neither CertKey nor PrivateKeyKey is set, tls.crt/tls.key is used,
+	// falling back to ca.crt/ca.key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	CertKey string `json:"certKey,omitempty"`
+
+	// PrivateKeyKey is the data key of the secret holding the CA private key. Defaults to tls.key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	PrivateKeyKey string `json:"privateKeyKey,omitempty"`
+}
+
+// ACMEIssuer configures an ACME account
+type ACMEIssuer struct {
+	// Server is the URL of the ACME directory, e.g. https://acme-v02.api.letsencrypt.org/directory
+	// +kubebuilder:validation:Pattern=`^https://`
+	Server string `json:"server"`
+
+	// Email is the contact address registered with the account
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// PrivateKeySecretName is a Secret in the Issuer's namespace holding the account key
+	PrivateKeySecretName string `json:"privateKeySecretName"`
+}
+
+// VaultIssuer configures a Vault PKI secrets engine
+type VaultIssuer struct {
+	// Server is the address of the Vault server, e.g. https://vault.example.com:8200
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Server string `json:"server"`
+
+	// Path is the signing endpoint of the PKI role, e.g. pki/sign/example-dot-com
+	Path string `json:"path"`
+
+	// TokenSecretName is a Secret in the Issuer's namespace holding the Vault token under token
+	// +optional
+	TokenSecretName string `json:"tokenSecretName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Issuer is the Schema for the issuers API
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IssuerSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IssuerList contains a list of Issuer
+type IssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Issuer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Issuer{}, &IssuerList{})
+}
@@ -17,18 +17,33 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/aws/aws-sdk-go-v2/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,7 +51,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+	"github.com/namansharma18899/certificate-management-operator/internal/admin"
+	"github.com/namansharma18899/certificate-management-operator/internal/audit"
+	"github.com/namansharma18899/certificate-management-operator/internal/awskms"
+	"github.com/namansharma18899/certificate-management-operator/internal/awssecrets"
 	"github.com/namansharma18899/certificate-management-operator/internal/controller"
+	"github.com/namansharma18899/certificate-management-operator/internal/ctlog"
+	"github.com/namansharma18899/certificate-management-operator/internal/inspect"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -54,6 +75,18 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	// The inspect subcommand is a read-only debugging aid that doesn't start the manager
+	if len(os.Args) > 1 && os.Args[1] == "inspect" {
+		cmd := inspect.NewCommand(func() (client.Client, error) {
+			return client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		})
+		cmd.SetArgs(os.Args[2:])
+		if err := cmd.ExecuteContext(ctrl.SetupSignalHandler()); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
@@ -61,6 +94,29 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var defaultOrganizations, defaultCountries, defaultOrganizationalUnits string
+	var defaultCAOrganizations, defaultCACountries, defaultCAOrganizationalUnits string
+	var defaultKeyAlgorithm string
+	var defaultKeySize int
+	var minRSAKeySize int
+	var disallowRSA bool
+	var maxConcurrentReconciles, maxIssuancesPerHour, namespaceIssuanceQuota int
+	var minRequeue, maxRequeue time.Duration
+	var maxRestartsPerNamespace, maxConcurrentIssuances int
+	var heartbeatLease, heartbeatLeaseNamespace string
+	var finalizerName string
+	var caCertFile, caKeyFile string
+	var caExpiryWarning time.Duration
+	var auditLog bool
+	var auditLogFile string
+	var onlyLabels string
+	var wildcardPolicy, wildcardExpansionLabels string
+	var allowedSecretNamespaces string
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var issueFromSecrets bool
+	var externalSecretStore, externalKeyManager, awsRegion string
+	var ctLogURLs string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -79,6 +135,84 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&defaultOrganizations, "default-organizations", "",
+		"Comma separated subject organizations for certificates that don't set their own.")
+	flag.StringVar(&defaultCountries, "default-countries", "",
+		"Comma separated subject countries for certificates that don't set their own.")
+	flag.StringVar(&defaultOrganizationalUnits, "default-organizational-units", "",
+		"Comma separated subject organizational units for certificates that don't set their own.")
+	flag.StringVar(&defaultCAOrganizations, "default-ca-organizations", "",
+		"Comma separated subject organizations for CA certificates that don't set their own.")
+	flag.StringVar(&defaultCACountries, "default-ca-countries", "",
+		"Comma separated subject countries for CA certificates that don't set their own.")
+	flag.StringVar(&defaultCAOrganizationalUnits, "default-ca-organizational-units", "",
+		"Comma separated subject organizational units for CA certificates that don't set their own.")
+	flag.StringVar(&defaultKeyAlgorithm, "default-key-algorithm", "",
+		"The key algorithm, RSA, ECDSA or Ed25519, of Certificates that set neither keyAlgorithm nor keySize. Empty means RSA.")
+	flag.IntVar(&defaultKeySize, "default-key-size", 0,
+		"The key size used with --default-key-algorithm. 0 selects the algorithm's default size.")
+	flag.IntVar(&minRSAKeySize, "min-rsa-key-size", 0,
+		"The smallest RSA key, in bits, issued for any Certificate. 0 disables the check.")
+	flag.BoolVar(&disallowRSA, "disallow-rsa", false,
+		"If set, Certificates with RSA keys are rejected; use ECDSA or Ed25519.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The maximum number of Certificates reconciled concurrently.")
+	flag.IntVar(&maxIssuancesPerHour, "max-issuances-per-hour", 0,
+		"The maximum number of times a single Certificate is issued per hour. 0 disables the limit.")
+	flag.IntVar(&namespaceIssuanceQuota, "namespace-issuance-quota", 0,
+		"The maximum number of issuance attempts across all Certificates in a namespace per hour. 0 disables the quota.")
+	flag.DurationVar(&minRequeue, "min-requeue", 0,
+		"The shortest interval between reconciles of an issued Certificate. 0 disables the floor.")
+	flag.DurationVar(&maxRequeue, "max-requeue", 0,
+		"The longest interval between reconciles of an issued Certificate. 0 disables the ceiling.")
+	flag.IntVar(&maxRestartsPerNamespace, "max-restarts-per-namespace", 1,
+		"The maximum number of Certificates restarting deployments in one namespace at a time. 0 disables the limit.")
+	flag.IntVar(&maxConcurrentIssuances, "max-concurrent-issuances", 0,
+		"The maximum number of Certificates issued at a time, soonest-expiry-first. 0 disables the limit.")
+	flag.StringVar(&heartbeatLease, "heartbeat-lease", "",
+		"The name of a Lease renewed after successful reconciles, for external monitoring. Empty disables it.")
+	flag.StringVar(&heartbeatLeaseNamespace, "heartbeat-lease-namespace", "certificate-management-operator-system",
+		"The namespace of the heartbeat Lease.")
+	flag.StringVar(&finalizerName, "finalizer-name", controller.DefaultFinalizer,
+		"The finalizer added to Certificates. Set a distinct name when another build of the operator shares the cluster.")
+	flag.StringVar(&caCertFile, "ca-cert-file", "",
+		"A PEM file with the CA certificate used by CA issuers that don't reference a secret.")
+	flag.StringVar(&caKeyFile, "ca-key-file", "",
+		"A PEM file with the private key of --ca-cert-file.")
+	flag.DurationVar(&caExpiryWarning, "ca-expiry-warning", 30*24*time.Hour,
+		"How long before a CA expires the Certificates it signs are marked CAExpiringSoon. 0 disables the check.")
+	flag.BoolVar(&auditLog, "audit-log", false,
+		"If set, an audit record of every issuance is written to stdout as a JSON line.")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"A file audit records are appended to, in addition to --audit-log.")
+	flag.StringVar(&onlyLabels, "only-labels", "",
+		"A label selector, e.g. tier=prod, limiting reconciliation to matching Certificates. Empty reconciles all of them.")
+	flag.StringVar(&wildcardPolicy, "wildcard-policy", controller.WildcardPolicyAllow,
+		"How wildcard DNS names are handled: Allow, Reject, or Expand into --wildcard-expansion-labels. "+
+			"Wildcard common names are rejected unless this is Allow.")
+	flag.StringVar(&wildcardExpansionLabels, "wildcard-expansion-labels", "",
+		"Comma separated host labels, e.g. www,api, that --wildcard-policy=Expand turns *.example.com into.")
+	flag.StringVar(&allowedSecretNamespaces, "allowed-secret-namespaces", "",
+		"Comma separated namespaces Certificates may write their secret to via secretNamespace, or * for any. "+
+			"Empty keeps every secret in its Certificate's namespace.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"A host:port OTLP gRPC collector that reconcile and issuance traces are exported to. Empty disables tracing.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false,
+		"If set, traces are exported to --otlp-endpoint without TLS.")
+	flag.BoolVar(&issueFromSecrets, "issue-from-secrets", false,
+		"If set, secrets annotated with "+controller.CertificateSpecAnnotation+" are filled with a certificate, "+
+			"without a Certificate object.")
+	flag.StringVar(&externalSecretStore, "external-secret-store", "",
+		"Where Certificates with externalSecretPath mirror their TLS material: aws-secrets-manager, or empty to disable mirroring. "+
+			"Secrets are named after the Certificate's namespace and externalSecretPath, and AWS credentials come from the default credential chain.")
+	flag.StringVar(&externalKeyManager, "external-key-manager", "",
+		"Where the keys of ExternalKey issuers are held: aws-kms, or empty to disable ExternalKey issuers. "+
+			"The issuer name is the KMS key ID, ARN or alias, and AWS credentials come from the default credential chain.")
+	flag.StringVar(&awsRegion, "aws-region", os.Getenv("AWS_REGION"),
+		"The AWS region of --external-secret-store=aws-secrets-manager and --external-key-manager=aws-kms. Defaults to AWS_REGION.")
+	flag.StringVar(&ctLogURLs, "ct-log-urls", "",
+		"Comma-separated base URLs of the certificate transparency logs Certificates with submitToCTLogs are submitted to, "+
+			"e.g. https://ct.example.com/2025h1. Without logs those Certificates report CTSubmitted=False.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -87,6 +221,123 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if maxRequeue > 0 && minRequeue > maxRequeue {
+		setupLog.Error(nil, "min-requeue must not exceed max-requeue", "min-requeue", minRequeue, "max-requeue", maxRequeue)
+		os.Exit(1)
+	}
+
+	if errs := validation.IsQualifiedName(finalizerName); len(errs) > 0 || !strings.Contains(finalizerName, "/") {
+		setupLog.Error(nil, "finalizer-name must be a domain-prefixed qualified name", "finalizer-name", finalizerName)
+		os.Exit(1)
+	}
+
+	if err := controller.ValidateKeySpec(defaultKeyAlgorithm, int32(defaultKeySize)); err != nil {
+		setupLog.Error(err, "invalid default key", "default-key-algorithm", defaultKeyAlgorithm, "default-key-size", defaultKeySize)
+		os.Exit(1)
+	}
+	if err := controller.ValidateKeyPolicy(defaultKeyAlgorithm, int32(defaultKeySize), int32(minRSAKeySize), disallowRSA); err != nil {
+		setupLog.Error(err, "default key violates the key policy", "min-rsa-key-size", minRSAKeySize, "disallow-rsa", disallowRSA)
+		os.Exit(1)
+	}
+
+	if (caCertFile == "") != (caKeyFile == "") {
+		setupLog.Error(nil, "ca-cert-file and ca-key-file must be set together")
+		os.Exit(1)
+	}
+
+	switch wildcardPolicy {
+	case controller.WildcardPolicyAllow, controller.WildcardPolicyReject, controller.WildcardPolicyExpand:
+	default:
+		setupLog.Error(nil, "wildcard-policy must be Allow, Reject or Expand", "wildcard-policy", wildcardPolicy)
+		os.Exit(1)
+	}
+
+	// Left nil without a store, so Certificates asking to be mirrored report it
+	var secretStore controller.ExternalSecretStore
+	switch externalSecretStore {
+	case "":
+	case "aws-secrets-manager":
+		if awsRegion == "" {
+			setupLog.Error(nil, "aws-region is required with external-secret-store=aws-secrets-manager")
+			os.Exit(1)
+		}
+		awsConfig, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(awsRegion))
+		if err != nil {
+			setupLog.Error(err, "unable to load AWS configuration")
+			os.Exit(1)
+		}
+		secretStore = awssecrets.New(awsConfig)
+	default:
+		setupLog.Error(nil, "external-secret-store must be aws-secrets-manager or empty", "external-secret-store", externalSecretStore)
+		os.Exit(1)
+	}
+
+	// Left nil without a key manager, so Certificates using ExternalKey issuers report it
+	var keyManager controller.KeyManager
+	switch externalKeyManager {
+	case "":
+	case "aws-kms":
+		if awsRegion == "" {
+			setupLog.Error(nil, "aws-region is required with external-key-manager=aws-kms")
+			os.Exit(1)
+		}
+		awsConfig, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(awsRegion))
+		if err != nil {
+			setupLog.Error(err, "unable to load AWS configuration")
+			os.Exit(1)
+		}
+		keyManager = awskms.New(awsConfig)
+	default:
+		setupLog.Error(nil, "external-key-manager must be aws-kms or empty", "external-key-manager", externalKeyManager)
+		os.Exit(1)
+	}
+
+	// Left nil without logs, so Certificates asking for CT submission report it
+	var ctSubmitter controller.CTSubmitter
+	if logs := splitList(ctLogURLs); len(logs) > 0 {
+		ctSubmitter = &ctlog.Submitter{LogURLs: logs, Client: &http.Client{Timeout: 30 * time.Second}}
+	}
+
+	var certificateSelector labels.Selector
+	if onlyLabels != "" {
+		selector, err := labels.Parse(onlyLabels)
+		if err != nil {
+			setupLog.Error(err, "only-labels is not a valid label selector", "only-labels", onlyLabels)
+			os.Exit(1)
+		}
+		certificateSelector = selector
+	}
+
+	var auditLogger *audit.Logger
+	var auditSinks []io.Writer
+	if auditLog {
+		auditSinks = append(auditSinks, os.Stdout)
+	}
+	if auditLogFile != "" {
+		file, err := os.OpenFile(auditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log file", "audit-log-file", auditLogFile)
+			os.Exit(1)
+		}
+		// Kept open for the life of the process
+		auditSinks = append(auditSinks, file)
+	}
+	if len(auditSinks) > 0 {
+		auditLogger = audit.NewLogger("certificate-operator", auditSinks...)
+	}
+
+	// Left nil when tracing is disabled, so the reconciler falls back to the global no-op provider
+	var tracerProvider trace.TracerProvider
+	var shutdownTracing func(context.Context) error
+	if otlpEndpoint != "" {
+		provider, err := newTracerProvider(context.Background(), otlpEndpoint, otlpInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up trace exporter", "otlp-endpoint", otlpEndpoint)
+			os.Exit(1)
+		}
+		tracerProvider, shutdownTracing = provider, provider.Shutdown
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -178,15 +429,68 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := (&controller.CertificateReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	certificateReconciler := &controller.CertificateReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("certificate-controller"),
+		APIReader: mgr.GetAPIReader(),
+		DefaultSubject: certv1alpha1.Subject{
+			Organizations:       splitList(defaultOrganizations),
+			Countries:           splitList(defaultCountries),
+			OrganizationalUnits: splitList(defaultOrganizationalUnits),
+		},
+		DefaultCASubject: certv1alpha1.Subject{
+			Organizations:       splitList(defaultCAOrganizations),
+			Countries:           splitList(defaultCACountries),
+			OrganizationalUnits: splitList(defaultCAOrganizationalUnits),
+		},
+		DefaultKeyAlgorithm:     defaultKeyAlgorithm,
+		DefaultKeySize:          int32(defaultKeySize),
+		MinRSAKeySize:           int32(minRSAKeySize),
+		DisallowRSA:             disallowRSA,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		MaxIssuancesPerHour:     maxIssuancesPerHour,
+		NamespaceIssuanceQuota:  namespaceIssuanceQuota,
+		MinRequeue:              minRequeue,
+		MaxRequeue:              maxRequeue,
+		MaxRestartsPerNamespace: maxRestartsPerNamespace,
+		MaxConcurrentIssuances:  maxConcurrentIssuances,
+		HeartbeatLease:          types.NamespacedName{Name: heartbeatLease, Namespace: heartbeatLeaseNamespace},
+		Finalizer:               finalizerName,
+		CACertFile:              caCertFile,
+		CAKeyFile:               caKeyFile,
+		CAExpiryWarning:         caExpiryWarning,
+		AuditLog:                auditLogger,
+		CertificateSelector:     certificateSelector,
+		WildcardPolicy:          wildcardPolicy,
+		WildcardExpansionLabels: splitList(wildcardExpansionLabels),
+		AllowedSecretNamespaces: splitList(allowedSecretNamespaces),
+		TracerProvider:          tracerProvider,
+		ExternalSecretStore:     secretStore,
+		KeyManager:              keyManager,
+		CTSubmitter:             ctSubmitter,
+	}
+	if err := certificateReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Certificate")
 		os.Exit(1)
 	}
+	if issueFromSecrets {
+		if err := (&controller.SecretIssuerReconciler{
+			Client:       mgr.GetClient(),
+			Certificates: certificateReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SecretIssuer")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Served next to /metrics, so it sits behind the same authn/authz filter
+	if err := mgr.AddMetricsServerExtraHandler(admin.CertificatesPath, admin.NewCertificatesHandler(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to set up certificates endpoint")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -197,8 +501,42 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if shutdownTracing != nil {
+		// Flushes the spans still batched for export
+		if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+			setupLog.Error(shutdownErr, "unable to flush traces")
+		}
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// newTracerProvider batches spans to the OTLP gRPC collector at endpoint
+func newTracerProvider(ctx context.Context, endpoint string, insecure bool) (*sdktrace.TracerProvider, error) {
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "certificate-management-operator"))),
+	), nil
+}
+
+// splitList parses a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,81 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// RFC2136Solver publishes challenges with dynamic DNS updates (RFC 2136), optionally signed with TSIG
+type RFC2136Solver struct {
+	// Nameserver is the host:port of the authoritative server accepting updates
+	Nameserver string
+
+	// Zone is the zone containing the challenge records, with a trailing dot
+	Zone string
+
+	// TTL of the challenge records. Defaults to 60 seconds
+	TTL uint32
+
+	// TSIGKeyName, TSIGSecret (base64) and TSIGAlgorithm sign the updates when TSIGKeyName is set.
+	// TSIGAlgorithm defaults to HMAC-SHA256
+	TSIGKeyName   string
+	TSIGSecret    string
+	TSIGAlgorithm string
+
+	// Timeout of a single update exchange. Defaults to 10 seconds
+	Timeout time.Duration
+}
+
+var _ Solver = &RFC2136Solver{}
+
+// Present adds the challenge TXT record
+func (s *RFC2136Solver) Present(ctx context.Context, challenge Challenge) error {
+	return s.update(ctx, challenge, func(msg *dns.Msg, rr dns.RR) { msg.Insert([]dns.RR{rr}) })
+}
+
+// CleanUp removes the challenge TXT record
+func (s *RFC2136Solver) CleanUp(ctx context.Context, challenge Challenge) error {
+	return s.update(ctx, challenge, func(msg *dns.Msg, rr dns.RR) { msg.Remove([]dns.RR{rr}) })
+}
+
+// update sends a single dynamic update built by op for the challenge record
+func (s *RFC2136Solver) update(ctx context.Context, challenge Challenge, op func(*dns.Msg, dns.RR)) error {
+	ttl := s.TTL
+	if ttl == 0 {
+		ttl = 60
+	}
+	rr := &dns.TXT{
+		Hdr: dns.RR_Header{Name: challenge.FQDN, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: ttl},
+		Txt: []string{challenge.Value},
+	}
+
+	msg := new(dns.Msg)
+	msg.SetUpdate(dns.Fqdn(s.Zone))
+	op(msg, rr)
+
+	c := &dns.Client{Timeout: s.Timeout}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if s.TSIGKeyName != "" {
+		keyName := dns.Fqdn(s.TSIGKeyName)
+		algorithm := s.TSIGAlgorithm
+		if algorithm == "" {
+			algorithm = dns.HmacSHA256
+		}
+		msg.SetTsig(keyName, dns.Fqdn(algorithm), 300, time.Now().Unix())
+		c.TsigSecret = map[string]string{keyName: s.TSIGSecret}
+	}
+
+	reply, _, err := c.ExchangeContext(ctx, msg, s.Nameserver)
+	if err != nil {
+		return fmt.Errorf("dns update for %s failed: %w", challenge.FQDN, err)
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("dns update for %s rejected: %s", challenge.FQDN, dns.RcodeToString[reply.Rcode])
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package acme
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"sync"
+
+	"github.com/miekg/dns"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RFC2136 solver", func() {
+	const (
+		keyName = "acme-update."
+		zone    = "example.com."
+	)
+	var (
+		server  *dns.Server
+		mu      sync.Mutex
+		records map[string][]string
+		signed  bool
+		secret  = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	)
+
+	BeforeEach(func() {
+		records = map[string][]string{}
+		signed = false
+
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		server = &dns.Server{
+			PacketConn: conn,
+			TsigSecret: map[string]string{keyName: secret},
+			// The default accept func answers UPDATE messages with NOTIMP
+			MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+			Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+				reply := new(dns.Msg)
+				reply.SetReply(req)
+
+				mu.Lock()
+				signed = req.IsTsig() != nil && w.TsigStatus() == nil
+				for _, rr := range req.Ns {
+					txt, ok := rr.(*dns.TXT)
+					if !ok {
+						continue
+					}
+					if rr.Header().Class == dns.ClassNONE {
+						delete(records, txt.Hdr.Name)
+					} else {
+						records[txt.Hdr.Name] = txt.Txt
+					}
+				}
+				mu.Unlock()
+
+				if req.IsTsig() != nil {
+					reply.SetTsig(keyName, dns.HmacSHA256, 300, int64(req.IsTsig().TimeSigned))
+				}
+				_ = w.WriteMsg(reply)
+			}),
+		}
+		started := make(chan struct{})
+		server.NotifyStartedFunc = func() { close(started) }
+		go func() { _ = server.ActivateAndServe() }()
+		<-started
+	})
+
+	AfterEach(func() {
+		Expect(server.Shutdown()).To(Succeed())
+	})
+
+	It("should add and remove the TXT record with signed updates", func() {
+		solver := &RFC2136Solver{
+			Nameserver:  server.PacketConn.LocalAddr().String(),
+			Zone:        zone,
+			TSIGKeyName: keyName,
+			TSIGSecret:  secret,
+		}
+		challenge := NewChallenge("www.example.com", "token.thumbprint")
+
+		Expect(solver.Present(context.Background(), challenge)).To(Succeed())
+		mu.Lock()
+		Expect(signed).To(BeTrue())
+		Expect(records).To(HaveKeyWithValue("_acme-challenge.www.example.com.", []string{challenge.Value}))
+		mu.Unlock()
+
+		Expect(solver.CleanUp(context.Background(), challenge)).To(Succeed())
+		mu.Lock()
+		Expect(records).NotTo(HaveKey("_acme-challenge.www.example.com."))
+		mu.Unlock()
+	})
+})
@@ -0,0 +1,67 @@
+// Package acme holds the building blocks for ACME issuance, starting with pluggable
+// DNS-01 challenge solvers.
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Challenge is a DNS-01 challenge presented as a TXT record
+type Challenge struct {
+	// Domain is the identifier being validated
+	Domain string
+
+	// FQDN is the fully qualified name of the TXT record, with a trailing dot
+	FQDN string
+
+	// Value is the TXT record content
+	Value string
+}
+
+// NewChallenge builds the DNS-01 challenge for domain from the ACME key authorization (RFC 8555, section 8.4)
+func NewChallenge(domain, keyAuthorization string) Challenge {
+	digest := sha256.Sum256([]byte(keyAuthorization))
+	domain = strings.TrimPrefix(strings.TrimSuffix(domain, "."), "*.")
+	return Challenge{
+		Domain: domain,
+		FQDN:   "_acme-challenge." + domain + ".",
+		Value:  base64.RawURLEncoding.EncodeToString(digest[:]),
+	}
+}
+
+// Solver publishes and removes DNS-01 challenge records with a DNS provider
+type Solver interface {
+	// Present publishes the challenge record
+	Present(ctx context.Context, challenge Challenge) error
+
+	// CleanUp removes the challenge record. It is called for every presented challenge,
+	// whether or not validation succeeded
+	CleanUp(ctx context.Context, challenge Challenge) error
+}
+
+// Solve presents all challenges of an order, runs validate once they are all published and
+// always cleans up the challenges that were presented
+func Solve(ctx context.Context, solver Solver, challenges []Challenge, validate func(context.Context) error) (err error) {
+	var presented []Challenge
+	defer func() {
+		for _, challenge := range presented {
+			if cleanupErr := solver.CleanUp(ctx, challenge); cleanupErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to clean up challenge for %s: %w", challenge.Domain, cleanupErr))
+			}
+		}
+	}()
+
+	for _, challenge := range challenges {
+		if err := solver.Present(ctx, challenge); err != nil {
+			return fmt.Errorf("failed to present challenge for %s: %w", challenge.Domain, err)
+		}
+		presented = append(presented, challenge)
+	}
+
+	return validate(ctx)
+}
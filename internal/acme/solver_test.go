@@ -0,0 +1,83 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSolver records the calls made to it and fails Present for the listed domains
+type fakeSolver struct {
+	calls      []string
+	failDomain string
+}
+
+func (s *fakeSolver) Present(_ context.Context, challenge Challenge) error {
+	s.calls = append(s.calls, "present "+challenge.Domain)
+	if challenge.Domain == s.failDomain {
+		return fmt.Errorf("provider unavailable")
+	}
+	return nil
+}
+
+func (s *fakeSolver) CleanUp(_ context.Context, challenge Challenge) error {
+	s.calls = append(s.calls, "cleanup "+challenge.Domain)
+	return nil
+}
+
+var _ = Describe("DNS-01 solving", func() {
+	challenges := []Challenge{
+		NewChallenge("a.example.com", "token-a.thumbprint"),
+		NewChallenge("b.example.com", "token-b.thumbprint"),
+	}
+
+	It("should derive the challenge record from the key authorization", func() {
+		// Digest from the RFC 8555 example key authorization
+		challenge := NewChallenge("*.example.com", "evaGxfADs6pSRb2LAv9IZf17Dt3juxGJ-PCt92wr-oA.nP1qzpXGymHBrUEepNY9HCsQk7K8KhOypzEt62jcerQ")
+		Expect(challenge.Domain).To(Equal("example.com"))
+		Expect(challenge.FQDN).To(Equal("_acme-challenge.example.com."))
+		Expect(challenge.Value).To(HaveLen(43))
+	})
+
+	It("should present all challenges before validating and clean them up after", func() {
+		solver := &fakeSolver{}
+		validated := false
+
+		err := Solve(context.Background(), solver, challenges, func(context.Context) error {
+			Expect(solver.calls).To(Equal([]string{"present a.example.com", "present b.example.com"}))
+			validated = true
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validated).To(BeTrue())
+		Expect(solver.calls).To(Equal([]string{
+			"present a.example.com", "present b.example.com",
+			"cleanup a.example.com", "cleanup b.example.com",
+		}))
+	})
+
+	It("should clean up presented challenges when a later one fails", func() {
+		solver := &fakeSolver{failDomain: "b.example.com"}
+
+		err := Solve(context.Background(), solver, challenges, func(context.Context) error {
+			Fail("validation must not run")
+			return nil
+		})
+		Expect(err).To(MatchError(ContainSubstring("provider unavailable")))
+		Expect(solver.calls).To(Equal([]string{
+			"present a.example.com", "present b.example.com", "cleanup a.example.com",
+		}))
+	})
+
+	It("should clean up when validation fails", func() {
+		solver := &fakeSolver{}
+
+		err := Solve(context.Background(), solver, challenges, func(context.Context) error {
+			return fmt.Errorf("authorization invalid")
+		})
+		Expect(err).To(MatchError(ContainSubstring("authorization invalid")))
+		Expect(solver.calls).To(ContainElements("cleanup a.example.com", "cleanup b.example.com"))
+	})
+})
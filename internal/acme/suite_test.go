@@ -0,0 +1,14 @@
+package acme
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestACME(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "ACME Suite")
+}
@@ -0,0 +1,76 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WebhookSolver delegates challenges to an external endpoint, for DNS providers without a built-in solver.
+// The endpoint receives a JSON WebhookRequest by POST and must answer with a 2xx status
+type WebhookSolver struct {
+	// URL of the endpoint
+	URL string
+
+	// Client sends the requests. Defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// WebhookRequest is the body posted to the webhook endpoint
+type WebhookRequest struct {
+	// Action is "present" or "cleanup"
+	Action string `json:"action"`
+	Domain string `json:"domain"`
+	FQDN   string `json:"fqdn"`
+	Value  string `json:"value"`
+}
+
+var _ Solver = &WebhookSolver{}
+
+// Present asks the endpoint to publish the challenge record
+func (s *WebhookSolver) Present(ctx context.Context, challenge Challenge) error {
+	return s.call(ctx, "present", challenge)
+}
+
+// CleanUp asks the endpoint to remove the challenge record
+func (s *WebhookSolver) CleanUp(ctx context.Context, challenge Challenge) error {
+	return s.call(ctx, "cleanup", challenge)
+}
+
+// call posts the challenge with the given action to the endpoint
+func (s *WebhookSolver) call(ctx context.Context, action string, challenge Challenge) error {
+	body, err := json.Marshal(WebhookRequest{
+		Action: action,
+		Domain: challenge.Domain,
+		FQDN:   challenge.FQDN,
+		Value:  challenge.Value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := s.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s failed: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook %s returned %s: %s", action, resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package acme
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook solver", func() {
+	It("should post present and cleanup requests", func() {
+		var received []WebhookRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req WebhookRequest
+			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+			received = append(received, req)
+		}))
+		defer server.Close()
+
+		solver := &WebhookSolver{URL: server.URL}
+		challenge := NewChallenge("www.example.com", "token.thumbprint")
+		Expect(solver.Present(context.Background(), challenge)).To(Succeed())
+		Expect(solver.CleanUp(context.Background(), challenge)).To(Succeed())
+
+		Expect(received).To(Equal([]WebhookRequest{
+			{Action: "present", Domain: "www.example.com", FQDN: challenge.FQDN, Value: challenge.Value},
+			{Action: "cleanup", Domain: "www.example.com", FQDN: challenge.FQDN, Value: challenge.Value},
+		}))
+	})
+
+	It("should fail on a non-2xx response", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "zone not found", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		solver := &WebhookSolver{URL: server.URL}
+		err := solver.Present(context.Background(), NewChallenge("www.example.com", "token.thumbprint"))
+		Expect(err).To(MatchError(ContainSubstring("zone not found")))
+	})
+})
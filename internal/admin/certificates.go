@@ -0,0 +1,68 @@
+// Package admin serves read-only operator endpoints for internal tooling.
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// CertificatesPath is where the certificates endpoint is served on the metrics server
+const CertificatesPath = "/certificates"
+
+// CertificateSummary is the JSON representation of a managed Certificate
+type CertificateSummary struct {
+	Namespace    string     `json:"namespace"`
+	Name         string     `json:"name"`
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	NotAfter     *time.Time `json:"notAfter,omitempty"`
+	// Ready is the status of the Ready condition: True, False or Unknown
+	Ready string `json:"ready"`
+}
+
+// NewCertificatesHandler returns a handler listing all Certificates from reader, which is
+// normally the manager's cached client
+func NewCertificatesHandler(reader client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		certificates := &certv1alpha1.CertificateList{}
+		if err := reader.List(r.Context(), certificates); err != nil {
+			log.FromContext(r.Context()).Error(err, "Failed to list certificates")
+			http.Error(w, "failed to list certificates", http.StatusInternalServerError)
+			return
+		}
+
+		summaries := make([]CertificateSummary, 0, len(certificates.Items))
+		for _, cert := range certificates.Items {
+			summary := CertificateSummary{
+				Namespace:    cert.Namespace,
+				Name:         cert.Name,
+				SerialNumber: cert.Status.SerialNumber,
+				Ready:        "Unknown",
+			}
+			if cert.Status.NotAfter != nil {
+				notAfter := cert.Status.NotAfter.UTC()
+				summary.NotAfter = &notAfter
+			}
+			if ready := meta.FindStatusCondition(cert.Status.Conditions, "Ready"); ready != nil {
+				summary.Ready = string(ready.Status)
+			}
+			summaries = append(summaries, summary)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(summaries); err != nil {
+			log.FromContext(r.Context()).Error(err, "Failed to write certificates response")
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Certificates endpoint", func() {
+	It("should return the managed certificates as JSON", func() {
+		scheme := runtime.NewScheme()
+		Expect(certv1alpha1.AddToScheme(scheme)).To(Succeed())
+		notAfter := metav1.NewTime(time.Date(2027, 1, 2, 3, 4, 5, 0, time.UTC))
+		reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&certv1alpha1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"},
+				Status: certv1alpha1.CertificateStatus{
+					SerialNumber: "1a2b",
+					NotAfter:     &notAfter,
+					Conditions: []metav1.Condition{{
+						Type: "Ready", Status: metav1.ConditionTrue, Reason: "CertificateIssued", LastTransitionTime: notAfter,
+					}},
+				},
+			},
+			&certv1alpha1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "dev"},
+			},
+		).Build()
+
+		recorder := httptest.NewRecorder()
+		NewCertificatesHandler(reader).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, CertificatesPath, nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(recorder.Body.String()).To(MatchJSON(`[
+			{"namespace": "dev", "name": "pending", "ready": "Unknown"},
+			{"namespace": "prod", "name": "api", "serialNumber": "1a2b", "notAfter": "2027-01-02T03:04:05Z", "ready": "True"}
+		]`))
+	})
+
+	It("should reject writes", func() {
+		reader := fake.NewClientBuilder().Build()
+		recorder := httptest.NewRecorder()
+		NewCertificatesHandler(reader).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, CertificatesPath, nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
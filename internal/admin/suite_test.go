@@ -0,0 +1,14 @@
+package admin
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAdmin(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Admin Suite")
+}
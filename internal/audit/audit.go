@@ -0,0 +1,78 @@
+// Package audit writes an append-only trail of certificate lifecycle actions, kept apart from
+// the operator's operational logs so it can be shipped and retained separately.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Kind identifies audit records among other output on a shared stream such as stdout
+const Kind = "CertificateAudit"
+
+// Actions recorded in the audit trail
+const (
+	ActionIssued = "Issued"
+)
+
+// Record is one audit entry. Each is written as a single JSON line
+type Record struct {
+	Kind string    `json:"kind"`
+	Time time.Time `json:"time"`
+	// Actor is the identity that performed the action, normally the operator itself
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+
+	// Namespace and Name identify the Certificate; SecretName is where the result was written
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	SecretName string `json:"secretName"`
+
+	SerialNumber string    `json:"serialNumber"`
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	DNSNames     []string  `json:"dnsNames,omitempty"`
+	IPAddresses  []string  `json:"ipAddresses,omitempty"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+}
+
+// Logger writes audit records to every configured sink
+type Logger struct {
+	mu    sync.Mutex
+	sinks []io.Writer
+	actor string
+	now   func() time.Time
+}
+
+// NewLogger returns a Logger recording actor as the identity behind each action
+func NewLogger(actor string, sinks ...io.Writer) *Logger {
+	return &Logger{sinks: sinks, actor: actor, now: time.Now}
+}
+
+// Record stamps the record with its kind, actor and time and appends it to each sink.
+// Every sink is attempted; the first error is returned
+func (l *Logger) Record(record Record) error {
+	record.Kind = Kind
+	record.Actor = l.actor
+	record.Time = l.now().UTC()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	// Serialize writes so concurrent reconciles can't interleave lines
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var firstErr error
+	for _, sink := range l.sinks {
+		if _, err := sink.Write(line); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
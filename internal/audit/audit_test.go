@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+var _ = Describe("Audit logger", func() {
+	It("should write one JSON line per record to every sink", func() {
+		var stdout, file bytes.Buffer
+		logger := NewLogger("certificate-operator", &stdout, &file)
+		logger.now = func() time.Time { return time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC) }
+
+		Expect(logger.Record(Record{Action: ActionIssued, Namespace: "prod", Name: "api", SerialNumber: "1a"})).To(Succeed())
+		Expect(logger.Record(Record{Action: ActionIssued, Namespace: "prod", Name: "web", SerialNumber: "2b"})).To(Succeed())
+
+		Expect(stdout.String()).To(Equal(file.String()))
+		lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+		Expect(lines).To(HaveLen(2))
+
+		var record Record
+		Expect(json.Unmarshal(lines[0], &record)).To(Succeed())
+		Expect(record.Kind).To(Equal(Kind))
+		Expect(record.Actor).To(Equal("certificate-operator"))
+		Expect(record.Time).To(Equal(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)))
+		Expect(record.Name).To(Equal("api"))
+		Expect(record.SerialNumber).To(Equal("1a"))
+	})
+
+	It("should keep writing to the other sinks when one fails", func() {
+		var stdout bytes.Buffer
+		logger := NewLogger("certificate-operator", failingWriter{}, &stdout)
+
+		Expect(logger.Record(Record{Action: ActionIssued})).To(MatchError("disk full"))
+		Expect(stdout.Len()).NotTo(BeZero())
+	})
+})
@@ -0,0 +1,128 @@
+// Package awskms signs certificates with keys held in AWS KMS, so the keys of ExternalKey
+// issuers never leave the key manager.
+package awskms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// signTimeout bounds a Sign call, which crypto.Signer gives no context for
+const signTimeout = 30 * time.Second
+
+// api is the part of the KMS client the key manager uses
+type api interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// KeyManager resolves ExternalKey issuers to asymmetric KMS signing keys. The issuer name is
+// the key ID, ARN or alias. It implements controller.KeyManager
+type KeyManager struct {
+	client api
+}
+
+// New returns a KeyManager calling KMS with cfg, e.g. from config.LoadDefaultConfig
+func New(cfg aws.Config) *KeyManager {
+	return &KeyManager{client: kms.NewFromConfig(cfg)}
+}
+
+// Signer returns a signer for the KMS key keyRef
+func (m *KeyManager) Signer(ctx context.Context, keyRef string) (crypto.Signer, error) {
+	out, err := m.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyRef)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of %s: %w", keyRef, err)
+	}
+	if out.KeyUsage != types.KeyUsageTypeSignVerify {
+		return nil, fmt.Errorf("key %s is for %s, not signing", keyRef, out.KeyUsage)
+	}
+
+	public, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key of %s: %w", keyRef, err)
+	}
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("key %s has unsupported type %T", keyRef, public)
+	}
+	return &signer{client: m.client, keyID: keyRef, public: public}, nil
+}
+
+// signer signs digests with a KMS key
+type signer struct {
+	client api
+	keyID  string
+	public crypto.PublicKey
+}
+
+// Public returns the public key of the KMS key
+func (s *signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign has KMS sign the digest. KMS returns ECDSA signatures ASN.1 encoded, as crypto.Signer expects
+func (s *signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithm, err := signingAlgorithm(s.public, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+	out, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: algorithm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with %s: %w", s.keyID, err)
+	}
+	return out.Signature, nil
+}
+
+// signingAlgorithms maps the kind of signature, and the hash of the digest, to the KMS signing algorithm
+var signingAlgorithms = map[string]map[crypto.Hash]types.SigningAlgorithmSpec{
+	"RSA": {
+		crypto.SHA256: types.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+		crypto.SHA384: types.SigningAlgorithmSpecRsassaPkcs1V15Sha384,
+		crypto.SHA512: types.SigningAlgorithmSpecRsassaPkcs1V15Sha512,
+	},
+	"RSA-PSS": {
+		crypto.SHA256: types.SigningAlgorithmSpecRsassaPssSha256,
+		crypto.SHA384: types.SigningAlgorithmSpecRsassaPssSha384,
+		crypto.SHA512: types.SigningAlgorithmSpecRsassaPssSha512,
+	},
+	"ECDSA": {
+		crypto.SHA256: types.SigningAlgorithmSpecEcdsaSha256,
+		crypto.SHA384: types.SigningAlgorithmSpecEcdsaSha384,
+		crypto.SHA512: types.SigningAlgorithmSpecEcdsaSha512,
+	},
+}
+
+// signingAlgorithm returns the KMS signing algorithm for the key type and signer options
+func signingAlgorithm(public crypto.PublicKey, opts crypto.SignerOpts) (types.SigningAlgorithmSpec, error) {
+	kind := "ECDSA"
+	if _, ok := public.(*rsa.PublicKey); ok {
+		kind = "RSA"
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			kind = "RSA-PSS"
+		}
+	}
+	algorithm, ok := signingAlgorithms[kind][opts.HashFunc()]
+	if !ok {
+		return "", fmt.Errorf("KMS can't sign %s with hash %v", kind, opts.HashFunc())
+	}
+	return algorithm, nil
+}
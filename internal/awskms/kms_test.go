@@ -0,0 +1,124 @@
+package awskms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeKMS signs with in-memory keys the way KMS does, checking the requested algorithm
+type fakeKMS struct {
+	keys  map[string]crypto.Signer
+	usage types.KeyUsageType
+	signs []types.SigningAlgorithmSpec
+}
+
+func (f *fakeKMS) GetPublicKey(_ context.Context, in *kms.GetPublicKeyInput, _ ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	key, ok := f.keys[aws.ToString(in.KeyId)]
+	if !ok {
+		return nil, &types.NotFoundException{Message: aws.String("no such key")}
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GetPublicKeyOutput{KeyId: in.KeyId, PublicKey: der, KeyUsage: f.usage}, nil
+}
+
+func (f *fakeKMS) Sign(_ context.Context, in *kms.SignInput, _ ...func(*kms.Options)) (*kms.SignOutput, error) {
+	if in.MessageType != types.MessageTypeDigest {
+		return nil, errors.New("expected a digest")
+	}
+	f.signs = append(f.signs, in.SigningAlgorithm)
+	key := f.keys[aws.ToString(in.KeyId)]
+	var opts crypto.SignerOpts
+	switch in.SigningAlgorithm {
+	case types.SigningAlgorithmSpecEcdsaSha256, types.SigningAlgorithmSpecRsassaPkcs1V15Sha256:
+		opts = crypto.SHA256
+	case types.SigningAlgorithmSpecEcdsaSha384:
+		opts = crypto.SHA384
+	case types.SigningAlgorithmSpecRsassaPssSha256:
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	default:
+		return nil, errors.New("unexpected signing algorithm")
+	}
+	signature, err := key.Sign(rand.Reader, in.Message, opts)
+	return &kms.SignOutput{Signature: signature, SigningAlgorithm: in.SigningAlgorithm}, err
+}
+
+var _ = Describe("KMS key manager", func() {
+	var fake *fakeKMS
+
+	BeforeEach(func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		fake = &fakeKMS{
+			keys:  map[string]crypto.Signer{"alias/ec": ecKey, "alias/rsa": rsaKey},
+			usage: types.KeyUsageTypeSignVerify,
+		}
+	})
+
+	// selfSign signs a CA certificate with the KMS key and returns it parsed
+	selfSign := func(keyRef string, algorithm x509.SignatureAlgorithm) *x509.Certificate {
+		signer, err := (&KeyManager{client: fake}).Signer(ctx, keyRef)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "KMS CA"},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+			SignatureAlgorithm:    algorithm,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(der)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.CheckSignatureFrom(cert)).To(Succeed())
+		return cert
+	}
+
+	It("should sign certificates with an ECDSA key", func() {
+		selfSign("alias/ec", x509.UnknownSignatureAlgorithm)
+		Expect(fake.signs).To(Equal([]types.SigningAlgorithmSpec{types.SigningAlgorithmSpecEcdsaSha384}))
+	})
+
+	It("should sign certificates with an RSA key", func() {
+		selfSign("alias/rsa", x509.UnknownSignatureAlgorithm)
+		selfSign("alias/rsa", x509.SHA256WithRSAPSS)
+		Expect(fake.signs).To(Equal([]types.SigningAlgorithmSpec{
+			types.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+			types.SigningAlgorithmSpecRsassaPssSha256,
+		}))
+	})
+
+	It("should reject keys that can't sign", func() {
+		fake.usage = types.KeyUsageTypeEncryptDecrypt
+		_, err := (&KeyManager{client: fake}).Signer(ctx, "alias/rsa")
+		Expect(err).To(MatchError(ContainSubstring("not signing")))
+	})
+
+	It("should report unknown keys", func() {
+		_, err := (&KeyManager{client: fake}).Signer(ctx, "alias/missing")
+		var notFound *types.NotFoundException
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+	})
+})
@@ -0,0 +1,17 @@
+package awskms
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx = context.Background()
+
+func TestAWSKMS(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "AWS KMS Suite")
+}
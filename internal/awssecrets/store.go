@@ -0,0 +1,62 @@
+// Package awssecrets mirrors certificates to AWS Secrets Manager, for consumers that read their
+// TLS material from it rather than from Kubernetes secrets.
+package awssecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// api is the part of the Secrets Manager client the store uses
+type api interface {
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput,
+		optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+	CreateSecret(ctx context.Context, params *secretsmanager.CreateSecretInput,
+		optFns ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error)
+}
+
+// Store writes secrets to AWS Secrets Manager. It implements controller.ExternalSecretStore
+type Store struct {
+	client api
+}
+
+// New returns a Store calling Secrets Manager with cfg, e.g. from config.LoadDefaultConfig
+func New(cfg aws.Config) *Store {
+	return &Store{client: secretsmanager.NewFromConfig(cfg)}
+}
+
+// Put stores payload as the current value of the secret named path, creating the secret on
+// first use
+func (s *Store) Put(ctx context.Context, path string, payload []byte) error {
+	err := s.putSecretValue(ctx, path, payload)
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		_, err = s.client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+			Name:         aws.String(path),
+			SecretString: aws.String(string(payload)),
+		})
+		// Another writer created the secret first, so the value goes in as a new version
+		var exists *types.ResourceExistsException
+		if errors.As(err, &exists) {
+			err = s.putSecretValue(ctx, path, payload)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write secret %s to AWS Secrets Manager: %w", path, err)
+	}
+	return nil
+}
+
+// putSecretValue replaces the value of the existing secret named path
+func (s *Store) putSecretValue(ctx context.Context, path string, payload []byte) error {
+	_, err := s.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(path),
+		SecretString: aws.String(string(payload)),
+	})
+	return err
+}
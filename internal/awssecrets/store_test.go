@@ -0,0 +1,83 @@
+package awssecrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSecretsManager keeps secrets in memory the way Secrets Manager does, recording each call
+type fakeSecretsManager struct {
+	secrets map[string]string
+	calls   []string
+	// createdElsewhere creates a secret concurrently with the next CreateSecret
+	createdElsewhere bool
+	err              error
+}
+
+func (f *fakeSecretsManager) PutSecretValue(_ context.Context, in *secretsmanager.PutSecretValueInput,
+	_ ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
+	f.calls = append(f.calls, "PutSecretValue")
+	if f.err != nil {
+		return nil, f.err
+	}
+	if _, ok := f.secrets[aws.ToString(in.SecretId)]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Secrets Manager can't find the specified secret.")}
+	}
+	f.secrets[aws.ToString(in.SecretId)] = aws.ToString(in.SecretString)
+	return &secretsmanager.PutSecretValueOutput{}, nil
+}
+
+func (f *fakeSecretsManager) CreateSecret(_ context.Context, in *secretsmanager.CreateSecretInput,
+	_ ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error) {
+	f.calls = append(f.calls, "CreateSecret")
+	if f.createdElsewhere {
+		f.createdElsewhere = false
+		f.secrets[aws.ToString(in.Name)] = "theirs"
+		return nil, &types.ResourceExistsException{Message: aws.String("The secret already exists.")}
+	}
+	f.secrets[aws.ToString(in.Name)] = aws.ToString(in.SecretString)
+	return &secretsmanager.CreateSecretOutput{}, nil
+}
+
+var _ = Describe("Secrets Manager store", func() {
+	var (
+		fake  *fakeSecretsManager
+		store *Store
+	)
+
+	BeforeEach(func() {
+		fake = &fakeSecretsManager{secrets: map[string]string{}}
+		store = &Store{client: fake}
+	})
+
+	It("should create the secret on first use and update it afterwards", func() {
+		Expect(store.Put(ctx, "default/prod/app/tls", []byte(`{"tls.crt":"v1"}`))).To(Succeed())
+		Expect(store.Put(ctx, "default/prod/app/tls", []byte(`{"tls.crt":"v2"}`))).To(Succeed())
+
+		Expect(fake.secrets).To(Equal(map[string]string{"default/prod/app/tls": `{"tls.crt":"v2"}`}))
+		Expect(fake.calls).To(Equal([]string{"PutSecretValue", "CreateSecret", "PutSecretValue"}))
+	})
+
+	It("should update a secret created concurrently", func() {
+		fake.createdElsewhere = true
+
+		Expect(store.Put(ctx, "default/prod/app/tls", []byte(`{"tls.crt":"v1"}`))).To(Succeed())
+		Expect(fake.secrets).To(Equal(map[string]string{"default/prod/app/tls": `{"tls.crt":"v1"}`}))
+		Expect(fake.calls).To(Equal([]string{"PutSecretValue", "CreateSecret", "PutSecretValue"}))
+	})
+
+	It("should report errors returned by AWS", func() {
+		fake.err = errors.New("AccessDeniedException: not authorized")
+
+		err := store.Put(ctx, "default/prod/app/tls", []byte(`{}`))
+		Expect(err).To(MatchError(ContainSubstring("failed to write secret default/prod/app/tls")))
+		Expect(err).To(MatchError(ContainSubstring("AccessDeniedException")))
+		Expect(fake.calls).To(Equal([]string{"PutSecretValue"}))
+	})
+})
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// reconcileAdditionalCertificates issues the AdditionalCertificates entries that are due and
+// rebuilds their status. It reports whether status changed; entries after a failed one are
+// still reconciled, and the first error is returned
+func (r *CertificateReconciler) reconcileAdditionalCertificates(ctx context.Context, cert *certv1alpha1.Certificate) (bool, error) {
+	var firstErr error
+	changed := len(cert.Status.AdditionalCertificates) != len(cert.Spec.AdditionalCertificates)
+	statuses := make([]certv1alpha1.AdditionalCertificateStatus, 0, len(cert.Spec.AdditionalCertificates))
+
+	for _, entry := range cert.Spec.AdditionalCertificates {
+		previous := findAdditionalStatus(cert.Status.AdditionalCertificates, entry.SecretName)
+		status, issued, err := r.reconcileAdditionalCertificate(ctx, cert, entry, previous)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("additional certificate %s: %w", entry.SecretName, err)
+		}
+		changed = changed || issued
+		statuses = append(statuses, status)
+	}
+
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	cert.Status.AdditionalCertificates = statuses
+	return changed, firstErr
+}
+
+// reconcileAdditionalCertificate issues a single entry when it is due, returning its status
+// and whether it was issued. On failure the previous status is kept
+func (r *CertificateReconciler) reconcileAdditionalCertificate(ctx context.Context, cert *certv1alpha1.Certificate,
+	entry certv1alpha1.AdditionalCertificate, previous certv1alpha1.AdditionalCertificateStatus) (certv1alpha1.AdditionalCertificateStatus, bool, error) {
+	if entry.SecretName == cert.Spec.SecretName || entry.SecretName == cert.Spec.ClientCertSecretName ||
+		entry.SecretName == cert.Spec.PublicSecretName || entry.SecretName == cert.Spec.KubeconfigSecretName {
+		return previous, false, fmt.Errorf("%w: secretName %s is already used by the certificate", ErrInvalidSpec, entry.SecretName)
+	}
+
+	derived := additionalCertificate(cert, entry, previous)
+	if !r.needsRenewal(derived) {
+		return previous, false, nil
+	}
+
+	issued, err := r.generateCertificateWithTimeout(ctx, derived)
+	if err != nil {
+		return previous, false, err
+	}
+	if err := r.writeSecret(ctx, derived, entry.SecretName, issued); err != nil {
+		return previous, false, err
+	}
+	countIssuance(derived)
+	r.auditIssuance(ctx, cert, entry.SecretName, issued)
+
+	return certv1alpha1.AdditionalCertificateStatus{
+		SecretName:   entry.SecretName,
+		SerialNumber: issued.serialNumber,
+		NotAfter:     &metav1.Time{Time: issued.notAfter},
+		RenewalTime:  r.calculateRenewalTime(derived, issued.notAfter),
+		SpecHash:     issuanceSpecHash(derived),
+	}, true, nil
+}
+
+// additionalCertificate derives the Certificate an entry is issued from. It shares the parent's
+// metadata, so the parent owns the entry's secret
+func additionalCertificate(cert *certv1alpha1.Certificate, entry certv1alpha1.AdditionalCertificate,
+	previous certv1alpha1.AdditionalCertificateStatus) *certv1alpha1.Certificate {
+	derived := cert.DeepCopy()
+	derived.Spec.CommonName = entry.CommonName
+	derived.Spec.DNSNames = entry.DNSNames
+	derived.Spec.IPAddresses = entry.IPAddresses
+	derived.Spec.SecretName = entry.SecretName
+	if entry.Duration != "" {
+		derived.Spec.Duration = entry.Duration
+		derived.Spec.ExpiresAt = nil
+	}
+	if entry.RenewBefore != "" {
+		derived.Spec.RenewBefore = entry.RenewBefore
+	}
+
+	// Settings that only make sense for the main certificate
+	derived.Spec.ServiceRef = nil
+	derived.Spec.IsCA = false
+	derived.Spec.MaxPathLen = nil
+	derived.Spec.CSRSecretRef = nil
+	derived.Spec.ClientCertSecretName = ""
+	derived.Spec.PublicSecretName = ""
+	derived.Spec.KubeconfigSecretName = ""
+	derived.Spec.Kubeconfig = nil
+	derived.Spec.DependentSecrets = nil
+	derived.Spec.HostPath = ""
+	derived.Spec.AdditionalCertificates = nil
+	if source := derived.Spec.SerialNumberSource; source != nil && source.Type == serialSourceProvided {
+		derived.Spec.SerialNumberSource = nil
+	}
+
+	derived.Status = certv1alpha1.CertificateStatus{
+		SecretName:   previous.SecretName,
+		SerialNumber: previous.SerialNumber,
+		NotAfter:     previous.NotAfter,
+		RenewalTime:  previous.RenewalTime,
+		SpecHash:     previous.SpecHash,
+	}
+	return derived
+}
+
+// findAdditionalStatus returns the status recorded for the entry writing secretName
+func findAdditionalStatus(statuses []certv1alpha1.AdditionalCertificateStatus, secretName string) certv1alpha1.AdditionalCertificateStatus {
+	for _, status := range statuses {
+		if status.SecretName == secretName {
+			return status
+		}
+	}
+	return certv1alpha1.AdditionalCertificateStatus{SecretName: secretName}
+}
+
+// earliestRenewalTime returns the soonest renewal time of the certificate and its additional certificates
+func earliestRenewalTime(cert *certv1alpha1.Certificate) *metav1.Time {
+	earliest := cert.Status.RenewalTime
+	for _, status := range cert.Status.AdditionalCertificates {
+		if status.RenewalTime != nil && (earliest == nil || status.RenewalTime.Before(earliest)) {
+			earliest = status.RenewalTime
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Additional certificates", func() {
+	newBundleCertificate := func() *certv1alpha1.Certificate {
+		cert := newTestCertificate("bundle")
+		cert.Spec.AdditionalCertificates = []certv1alpha1.AdditionalCertificate{
+			{CommonName: "api.example.com", DNSNames: []string{"api.example.com"}, SecretName: "bundle-api-tls"},
+			{CommonName: "admin.example.com", DNSNames: []string{"admin.example.com"}, SecretName: "bundle-admin-tls"},
+		}
+		return cert
+	}
+
+	reconcileBundle := func(r *CertificateReconciler, cert *certv1alpha1.Certificate) *certv1alpha1.Certificate {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return updated
+	}
+
+	It("should issue each entry into its own secret", func() {
+		cert := newBundleCertificate()
+		r := newFakeReconciler(cert)
+		updated := reconcileBundle(r, cert)
+
+		for _, entry := range cert.Spec.AdditionalCertificates {
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: entry.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			parsed := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+			Expect(parsed.Subject.CommonName).To(Equal(entry.CommonName))
+			Expect(parsed.DNSNames).To(Equal(entry.DNSNames))
+			Expect(verifySecretKeyPair(secret)).To(Succeed())
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Name).To(Equal(cert.Name))
+		}
+
+		Expect(updated.Status.AdditionalCertificates).To(HaveLen(2))
+		Expect(updated.Status.AdditionalCertificates[0].SecretName).To(Equal("bundle-api-tls"))
+		Expect(updated.Status.AdditionalCertificates[0].SerialNumber).NotTo(BeEmpty())
+		Expect(updated.Status.AdditionalCertificates[0].SerialNumber).NotTo(Equal(updated.Status.AdditionalCertificates[1].SerialNumber))
+	})
+
+	It("should renew entries independently", func() {
+		cert := newBundleCertificate()
+		r := newFakeReconciler(cert)
+		first := reconcileBundle(r, cert)
+
+		// Only the admin certificate is due
+		due := first.DeepCopy()
+		due.Status.AdditionalCertificates[1].RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, due)).To(Succeed())
+
+		second := reconcileBundle(r, due)
+		Expect(second.Status.SerialNumber).To(Equal(first.Status.SerialNumber))
+		Expect(second.Status.AdditionalCertificates[0].SerialNumber).To(Equal(first.Status.AdditionalCertificates[0].SerialNumber))
+		Expect(second.Status.AdditionalCertificates[1].SerialNumber).NotTo(Equal(first.Status.AdditionalCertificates[1].SerialNumber))
+		Expect(second.Status.AdditionalCertificates[1].RenewalTime.Time).To(BeTemporally(">", time.Now()))
+
+		// Changing one entry re-issues only that entry
+		second.Spec.AdditionalCertificates[0].DNSNames = append(second.Spec.AdditionalCertificates[0].DNSNames, "api.example.org")
+		Expect(r.Update(ctx, second)).To(Succeed())
+		third := reconcileBundle(r, second)
+		Expect(third.Status.AdditionalCertificates[0].SerialNumber).NotTo(Equal(second.Status.AdditionalCertificates[0].SerialNumber))
+		Expect(third.Status.AdditionalCertificates[1].SerialNumber).To(Equal(second.Status.AdditionalCertificates[1].SerialNumber))
+		Expect(third.Status.SerialNumber).To(Equal(first.Status.SerialNumber))
+	})
+
+	It("should requeue for the earliest renewal", func() {
+		cert := newTestCertificate("bundle-requeue")
+		soon := metav1.NewTime(time.Now().Add(2 * time.Hour))
+		cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(30 * 24 * time.Hour)}
+		cert.Status.AdditionalCertificates = []certv1alpha1.AdditionalCertificateStatus{{SecretName: "soon-tls", RenewalTime: &soon}}
+		Expect(newFakeReconciler().getRequeueTime(ctx, cert)).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should reject an entry reusing the main secret", func() {
+		cert := newTestCertificate("bundle-clash")
+		cert.Spec.AdditionalCertificates = []certv1alpha1.AdditionalCertificate{{CommonName: "clash.example.com", SecretName: cert.Spec.SecretName}}
+		_, err := newFakeReconciler(cert).reconcileAdditionalCertificates(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"fmt"
+	"net/url"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// validateAIAURLs checks that the Authority Information Access URLs are absolute HTTP(S) URLs,
+// which is what relying parties outside the cluster fetch
+func validateAIAURLs(cert *certv1alpha1.Certificate) error {
+	for _, field := range []struct {
+		name string
+		urls []string
+	}{
+		{"ocspServers", cert.Spec.OCSPServers},
+		{"issuingCertificateURLs", cert.Spec.IssuingCertificateURLs},
+	} {
+		for _, raw := range field.urls {
+			parsed, err := url.Parse(raw)
+			if err != nil {
+				return fmt.Errorf("%w: invalid %s URL %q: %w", ErrInvalidSpec, field.name, raw, err)
+			}
+			if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("%w: %s URL %q must be an absolute http or https URL", ErrInvalidSpec, field.name, raw)
+			}
+		}
+	}
+	return nil
+}
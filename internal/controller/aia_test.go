@@ -0,0 +1,41 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authority Information Access", func() {
+	It("should write OCSP and CA Issuers URLs to the certificate", func() {
+		cert := newTestCertificate("aia")
+		cert.Spec.OCSPServers = []string{"http://ocsp.example.com"}
+		cert.Spec.IssuingCertificateURLs = []string{"http://pki.example.com/ca.crt", "https://pki.example.com/ca.der"}
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.OCSPServer).To(Equal([]string{"http://ocsp.example.com"}))
+		Expect(parsed.IssuingCertificateURL).To(Equal([]string{"http://pki.example.com/ca.crt", "https://pki.example.com/ca.der"}))
+	})
+
+	It("should omit the extension by default", func() {
+		issued, err := newFakeReconciler().generateCertificate(ctx, newTestCertificate("no-aia"))
+		Expect(err).NotTo(HaveOccurred())
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.OCSPServer).To(BeEmpty())
+		Expect(parsed.IssuingCertificateURL).To(BeEmpty())
+	})
+
+	DescribeTable("should reject malformed URLs",
+		func(ocsp, issuing []string) {
+			cert := newTestCertificate("bad-aia")
+			cert.Spec.OCSPServers = ocsp
+			cert.Spec.IssuingCertificateURLs = issuing
+			_, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		},
+		Entry("relative OCSP URL", []string{"ocsp.example.com"}, nil),
+		Entry("unsupported scheme", nil, []string{"ldap://pki.example.com/ca"}),
+		Entry("unparseable URL", []string{"http://[::1"}, nil),
+	)
+})
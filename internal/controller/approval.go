@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typeApprovedCert is set by an approver, never by the controller, to release issuance of a
+// certificate with RequireApproval. False denies issuance until it is set to True. The approval
+// covers the spec generation in its observedGeneration, so any later spec change needs a new one
+const typeApprovedCert = "Approved"
+
+// pendingApproval reports whether issuance is held for approval, setting Ready to PendingApproval
+// when it is, and whether that changed the status. Status changes trigger a reconcile, so
+// issuance resumes as soon as the certificate is approved
+func pendingApproval(cert *certv1alpha1.Certificate) (bool, bool) {
+	if !cert.Spec.RequireApproval {
+		return false, false
+	}
+	approved := meta.FindStatusCondition(cert.Status.Conditions, typeApprovedCert)
+	if approved != nil && approved.Status == metav1.ConditionTrue && approved.ObservedGeneration == cert.Generation {
+		return false, false
+	}
+
+	message := "Issuance is waiting for the Approved condition to be set to True"
+	switch {
+	case approved == nil:
+	case approved.Status == metav1.ConditionFalse:
+		message = fmt.Sprintf("Issuance was denied (%s): %s", approved.Reason, approved.Message)
+	case approved.Status == metav1.ConditionTrue:
+		message = fmt.Sprintf("Issuance was approved for generation %d, but the spec is at generation %d and needs a new approval",
+			approved.ObservedGeneration, cert.Generation)
+	}
+	changed := meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeReadyCert,
+		Status:             metav1.ConditionFalse,
+		Reason:             "PendingApproval",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	return true, changed
+}
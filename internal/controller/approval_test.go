@@ -0,0 +1,120 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Issuance approval", func() {
+	setApproved := func(r *CertificateReconciler, key client.ObjectKey, status metav1.ConditionStatus, reason string) {
+		cert := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, key, cert)).To(Succeed())
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeApprovedCert,
+			Status:             status,
+			Reason:             reason,
+			Message:            "reviewed by security",
+			ObservedGeneration: cert.Generation,
+		})
+		ExpectWithOffset(1, r.Status().Update(ctx, cert)).To(Succeed())
+	}
+
+	It("should hold issuance until the certificate is approved", func() {
+		cert := newTestCertificate("approval")
+		cert.Spec.RequireApproval = true
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		pending := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, pending)).To(Succeed())
+		ready := meta.FindStatusCondition(pending.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("PendingApproval"))
+		Expect(pending.Status.SerialNumber).To(BeEmpty())
+		Expect(errors.IsNotFound(r.Get(ctx, secretKey, &corev1.Secret{}))).To(BeTrue())
+
+		By("approving the certificate")
+		setApproved(r, req.NamespacedName, metav1.ConditionTrue, "Approved")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		approved := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, approved)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(approved.Status.Conditions, typeReadyCert)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(approved.Status.Conditions, typeApprovedCert)).To(BeTrue())
+		Expect(approved.Status.SerialNumber).NotTo(BeEmpty())
+		Expect(r.Get(ctx, secretKey, &corev1.Secret{})).To(Succeed())
+	})
+
+	It("should hold issuance again when the spec changes after approval", func() {
+		cert := newTestCertificate("approval-spec-change")
+		cert.Spec.RequireApproval = true
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		setApproved(r, req.NamespacedName, metav1.ConditionTrue, "Approved")
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		issued := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, issued)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(issued.Status.Conditions, typeReadyCert)).To(BeTrue())
+		serialNumber := issued.Status.SerialNumber
+
+		By("adding a DNS name without a new approval")
+		issued.Spec.DNSNames = append(issued.Spec.DNSNames, "added.example.com")
+		// The fake client doesn't bump the generation on spec changes the way the API server does
+		issued.Generation++
+		Expect(r.Update(ctx, issued)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		changed := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, changed)).To(Succeed())
+		ready := meta.FindStatusCondition(changed.Status.Conditions, typeReadyCert)
+		Expect(ready.Reason).To(Equal("PendingApproval"))
+		Expect(ready.Message).To(ContainSubstring("needs a new approval"))
+		Expect(changed.Status.SerialNumber).To(Equal(serialNumber))
+
+		By("approving the new generation")
+		setApproved(r, req.NamespacedName, metav1.ConditionTrue, "Approved")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		reissued := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, reissued)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(reissued.Status.Conditions, typeReadyCert)).To(BeTrue())
+		Expect(reissued.Status.SerialNumber).NotTo(Equal(serialNumber))
+	})
+
+	It("should keep a denied certificate pending with the denial reason", func() {
+		cert := newTestCertificate("approval-denied")
+		cert.Spec.RequireApproval = true
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		setApproved(r, req.NamespacedName, metav1.ConditionFalse, "Denied")
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		denied := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, denied)).To(Succeed())
+		ready := meta.FindStatusCondition(denied.Status.Conditions, typeReadyCert)
+		Expect(ready.Reason).To(Equal("PendingApproval"))
+		Expect(ready.Message).To(ContainSubstring("denied (Denied): reviewed by security"))
+		Expect(denied.Status.SerialNumber).To(BeEmpty())
+	})
+
+	It("should issue certificates that don't require approval", func() {
+		cert := newTestCertificate("approval-not-required")
+		Expect(pendingApproval(cert)).To(BeFalse())
+	})
+})
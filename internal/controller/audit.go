@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+	"github.com/namansharma18899/certificate-management-operator/internal/audit"
+)
+
+// auditIssuance records an issuance written to secretName in the audit trail, if one is configured.
+// A failed audit write is logged but doesn't undo the issuance
+func (r *CertificateReconciler) auditIssuance(ctx context.Context, cert *certv1alpha1.Certificate, secretName string, issued *issuedCertificate) {
+	if r.AuditLog == nil {
+		return
+	}
+
+	record := audit.Record{
+		Action:       audit.ActionIssued,
+		Namespace:    cert.Namespace,
+		Name:         cert.Name,
+		SecretName:   secretName,
+		SerialNumber: issued.serialNumber,
+		NotBefore:    issued.notBefore.UTC(),
+		NotAfter:     issued.notAfter.UTC(),
+	}
+	if block, _ := pem.Decode(issued.certPEM); block != nil {
+		if leaf, err := x509.ParseCertificate(block.Bytes); err == nil {
+			record.Subject = leaf.Subject.String()
+			record.Issuer = leaf.Issuer.String()
+			record.DNSNames = leaf.DNSNames
+			for _, ip := range leaf.IPAddresses {
+				record.IPAddresses = append(record.IPAddresses, ip.String())
+			}
+		}
+	}
+
+	if err := r.AuditLog.Record(record); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to write audit record", "serialNumber", issued.serialNumber)
+	}
+}
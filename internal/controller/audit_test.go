@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+	"github.com/namansharma18899/certificate-management-operator/internal/audit"
+)
+
+var _ = Describe("Audit trail", func() {
+	It("should write exactly one audit record per issuance", func() {
+		cert := newTestCertificate("audited")
+		cert.Spec.DNSNames = []string{"audited.example.com", "www.audited.example.com"}
+		cert.Spec.IPAddresses = []string{"10.0.0.9"}
+		r := newFakeReconciler(cert)
+		var sink bytes.Buffer
+		r.AuditLog = audit.NewLogger("certificate-operator", &sink)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		// Nothing is issued while the certificate is current
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := bytes.Split(bytes.TrimSpace(sink.Bytes()), []byte("\n"))
+		Expect(lines).To(HaveLen(1))
+		var record audit.Record
+		Expect(json.Unmarshal(lines[0], &record)).To(Succeed())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(record.Kind).To(Equal(audit.Kind))
+		Expect(record.Action).To(Equal(audit.ActionIssued))
+		Expect(record.Actor).To(Equal("certificate-operator"))
+		Expect(record.Namespace).To(Equal("default"))
+		Expect(record.Name).To(Equal("audited"))
+		Expect(record.SecretName).To(Equal("audited-tls"))
+		Expect(record.SerialNumber).To(Equal(updated.Status.SerialNumber))
+		Expect(record.Subject).To(ContainSubstring("CN=audited.example.com"))
+		Expect(record.DNSNames).To(ConsistOf("audited.example.com", "www.audited.example.com"))
+		Expect(record.IPAddresses).To(ConsistOf("10.0.0.9"))
+		Expect(record.NotAfter).To(BeTemporally("~", updated.Status.NotAfter.Time, time.Second))
+		Expect(record.Time).NotTo(BeZero())
+	})
+
+	It("should not require an audit log", func() {
+		cert := newTestCertificate("unaudited")
+		r := newFakeReconciler(cert)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// issuanceBudgetWindow is the period MaxIssuancesPerHour is counted over
+const issuanceBudgetWindow = time.Hour
+
+// issuanceBudgetExhausted reports whether the certificate used up its issuance budget, and if so
+// how long until the window resets. Starts a new window in status when the previous one ended
+func (r *CertificateReconciler) issuanceBudgetExhausted(cert *certv1alpha1.Certificate) (bool, time.Duration) {
+	if r.MaxIssuancesPerHour <= 0 {
+		return false, 0
+	}
+
+	now := time.Now()
+	start := cert.Status.IssuanceWindowStart
+	if start == nil || now.Sub(start.Time) >= issuanceBudgetWindow {
+		cert.Status.IssuanceWindowStart = &metav1.Time{Time: now}
+		cert.Status.IssuancesInWindow = 0
+		return false, 0
+	}
+
+	if int(cert.Status.IssuancesInWindow) < r.MaxIssuancesPerHour {
+		return false, 0
+	}
+	return true, start.Add(issuanceBudgetWindow).Sub(now)
+}
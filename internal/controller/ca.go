@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// issuerKindCA signs certificates with a CA keypair stored in a secret
+	issuerKindCA = "CA"
+
+	// pinCAFingerprintAnnotation pins the CA version a certificate is signed with to the CA
+	// certificate with this SHA-256 fingerprint, for staged CA migrations
+	pinCAFingerprintAnnotation = "cert.example.com/pin-ca-fingerprint"
+
+	// caHistoryLabel marks secrets holding previous versions of a CA issuer's keypair. Its value
+	// is the name of the issuer secret; pinned certificates are signed from these after rotation
+	caHistoryLabel = "cert.example.com/ca-history"
+
+	// caPrivateKeyKey holds the CA private key in issuer secrets following the ca.crt/ca.key convention
+	caPrivateKeyKey = "ca.key"
+)
+
+// caIssuer is a CA keypair loaded from an issuer secret
+type caIssuer struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	// chainPEM is the CA certificate and its own chain, appended to issued leaves
+	chainPEM []byte
+	// caPEM is the CA chain up to the trust anchor, written to ca.crt of issued secrets
+	caPEM []byte
+}
+
+// loadCA reads the CA keypair from the secret named by the certificate's issuer reference,
+// or from its history when the certificate is pinned to a previous CA version. Without a
+// secret name, the CA files configured on the controller are used
+func (r *CertificateReconciler) loadCA(ctx context.Context, cert *certv1alpha1.Certificate) (*caIssuer, error) {
+	if cert.Spec.IssuerRef.Name == "" {
+		return r.loadFileCA()
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: cert.Spec.IssuerRef.Name, Namespace: cert.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("%w: failed to get CA secret %s: %w", ErrCALoad, key.Name, err)
+	}
+
+	ref := cert.Spec.IssuerRef
+	pin, pinned := cert.Annotations[pinCAFingerprintAnnotation]
+	if !pinned || certificateFingerprint(caCertificatePEM(ref, secret)) == pin {
+		return parseCA(ref, secret)
+	}
+
+	history := &corev1.SecretList{}
+	if err := r.List(ctx, history, client.InNamespace(cert.Namespace), client.MatchingLabels{caHistoryLabel: key.Name}); err != nil {
+		return nil, fmt.Errorf("%w: failed to list history of CA %s: %w", ErrCALoad, key.Name, err)
+	}
+	for i := range history.Items {
+		if certificateFingerprint(caCertificatePEM(ref, &history.Items[i])) == pin {
+			return parseCA(ref, &history.Items[i])
+		}
+	}
+	return nil, fmt.Errorf("%w: no version of CA %s has the pinned fingerprint %s", ErrCALoad, key.Name, pin)
+}
+
+// loadFileCA reads the CA keypair from the files mounted into the controller. They are read on
+// every issuance so a rotated mount takes effect without a restart
+func (r *CertificateReconciler) loadFileCA() (*caIssuer, error) {
+	if r.CACertFile == "" || r.CAKeyFile == "" {
+		return nil, fmt.Errorf("%w: the CA issuer references no secret and no CA files are configured", ErrInvalidSpec)
+	}
+	chainPEM, err := os.ReadFile(r.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CA certificate file: %w", ErrCALoad, err)
+	}
+	keyPEM, err := os.ReadFile(r.CAKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CA key file: %w", ErrCALoad, err)
+	}
+	return parseCAKeyPair(r.CACertFile, chainPEM, keyPEM, nil)
+}
+
+// caSecretKeys returns the data keys holding the CA certificate and private key in an issuer
+// secret. Without keys on the issuer reference, tls.crt/tls.key is used unless the secret only
+// follows the ca.crt/ca.key convention
+func caSecretKeys(ref certv1alpha1.IssuerRef, secret *corev1.Secret) (string, string) {
+	if ref.CertKey != "" || ref.PrivateKeyKey != "" {
+		certKey, keyKey := corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+		if ref.CertKey != "" {
+			certKey = ref.CertKey
+		}
+		if ref.PrivateKeyKey != "" {
+			keyKey = ref.PrivateKeyKey
+		}
+		return certKey, keyKey
+	}
+
+	_, hasTLSCert := secret.Data[corev1.TLSCertKey]
+	_, hasCACert := secret.Data[defaultCASecretKey]
+	_, hasCAKey := secret.Data[caPrivateKeyKey]
+	if !hasTLSCert && hasCACert && hasCAKey {
+		return defaultCASecretKey, caPrivateKeyKey
+	}
+	return corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+}
+
+// caCertificatePEM returns the CA certificate held in an issuer secret
+func caCertificatePEM(ref certv1alpha1.IssuerRef, secret *corev1.Secret) []byte {
+	certKey, _ := caSecretKeys(ref, secret)
+	return secret.Data[certKey]
+}
+
+// parseCA parses and validates the CA keypair held in a secret. ca.crt, when the keypair isn't
+// stored there, holds the rest of the chain up to the root
+func parseCA(ref certv1alpha1.IssuerRef, secret *corev1.Secret) (*caIssuer, error) {
+	certKey, keyKey := caSecretKeys(ref, secret)
+	return parseCAKeyPair("secret "+secret.Name, secret.Data[certKey], secret.Data[keyKey], secret.Data[defaultCASecretKey])
+}
+
+// parseCAKeyPair parses and validates a PEM CA keypair read from source. The CA may be an
+// intermediate whose root is kept offline: chainPEM then holds the intermediate and any chain
+// above it that issued leaves should carry, and caPEM optionally holds the rest up to the root.
+// The trust bundle written to issued secrets is the full chain of both
+func parseCAKeyPair(source string, chainPEM, keyPEM, caPEM []byte) (*caIssuer, error) {
+	keyPair, err := tls.X509KeyPair(chainPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid keypair in %s: %w", ErrCALoad, source, err)
+	}
+
+	caCert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse CA certificate: %w", ErrCALoad, err)
+	}
+	if !caCert.IsCA {
+		return nil, fmt.Errorf("%w: certificate in %s is not a CA", ErrCALoad, source)
+	}
+
+	signer, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported private key type %T", ErrCALoad, keyPair.PrivateKey)
+	}
+
+	caPEM, err = mergeCertificatesPEM(chainPEM, caPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CA bundle in %s: %w", ErrCALoad, source, err)
+	}
+
+	return &caIssuer{
+		cert:     caCert,
+		key:      signer,
+		chainPEM: chainPEM,
+		caPEM:    caPEM,
+	}, nil
+}
+
+// mergeCertificatesPEM concatenates the certificates of the PEM bundles in order, dropping
+// duplicates and any non-certificate blocks
+func mergeCertificatesPEM(bundles ...[]byte) ([]byte, error) {
+	var merged []byte
+	seen := map[string]bool{}
+	for _, bundle := range bundles {
+		for rest := bundle; ; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" || seen[string(block.Bytes)] {
+				continue
+			}
+			if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+				return nil, err
+			}
+			seen[string(block.Bytes)] = true
+			merged = append(merged, pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes})...)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typeCAExpiringSoonCert warns that the CA signing the certificate expires within
+// CAExpiryWarning. Every leaf it issued stops validating with it, so the CA needs rotating
+const typeCAExpiringSoonCert = "CAExpiringSoon"
+
+// reconcileCAExpiry sets the CAExpiringSoon condition from the CA's expiry and reports whether
+// it changed. Certificates whose CA was never close to expiry get no condition
+func reconcileCAExpiry(cert *certv1alpha1.Certificate, ca *caIssuer, warning time.Duration, now time.Time) bool {
+	remaining := ca.cert.NotAfter.Sub(now)
+	if remaining > warning {
+		if meta.FindStatusCondition(cert.Status.Conditions, typeCAExpiringSoonCert) == nil {
+			return false
+		}
+		return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeCAExpiringSoonCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "CAValid",
+			Message:            fmt.Sprintf("CA %s is valid until %s", ca.cert.Subject.CommonName, ca.cert.NotAfter.UTC().Format(time.RFC3339)),
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	reason, message := "CAExpiresSoon", fmt.Sprintf("CA %s expires at %s; rotate it before the certificates it signed stop validating",
+		ca.cert.Subject.CommonName, ca.cert.NotAfter.UTC().Format(time.RFC3339))
+	if remaining <= 0 {
+		reason, message = "CAExpired", fmt.Sprintf("CA %s expired at %s", ca.cert.Subject.CommonName, ca.cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeCAExpiringSoonCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// syncCAExpiry checks the expiry of the CA signing a CA-issued certificate and records it in
+// status when it changed, with a Warning event as the CA enters the warning window
+func (r *CertificateReconciler) syncCAExpiry(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if r.CAExpiryWarning <= 0 || issuerKind(cert) != issuerKindCA {
+		return nil
+	}
+
+	ca, err := r.loadCA(ctx, cert)
+	if err != nil {
+		// Issuance reports an unusable CA; there is no expiry to warn about
+		log.FromContext(ctx).V(1).Info("Skipping CA expiry check", "reason", err.Error())
+		return nil
+	}
+	if !reconcileCAExpiry(cert, ca, r.CAExpiryWarning, time.Now()) {
+		return nil
+	}
+	if condition := meta.FindStatusCondition(cert.Status.Conditions, typeCAExpiringSoonCert); condition.Status == metav1.ConditionTrue {
+		r.Recorder.Event(cert, corev1.EventTypeWarning, typeCAExpiringSoonCert, condition.Message)
+	}
+	if err := r.updateStatus(ctx, cert); err != nil {
+		return fmt.Errorf("failed to record CA expiry: %w", err)
+	}
+	return nil
+}
+
+// caRequeueTime returns how long until the CA signing a CA-issued certificate enters the
+// CAExpiryWarning window or expires, and whether either is still ahead
+func (r *CertificateReconciler) caRequeueTime(ctx context.Context, cert *certv1alpha1.Certificate) (time.Duration, bool) {
+	if issuerKind(cert) != issuerKindCA {
+		return 0, false
+	}
+	ca, err := r.loadCA(ctx, cert)
+	if err != nil {
+		return 0, false
+	}
+	return caExpiryRequeueTime(ca.cert.NotAfter, r.CAExpiryWarning, time.Now())
+}
+
+// caExpiryRequeueTime returns the time from now to the next of the CA entering the warning
+// window and expiring, the points at which its leaves need re-evaluating
+func caExpiryRequeueTime(notAfter time.Time, warning time.Duration, now time.Time) (time.Duration, bool) {
+	for _, at := range []time.Time{notAfter.Add(-warning), notAfter} {
+		if at.After(now) {
+			return at.Sub(now), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("CA expiry warnings", func() {
+	drainEvents := func(r *CertificateReconciler) []string {
+		var events []string
+		for {
+			select {
+			case event := <-r.Recorder.(*record.FakeRecorder).Events:
+				events = append(events, event)
+			default:
+				return events
+			}
+		}
+	}
+
+	It("should warn while the CA is close to expiry and clear the warning once it is rotated", func() {
+		ca := newKeyPairSecret("expiring-ca", "Expiring Root CA", true, 10*24*time.Hour)
+		cert := newTestCertificate("expiring-ca-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		r := newFakeReconciler(cert, ca)
+		r.CAExpiryWarning = 30 * 24 * time.Hour
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		warning := meta.FindStatusCondition(updated.Status.Conditions, typeCAExpiringSoonCert)
+		Expect(warning).NotTo(BeNil())
+		Expect(warning.Status).To(Equal(metav1.ConditionTrue))
+		Expect(warning.Reason).To(Equal("CAExpiresSoon"))
+		Expect(warning.Message).To(ContainSubstring("Expiring Root CA"))
+		Expect(drainEvents(r)).To(ContainElement(ContainSubstring("Warning CAExpiringSoon")))
+
+		// Reconciling again without a change neither warns nor writes again
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drainEvents(r)).NotTo(ContainElement(ContainSubstring("CAExpiringSoon")))
+
+		rotated := newKeyPairSecret(ca.Name, "Expiring Root CA", true, 365*24*time.Hour)
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(ca), ca)).To(Succeed())
+		ca.Data = rotated.Data
+		Expect(r.Update(ctx, ca)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		warning = meta.FindStatusCondition(updated.Status.Conditions, typeCAExpiringSoonCert)
+		Expect(warning.Status).To(Equal(metav1.ConditionFalse))
+		Expect(warning.Reason).To(Equal("CAValid"))
+	})
+
+	It("should report an expired CA", func() {
+		ca := newKeyPairSecret("expired-ca", "Expired Root CA", true, 24*time.Hour)
+		caIssuer, err := parseCA(certv1alpha1.IssuerRef{}, ca)
+		Expect(err).NotTo(HaveOccurred())
+		cert := newTestCertificate("expired-ca-issued")
+
+		Expect(reconcileCAExpiry(cert, caIssuer, 30*24*time.Hour, time.Now().Add(48*time.Hour))).To(BeTrue())
+		Expect(meta.FindStatusCondition(cert.Status.Conditions, typeCAExpiringSoonCert).Reason).To(Equal("CAExpired"))
+	})
+
+	It("should not add the condition while the CA is far from expiry or the check is disabled", func() {
+		ca := newKeyPairSecret("long-lived-ca", "Long-lived Root CA", true, 365*24*time.Hour)
+		caIssuer, err := parseCA(certv1alpha1.IssuerRef{}, ca)
+		Expect(err).NotTo(HaveOccurred())
+		cert := newTestCertificate("long-lived-ca-issued")
+		Expect(reconcileCAExpiry(cert, caIssuer, 30*24*time.Hour, time.Now())).To(BeFalse())
+		Expect(cert.Status.Conditions).To(BeEmpty())
+
+		expiring := newKeyPairSecret("disabled-ca", "Expiring Root CA", true, 24*time.Hour)
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: expiring.Name, Kind: issuerKindCA}
+		r := newFakeReconciler(cert, expiring)
+		Expect(r.syncCAExpiry(ctx, cert)).To(Succeed())
+		Expect(cert.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should requeue a leaf whose CA expires soon before its own renewal", func() {
+		ca := newKeyPairSecret("requeue-ca", "Requeue Root CA", true, 10*24*time.Hour)
+		cert := newTestCertificate("requeue-ca-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(60 * 24 * time.Hour)}
+		r := newFakeReconciler(cert, ca)
+
+		Expect(r.getRequeueTime(ctx, cert)).To(BeNumerically("~", 10*24*time.Hour, time.Minute))
+
+		r.CAExpiryWarning = 7 * 24 * time.Hour
+		Expect(r.getRequeueTime(ctx, cert)).To(BeNumerically("~", 3*24*time.Hour, time.Minute))
+
+		// Other issuers follow their own schedule
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{}
+		Expect(r.getRequeueTime(ctx, cert)).To(BeNumerically(">", 50*24*time.Hour))
+	})
+
+	It("should wake at the CA's expiry once inside the warning window and not after it", func() {
+		notAfter := time.Now().Add(48 * time.Hour)
+		requeue, ok := caExpiryRequeueTime(notAfter, 30*24*time.Hour, time.Now())
+		Expect(ok).To(BeTrue())
+		Expect(requeue).To(BeNumerically("~", 48*time.Hour, time.Minute))
+
+		_, ok = caExpiryRequeueTime(notAfter, 30*24*time.Hour, notAfter.Add(time.Minute))
+		Expect(ok).To(BeFalse())
+	})
+})
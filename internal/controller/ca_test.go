@@ -0,0 +1,450 @@
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// newKeyPairSecret returns a TLS secret holding a freshly generated keypair with the given CN
+func newKeyPairSecret(name, commonName string, isCA bool, validFor time.Duration) *corev1.Secret {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+	}
+}
+
+var _ = Describe("CA issuer", func() {
+	reconcileCertificate := func(r *CertificateReconciler, cert *certv1alpha1.Certificate) *certv1alpha1.Certificate {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return updated
+	}
+
+	It("should sign with the referenced CA and store the chain", func() {
+		ca := newKeyPairSecret("test-ca", "Test Root CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("ca-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		r := newFakeReconciler(cert, ca)
+
+		updated := reconcileCertificate(r, cert)
+		Expect(updated.Status.ChainLength).To(Equal(int32(2)))
+		Expect(updated.Status.IssuerCommonName).To(Equal("Test Root CA"))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("ca.crt", ca.Data[corev1.TLSCertKey]))
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	It("should refuse to sign with a certificate that isn't a CA", func() {
+		notCA := newKeyPairSecret("not-a-ca", "Leaf", false, 24*time.Hour)
+		cert := newTestCertificate("not-ca-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: notCA.Name, Kind: issuerKindCA}
+
+		_, err := newFakeReconciler(notCA).generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrCALoad))
+	})
+
+	It("should fail when the CA secret is missing", func() {
+		cert := newTestCertificate("missing-ca")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "absent", Kind: issuerKindCA}
+
+		_, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrCALoad))
+	})
+
+	Context("When bootstrapping a CA certificate", func() {
+		It("should apply CA-specific defaults", func() {
+			cert := newTestCertificate("root-ca")
+			cert.Spec.IsCA = true
+			r := newFakeReconciler()
+			r.DefaultSubject = certv1alpha1.Subject{Organizations: []string{"Leaf Org"}}
+			r.DefaultCASubject = certv1alpha1.Subject{Organizations: []string{"Root Org"}}
+
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed := parseCertificatePEM(issued.certPEM)
+			Expect(parsed.IsCA).To(BeTrue())
+			Expect(parsed.KeyUsage & x509.KeyUsageCertSign).NotTo(BeZero())
+			Expect(parsed.KeyUsage & x509.KeyUsageCRLSign).NotTo(BeZero())
+			Expect(parsed.MaxPathLen).To(Equal(-1))
+			Expect(parsed.NotAfter.Sub(parsed.NotBefore)).To(Equal(defaultCADuration))
+			Expect(parsed.Subject.Organization).To(Equal([]string{"Root Org"}))
+		})
+
+		It("should honour an explicit path length of zero", func() {
+			cert := newTestCertificate("issuing-ca")
+			cert.Spec.IsCA = true
+			cert.Spec.MaxPathLen = new(int32)
+
+			issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed := parseCertificatePEM(issued.certPEM)
+			Expect(parsed.MaxPathLen).To(Equal(0))
+			Expect(parsed.MaxPathLenZero).To(BeTrue())
+		})
+
+		It("should clamp a leaf that would outlive its CA", func() {
+			ca := newKeyPairSecret("short-lived-ca", "Short Lived CA", true, 24*time.Hour)
+			caCert := parseCertificatePEM(ca.Data[corev1.TLSCertKey])
+			cert := newTestCertificate("long-leaf")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+			cert.Spec.Duration = "48h"
+			r := newFakeReconciler(cert, ca)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			Expect(parseCertificatePEM(secret.Data["tls.crt"]).NotAfter).To(Equal(caCert.NotAfter))
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeValidityClampedCert)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+			Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("ValidityClamped")))
+		})
+
+		It("should not clamp a leaf that fits within its CA", func() {
+			ca := newKeyPairSecret("long-lived-ca", "Long Lived CA", true, 365*24*time.Hour)
+			cert := newTestCertificate("short-leaf")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+			cert.Spec.Duration = "48h"
+
+			issued, err := newFakeReconciler(ca).generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issued.validityClamped).To(BeFalse())
+			Expect(issued.notAfter.Sub(issued.notBefore)).To(Equal(48 * time.Hour))
+		})
+	})
+
+	Context("When a certificate pins its CA version", func() {
+		It("should keep signing with the pinned CA after the issuer rotates", func() {
+			oldCA := newKeyPairSecret("rotating-ca", "Old CA", true, 365*24*time.Hour)
+			oldFingerprint := certificateFingerprint(oldCA.Data[corev1.TLSCertKey])
+
+			// The rotation keeps the previous keypair as labelled history
+			history := oldCA.DeepCopy()
+			history.Name = "rotating-ca-v1"
+			history.Labels = map[string]string{caHistoryLabel: "rotating-ca"}
+			newCA := newKeyPairSecret("rotating-ca", "New CA", true, 365*24*time.Hour)
+
+			cert := newTestCertificate("pinned-leaf")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: newCA.Name, Kind: issuerKindCA}
+			cert.Annotations = map[string]string{pinCAFingerprintAnnotation: oldFingerprint}
+			r := newFakeReconciler(newCA, history)
+
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parseCertificatePEM(issued.certPEM).Issuer.CommonName).To(Equal("Old CA"))
+
+			delete(cert.Annotations, pinCAFingerprintAnnotation)
+			issued, err = r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parseCertificatePEM(issued.certPEM).Issuer.CommonName).To(Equal("New CA"))
+		})
+
+		It("should re-issue when the pin changes", func() {
+			oldCA := newKeyPairSecret("repinned-ca", "Old CA", true, 365*24*time.Hour)
+			history := oldCA.DeepCopy()
+			history.Name = "repinned-ca-v1"
+			history.Labels = map[string]string{caHistoryLabel: "repinned-ca"}
+			newCA := newKeyPairSecret("repinned-ca", "New CA", true, 365*24*time.Hour)
+
+			cert := newTestCertificate("repinned-leaf")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: newCA.Name, Kind: issuerKindCA}
+			cert.Annotations = map[string]string{pinCAFingerprintAnnotation: certificateFingerprint(oldCA.Data[corev1.TLSCertKey])}
+			r := newFakeReconciler(cert, newCA, history)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+			issuerOf := func() string {
+				secret := &corev1.Secret{}
+				ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+				return parseCertificatePEM(secret.Data[corev1.TLSCertKey]).Issuer.CommonName
+			}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issuerOf()).To(Equal("Old CA"))
+
+			// Dropping the pin moves the certificate to the current CA version
+			Expect(r.Get(ctx, req.NamespacedName, cert)).To(Succeed())
+			delete(cert.Annotations, pinCAFingerprintAnnotation)
+			Expect(r.Update(ctx, cert)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issuerOf()).To(Equal("New CA"))
+		})
+
+		It("should fail when no CA version matches the pin", func() {
+			ca := newKeyPairSecret("unpinned-ca", "Current CA", true, 365*24*time.Hour)
+			cert := newTestCertificate("unknown-pin")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+			cert.Annotations = map[string]string{pinCAFingerprintAnnotation: "00"}
+
+			_, err := newFakeReconciler(ca).generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrCALoad))
+		})
+	})
+
+	Context("When the CA is mounted as files", func() {
+		writeCAFiles := func(r *CertificateReconciler, ca *corev1.Secret) {
+			dir := GinkgoT().TempDir()
+			r.CACertFile = filepath.Join(dir, "ca.crt")
+			r.CAKeyFile = filepath.Join(dir, "ca.key")
+			ExpectWithOffset(1, os.WriteFile(r.CACertFile, ca.Data[corev1.TLSCertKey], 0o600)).To(Succeed())
+			ExpectWithOffset(1, os.WriteFile(r.CAKeyFile, ca.Data[corev1.TLSPrivateKeyKey], 0o600)).To(Succeed())
+		}
+
+		It("should sign with the file CA when no secret is referenced", func() {
+			ca := newKeyPairSecret("file-ca", "File Root CA", true, 365*24*time.Hour)
+			cert := newTestCertificate("file-ca-issued")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindCA}
+			r := newFakeReconciler(cert)
+			writeCAFiles(r, ca)
+
+			updated := reconcileCertificate(r, cert)
+			Expect(updated.Status.IssuerCommonName).To(Equal("File Root CA"))
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("ca.crt", ca.Data[corev1.TLSCertKey]))
+			Expect(verifySecretKeyPair(secret)).To(Succeed())
+
+			roots := x509.NewCertPool()
+			roots.AddCert(parseCertificatePEM(ca.Data[corev1.TLSCertKey]))
+			_, err := parseCertificatePEM(secret.Data[corev1.TLSCertKey]).Verify(x509.VerifyOptions{Roots: roots})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should prefer a referenced secret over the files", func() {
+			fileCA := newKeyPairSecret("file-ca", "File Root CA", true, 365*24*time.Hour)
+			secretCA := newKeyPairSecret("secret-ca", "Secret Root CA", true, 365*24*time.Hour)
+			cert := newTestCertificate("file-ca-secret")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: secretCA.Name, Kind: issuerKindCA}
+			r := newFakeReconciler(secretCA)
+			writeCAFiles(r, fileCA)
+
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parseCertificatePEM(issued.certPEM).Issuer.CommonName).To(Equal("Secret Root CA"))
+		})
+
+		It("should report an invalid spec when no CA files are configured", func() {
+			cert := newTestCertificate("file-ca-unconfigured")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindCA}
+
+			_, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		})
+
+		It("should fail when the CA files can't be read", func() {
+			cert := newTestCertificate("file-ca-missing")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindCA}
+			r := newFakeReconciler()
+			r.CACertFile, r.CAKeyFile = "/nonexistent/ca.crt", "/nonexistent/ca.key"
+
+			_, err := r.generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrCALoad))
+		})
+	})
+})
+
+var _ = Describe("Intermediate CA issuer", func() {
+	// newIntermediateSecret returns the PEM of an offline root and an issuer secret holding only
+	// an intermediate signed by it
+	newIntermediateSecret := func(name string) ([]byte, *corev1.Secret) {
+		rootKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		rootTemplate := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "Offline Root CA"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+		Expect(err).NotTo(HaveOccurred())
+		root, err := x509.ParseCertificate(rootDER)
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(2),
+			Subject:               pkix.Name{CommonName: "Online Intermediate CA"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, root, &key.PublicKey, rootKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER}), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+				corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			},
+		}
+	}
+
+	issue := func(issuer *corev1.Secret) *corev1.Secret {
+		cert := newTestCertificate("leaf-of-" + issuer.Name)
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: issuer.Name, Kind: issuerKindCA}
+		r := newFakeReconciler(cert, issuer)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		return secret
+	}
+
+	It("should produce a bundle that verifies against the offline root", func() {
+		rootPEM, issuer := newIntermediateSecret("intermediate-ca")
+		issuer.Data["ca.crt"] = rootPEM
+
+		secret := issue(issuer)
+
+		// tls.crt is the leaf followed by the intermediate, without the root
+		_, chainLength := describeChain(secret.Data[corev1.TLSCertKey])
+		Expect(chainLength).To(Equal(int32(2)))
+		Expect(secret.Data[corev1.TLSCertKey]).To(HaveSuffix(string(issuer.Data[corev1.TLSCertKey])))
+
+		// ca.crt is the full chain, intermediate then root
+		Expect(secret.Data["ca.crt"]).To(Equal(append(append([]byte{}, issuer.Data[corev1.TLSCertKey]...), rootPEM...)))
+
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(rootPEM)).To(BeTrue())
+		intermediates := x509.NewCertPool()
+		Expect(intermediates.AppendCertsFromPEM(issuer.Data[corev1.TLSCertKey])).To(BeTrue())
+		leaf := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		_, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	It("should sign with an intermediate whose root isn't provided", func() {
+		_, issuer := newIntermediateSecret("rootless-intermediate-ca")
+
+		secret := issue(issuer)
+
+		Expect(secret.Data["ca.crt"]).To(Equal(issuer.Data[corev1.TLSCertKey]))
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).Issuer.CommonName).To(Equal("Online Intermediate CA"))
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	It("should not write the secret when ca.crt holds a root that didn't sign the intermediate", func() {
+		_, issuer := newIntermediateSecret("mismatched-intermediate-ca")
+		otherRootPEM, _ := newIntermediateSecret("other-intermediate-ca")
+		issuer.Data["ca.crt"] = otherRootPEM
+		cert := newTestCertificate("leaf-of-mismatched-intermediate-ca")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: issuer.Name, Kind: issuerKindCA}
+		r := newFakeReconciler(cert, issuer)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ErrChainVerification))
+
+		err = r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("ChainVerificationFailed"))
+	})
+})
+
+var _ = Describe("CA issuer secret keys", func() {
+	// rekey moves the CA keypair in secret to the given data keys
+	rekey := func(secret *corev1.Secret, certKey, keyKey string) *corev1.Secret {
+		secret.Data = map[string][]byte{
+			certKey: secret.Data[corev1.TLSCertKey],
+			keyKey:  secret.Data[corev1.TLSPrivateKeyKey],
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		return secret
+	}
+
+	It("should fall back to the ca.crt/ca.key convention", func() {
+		ca := rekey(newKeyPairSecret("ca-key-convention", "Convention CA", true, 365*24*time.Hour), "ca.crt", "ca.key")
+		cert := newTestCertificate("ca-key-convention-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+
+		issued, err := newFakeReconciler(ca).generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCertificatePEM(issued.certPEM).Issuer.CommonName).To(Equal("Convention CA"))
+		Expect(issued.caPEM).To(Equal(ca.Data["ca.crt"]))
+	})
+
+	It("should load the CA from the keys named on the issuer reference", func() {
+		ca := rekey(newKeyPairSecret("ca-custom-keys", "Custom Keys CA", true, 365*24*time.Hour), "root.pem", "root-key.pem")
+		cert := newTestCertificate("ca-custom-keys-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{
+			Name:          ca.Name,
+			Kind:          issuerKindCA,
+			CertKey:       "root.pem",
+			PrivateKeyKey: "root-key.pem",
+		}
+
+		issued, err := newFakeReconciler(ca).generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCertificatePEM(issued.certPEM).Issuer.CommonName).To(Equal("Custom Keys CA"))
+	})
+
+	It("should fail when the named keys are missing", func() {
+		ca := newKeyPairSecret("ca-wrong-keys", "Wrong Keys CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("ca-wrong-keys-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA, CertKey: "root.pem", PrivateKeyKey: "root-key.pem"}
+
+		_, err := newFakeReconciler(ca).generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrCALoad))
+	})
+})
@@ -2,52 +2,226 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
+	"crypto"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
-	"math/big"
-	"net"
+	"io"
+	"maps"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+	"github.com/namansharma18899/certificate-management-operator/internal/audit"
 )
 
+// DefaultFinalizer is the finalizer added to Certificates unless the reconciler configures another
+const DefaultFinalizer = "cert.example.com/finalizer"
+
 const (
-	certificateFinalizer = "cert.example.com/finalizer"
-	typeAvailableCert    = "Available"
-	typeReadyCert        = "Ready"
+	typeAvailableCert = "Available"
+	typeReadyCert     = "Ready"
+
+	// typeValidityClampedCert warns that the certificate expires earlier than requested because its CA does
+	typeValidityClampedCert = "ValidityClamped"
+
+	// typeNoConsumersFoundCert reports that restarting deployments found none using the secret
+	typeNoConsumersFoundCert = "NoConsumersFound"
+
+	// typeSubjectChangedCert reports whether the latest re-issue changed the CommonName served from SecretName
+	typeSubjectChangedCert = "SubjectChanged"
+
+	// typeRestartPendingCert reports that the consumers of the latest certificate are still waiting
+	// for their restart, e.g. because the namespace's restart limit was reached
+	typeRestartPendingCert = "RestartPending"
+
+	// statusUpdateRetryDelay is how soon to retry recording a certificate whose secret was already written
+	statusUpdateRetryDelay = 5 * time.Second
+
+	// namespaceTerminatingRequeue is how long to wait before retrying a secret write rejected by a terminating namespace
+	namespaceTerminatingRequeue = 30 * time.Second
+
+	// restartRetryDelay is how soon to retry a pending restart of the secret's consumers
+	restartRetryDelay = 10 * time.Second
+
+	// finalizerRemovalRequeue is how long to wait before retrying a finalizer removal that exhausted its backoff
+	finalizerRemovalRequeue = time.Minute
+
+	// defaultCADuration is the validity of CA certificates that don't set a duration
+	defaultCADuration = 10 * 365 * 24 * time.Hour
+
+	// renewIfBeforeAnnotation forces a one-shot renewal when the certificate expires within the given duration
+	renewIfBeforeAnnotation = "cert.example.com/renew-if-before"
 )
 
 // CertificateReconciler reconciles a Certificate object
 type CertificateReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// APIReader reads straight from the API server, for objects the manager's RBAC doesn't let an
+	// informer list and watch, such as Ingresses and Leases. Nil means Client
+	APIReader client.Reader
+
+	// DefaultSubject supplies subject fields for certificates that don't set their own
+	DefaultSubject certv1alpha1.Subject
+
+	// DefaultCASubject supplies subject fields for CA certificates that don't set their own
+	DefaultCASubject certv1alpha1.Subject
+
+	// DefaultKeyAlgorithm and DefaultKeySize select the private key of certificates that set neither
+	// KeyAlgorithm nor KeySize, to enforce e.g. ECDSA org-wide. Empty and zero mean 2048-bit RSA
+	DefaultKeyAlgorithm string
+	DefaultKeySize      int32
+
+	// MinRSAKeySize rejects RSA keys smaller than this many bits, and DisallowRSA rejects RSA keys
+	// altogether, whatever the Certificate asks for. Zero and false leave RSA keys unrestricted
+	MinRSAKeySize int32
+	DisallowRSA   bool
+
+	// MaxConcurrentReconciles is the number of Certificates reconciled in parallel
+	MaxConcurrentReconciles int
+
+	// MaxIssuancesPerHour caps the issuances of a single Certificate per hour. Zero disables the cap
+	MaxIssuancesPerHour int
+
+	// NamespaceIssuanceQuota caps the issuance attempts of all Certificates in a namespace
+	// per hour, protecting issuer capacity shared across namespaces. Zero disables the quota
+	NamespaceIssuanceQuota int
+
+	// MinRequeue and MaxRequeue clamp the requeue interval computed from the renewal time.
+	// Zero leaves that side unbounded
+	MinRequeue time.Duration
+	MaxRequeue time.Duration
+
+	// MaxRestartsPerNamespace bounds how many Certificates restart deployments in one namespace
+	// at a time. Zero leaves restarts unbounded
+	MaxRestartsPerNamespace int
+
+	// MaxConcurrentIssuances bounds how many Certificates are issued at a time; waiting Certificates
+	// are issued soonest-expiry-first. Zero leaves issuance unbounded
+	MaxConcurrentIssuances int
+
+	// CTSubmitter submits issued certificates to certificate transparency logs when requested
+	CTSubmitter CTSubmitter
+
+	// KeyManager resolves the keys of ExternalKey issuers. Certificates using them fail while it is unset
+	KeyManager KeyManager
+
+	// ExternalSecretStore receives the TLS material of Certificates with ExternalSecretPath.
+	// Mirroring is reported as failed while it is unset
+	ExternalSecretStore ExternalSecretStore
+
+	// AuditLog receives a record of every issuance. Nil disables the audit trail
+	AuditLog *audit.Logger
+
+	// CACertFile and CAKeyFile are PEM files holding the keypair used by CA issuers that don't
+	// name a secret, for clusters where the CA is mounted rather than stored in the API
+	CACertFile string
+	CAKeyFile  string
+
+	// Finalizer is the finalizer added to Certificates. Empty means DefaultFinalizer; forks and
+	// rebranded builds sharing a cluster set their own so they don't release each other's objects
+	Finalizer string
+
+	// CertificateSelector limits reconciliation to Certificates whose labels match, e.g. tier=prod,
+	// so changes can be rolled out to one environment or tier at a time. Nil selects every Certificate
+	CertificateSelector labels.Selector
+
+	// CAExpiryWarning is how long before its CA expires a CA-issued Certificate gets the
+	// CAExpiringSoon condition, as lead time to rotate the CA. Zero disables the check
+	CAExpiryWarning time.Duration
+
+	// WildcardPolicy is Allow (or empty), Reject or Expand, for organizations that disallow wildcard
+	// certificates. Expand replaces each wildcard DNS name with WildcardExpansionLabels under its
+	// domain, and rejects it like Reject when there are none. Wildcard common names are rejected
+	// under both
+	WildcardPolicy          string
+	WildcardExpansionLabels []string
+
+	// AllowedSecretNamespaces lists the namespaces Certificates may write SecretName to besides
+	// their own, so tenants can't place secrets in namespaces they don't control. "*" allows any
+	AllowedSecretNamespaces []string
+	// Rand is the source of randomness for keys, serial numbers and signatures. Nil means crypto/rand;
+	// transient failures reading it are retried with a short backoff
+	Rand io.Reader
+
+	// TracerProvider receives spans for reconciles, issuance and secret writes. Nil means the global
+	// provider, which discards them unless an exporter is configured
+	TracerProvider trace.TracerProvider
+
+	// HeartbeatLease names a Lease whose renewTime records the last successful reconcile, for
+	// external monitors. An empty name disables the heartbeat
+	HeartbeatLease types.NamespacedName
+
+	restartLimiter namespaceLimiter
+	issuanceQueue  issuanceQueue
+	namespaceQuota namespaceQuota
 }
 
 //+kubebuilder:rbac:groups=cert.example.com,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cert.example.com,resources=certificates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cert.example.com,resources=certificates/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cert.example.com,resources=certificatetemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cert.example.com,resources=issuers,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;update;patch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := r.startSpan(ctx, "Reconcile", nil)
+	span.SetAttributes(namespaceAttribute.String(req.Namespace), nameAttribute.String(req.Name))
+	defer func() { endSpan(span, err) }()
+
+	result, err = r.reconcile(ctx, req)
+	// Transient API server failures retry on a short fixed delay instead of the growing error backoff
+	if err != nil && isTransientAPIError(err) {
+		log.FromContext(ctx).Info("Transient API error, retrying shortly", "error", err.Error())
+		return ctrl.Result{RequeueAfter: transientAPIErrorDelay(err)}, nil
+	}
+	if err == nil {
+		if err := r.recordHeartbeat(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to record heartbeat")
+		}
+	}
+	return result, err
+}
+
+// reconcile issues, renews or imports the certificate named by req
+func (r *CertificateReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling Certificate")
 
@@ -63,10 +237,21 @@ func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	setCertificateAttributes(trace.SpanFromContext(ctx), certificate)
+
+	// Watches mapping other objects to Certificates bypass the selector predicate
+	if !r.selects(certificate) {
+		logger.V(1).Info("Certificate does not match the selector, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	// Status writes patch what this reconcile changed against the status as read
+	ctx = withStatusBase(ctx, certificate)
+
 	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(certificate, certificateFinalizer) {
+	if !controllerutil.ContainsFinalizer(certificate, r.finalizer()) {
 		logger.Info("Adding Finalizer for Certificate")
-		if ok := controllerutil.AddFinalizer(certificate, certificateFinalizer); !ok {
+		if ok := controllerutil.AddFinalizer(certificate, r.finalizer()); !ok {
 			logger.Error(err, "Failed to add finalizer to Certificate")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -79,67 +264,302 @@ func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Check if the Certificate instance is marked to be deleted
 	if certificate.GetDeletionTimestamp() != nil {
-		if controllerutil.ContainsFinalizer(certificate, certificateFinalizer) {
+		if controllerutil.ContainsFinalizer(certificate, r.finalizer()) {
 			logger.Info("Performing cleanup for Certificate")
 
+			// Garbage collection only follows owner references within the Certificate's namespace
+			if err := r.cleanupCrossNamespaceSecrets(ctx, certificate); err != nil {
+				logger.Error(err, "Failed to delete secret in another namespace")
+				return ctrl.Result{}, err
+			}
+
 			// Remove finalizer
-			if ok := controllerutil.RemoveFinalizer(certificate, certificateFinalizer); !ok {
+			if ok := controllerutil.RemoveFinalizer(certificate, r.finalizer()); !ok {
 				logger.Error(err, "Failed to remove finalizer from Certificate")
 				return ctrl.Result{Requeue: true}, nil
 			}
 
-			if err := r.Update(ctx, certificate); err != nil {
+			if err := r.removeFinalizer(ctx, certificate); err != nil {
 				logger.Error(err, "Failed to remove finalizer from Certificate")
-				return ctrl.Result{}, err
+				r.Recorder.Eventf(certificate, corev1.EventTypeWarning, "FinalizerRemovalFailed",
+					"Failed to remove finalizer, retrying in %s: %v", finalizerRemovalRequeue, err)
+				return ctrl.Result{RequeueAfter: finalizerRemovalRequeue}, nil
 			}
 		}
 		return ctrl.Result{}, nil
 	}
 
-	// Check if certificate needs renewal
-	if r.needsRenewal(certificate) {
-		logger.Info("Certificate needs issuance or renewal", "name", certificate.Name)
+	// A paused certificate is left alone until its deadline, then renewal is re-evaluated
+	if paused, resumeIn := r.reconcilePause(ctx, certificate); paused {
+		logger.Info("Certificate is paused", "resumeIn", resumeIn)
+		return ctrl.Result{RequeueAfter: resumeIn}, nil
+	}
 
-		// Generate new certificate
-		certPEM, keyPEM, notBefore, notAfter, serialNumber, err := r.generateCertificate(certificate)
-		if err != nil {
-			logger.Error(err, "Failed to generate certificate")
-			meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
-				Type:               typeReadyCert,
-				Status:             metav1.ConditionFalse,
-				Reason:             "GenerationFailed",
-				Message:            fmt.Sprintf("Failed to generate certificate: %v", err),
-				LastTransitionTime: metav1.Now(),
-			})
-			if err := r.Status().Update(ctx, certificate); err != nil {
+	// Certificates sharing a secret would overwrite each other's certificate, so only the first one writes it
+	owner, err := r.secretNameOwner(ctx, certificate)
+	if err != nil {
+		logger.Error(err, "Failed to check for secret name conflicts")
+		return ctrl.Result{}, err
+	}
+	if owner != nil {
+		logger.Info("Secret is claimed by an earlier Certificate", "secret", certificate.Spec.SecretName, "owner", owner.Name)
+		message := fmt.Sprintf("Secret %s is claimed by Certificate %s, created earlier; choose another secretName",
+			certificate.Spec.SecretName, owner.Name)
+		if meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
+			Type:               typeReadyCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SecretNameConflict",
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		}) {
+			r.Recorder.Event(certificate, corev1.EventTypeWarning, "SecretNameConflict", message)
+			if err := r.updateStatus(ctx, certificate); err != nil {
 				logger.Error(err, "Failed to update Certificate status")
+				return ctrl.Result{}, err
 			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Merge the referenced template under the spec for the rest of the reconcile
+	if err := r.applyTemplate(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to apply certificate template")
+		r.fail(ctx, certificate, "TemplateUnavailable", fmt.Errorf("failed to apply certificate template: %w", err))
+		return ctrl.Result{}, err
+	}
+
+	if err := r.applyIssuer(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to resolve issuer")
+		reason, retryable := issuanceFailure(err)
+		r.fail(ctx, certificate, reason, fmt.Errorf("failed to resolve issuer: %w", err))
+		if !retryable {
+			// Issuers are watched, so fixing the Issuer triggers a reconcile
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The issuer's policy decides the effective duration, so it fails like issuance does
+	if err := r.applyIssuerPolicy(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to apply issuer policy")
+		// Issuer secrets aren't watched, so even an invalid policy is retried with backoff
+		reason, _ := issuanceFailure(err)
+		r.fail(ctx, certificate, reason, fmt.Errorf("failed to apply issuer policy: %w", err))
+		return ctrl.Result{}, err
+	}
+
+	// An unparseable IP would be missing from the certificate, so the whole spec is rejected
+	if err := validateIPAddresses(certificate); err != nil {
+		logger.Error(err, "Certificate has invalid IP addresses")
+		r.fail(ctx, certificate, "InvalidIPAddress", err)
+		// Retrying can't succeed; the next spec update triggers a reconcile
+		return ctrl.Result{}, nil
+	}
+
+	// A secret outside the allowlist is never written, so the spec is rejected before anything is
+	if err := r.checkSecretNamespace(certificate); err != nil {
+		logger.Error(err, "Certificate secret namespace is not allowed")
+		reason, _ := issuanceFailure(err)
+		r.fail(ctx, certificate, reason, err)
+		// Retrying can't succeed; the next spec update triggers a reconcile
+		return ctrl.Result{}, nil
+	}
+
+	// Organization policy on wildcards applies before anything compares or issues DNS names
+	if err := r.applyWildcardPolicy(certificate); err != nil {
+		logger.Error(err, "Certificate violates wildcard policy")
+		reason, _ := issuanceFailure(err)
+		r.fail(ctx, certificate, reason, err)
+		// Retrying can't succeed; the next spec update triggers a reconcile
+		return ctrl.Result{}, nil
+	}
+
+	// Imported certificates are managed elsewhere, so only their expiry is tracked
+	if certificate.Spec.ImportFromSecret != "" {
+		if err := r.syncImportedCertificate(ctx, certificate); err != nil {
 			return ctrl.Result{}, err
 		}
+	} else if secret := r.externallyRotatedSecret(ctx, certificate); secret != nil {
+		// Hybrid ownership: an external system rotated the secret, so record it rather than overwrite it
+		if err := r.syncExternallyRotatedCertificate(ctx, certificate, secret); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if r.needsRenewal(certificate) || r.storedSANsMismatch(ctx, certificate) {
+		logger.Info("Certificate needs issuance or renewal", "name", certificate.Name)
 
-		// Create or update secret
-		err = r.createOrUpdateSecret(ctx, certificate, certPEM, keyPEM)
-		if err != nil {
-			logger.Error(err, "Failed to create/update secret")
-			meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
-				Type:               typeReadyCert,
-				Status:             metav1.ConditionFalse,
-				Reason:             "SecretUpdateFailed",
-				Message:            fmt.Sprintf("Failed to update secret: %v", err),
-				LastTransitionTime: metav1.Now(),
-			})
-			if err := r.Status().Update(ctx, certificate); err != nil {
-				logger.Error(err, "Failed to update Certificate status")
+		// A previous issuance may have written the secret but failed to record it in status
+		issued := r.recoverIssuedCertificate(ctx, certificate)
+		if issued != nil {
+			logger.Info("Recovered certificate from previously written secret", "serialNumber", issued.serialNumber)
+		} else {
+			// Regulated certificates aren't issued until an approver releases them
+			if pending, changed := pendingApproval(certificate); pending {
+				logger.Info("Certificate is waiting for approval")
+				if changed {
+					if err := r.updateStatus(ctx, certificate); err != nil {
+						logger.Error(err, "Failed to update Certificate status")
+					}
+				}
+				return ctrl.Result{}, nil
+			}
+
+			// Stop a misconfigured certificate from re-issuing in a loop and exhausting issuer quotas
+			if exhausted, resetIn := r.issuanceBudgetExhausted(certificate); exhausted {
+				logger.Info("Issuance budget exceeded", "issuances", certificate.Status.IssuancesInWindow, "resetIn", resetIn)
+				r.fail(ctx, certificate, "IssuanceBudgetExceeded", fmt.Errorf("issued %d times in the last hour; next issuance allowed in %s",
+					certificate.Status.IssuancesInWindow, resetIn.Round(time.Second)))
+				return ctrl.Result{RequeueAfter: resetIn}, nil
 			}
+
+			// Certificates that expire sooner get issuer capacity first. The rest retry shortly
+			// rather than holding a reconcile worker while they wait
+			release, granted := r.acquireIssuanceSlot(certificate)
+			if !granted {
+				logger.Info("Waiting for an issuance slot")
+				return ctrl.Result{RequeueAfter: issuanceSlotRetryDelay}, nil
+			}
+
+			// Keep one namespace from exhausting the issuer for everyone else
+			if allowed, resetIn := r.namespaceQuota.reserve(certificate.Namespace, r.NamespaceIssuanceQuota, time.Now()); !allowed {
+				release()
+				logger.Info("Namespace issuance quota exceeded", "namespace", certificate.Namespace, "resetIn", resetIn)
+				r.fail(ctx, certificate, "QuotaExceeded", fmt.Errorf("namespace %s used its quota of %d issuances per hour; next issuance allowed in %s",
+					certificate.Namespace, r.NamespaceIssuanceQuota, resetIn.Round(time.Second)))
+				return ctrl.Result{RequeueAfter: resetIn}, nil
+			}
+
+			// Generate new certificate
+			issued, err = r.generateCertificateWithTimeout(ctx, certificate)
+			release()
+			if err != nil {
+				logger.Error(err, "Failed to generate certificate")
+				reason, retryable := issuanceFailure(err)
+				r.fail(ctx, certificate, reason, fmt.Errorf("failed to generate certificate: %w", err))
+				// Retrying an invalid spec can't succeed; the next spec update triggers a reconcile
+				if !retryable {
+					return ctrl.Result{}, nil
+				}
+				return ctrl.Result{}, err
+			}
+
+			// Create or update secret
+			err = r.createOrUpdateSecret(ctx, certificate, issued)
+			if err != nil && isNamespaceTerminating(err) {
+				// Retrying promptly can't succeed while the namespace is being deleted
+				logger.Info("Namespace is terminating, not writing secret", "namespace", certificate.Namespace)
+				r.fail(ctx, certificate, "NamespaceTerminating", fmt.Errorf("namespace %s is terminating: %w", certificate.Namespace, err))
+				return ctrl.Result{RequeueAfter: namespaceTerminatingRequeue}, nil
+			}
+			if err != nil {
+				logger.Error(err, "Failed to create/update secret")
+				r.fail(ctx, certificate, dependentSecretFailure(err), fmt.Errorf("failed to update secret: %w", err))
+				return ctrl.Result{}, err
+			}
+			certificate.Status.IssuancesInWindow++
+			countIssuance(certificate)
+			observeRenewalAge(certificate, issued.notBefore)
+			r.auditIssuance(ctx, certificate, certificate.Spec.SecretName, issued)
+
+			// Log submission doesn't gate issuance; its outcome is reported in a condition
+			if certificate.Spec.SubmitToCTLogs {
+				r.submitToCTLogs(ctx, certificate, issued)
+			}
+
+			// The client certificate for mutual TLS renews together with the server certificate
+			if certificate.Spec.ClientCertSecretName != "" {
+				if err := r.issueClientCertificate(ctx, certificate); err != nil {
+					logger.Error(err, "Failed to issue client certificate")
+					reason, _ := issuanceFailure(err)
+					r.fail(ctx, certificate, reason, fmt.Errorf("failed to issue client certificate: %w", err))
+					return ctrl.Result{}, err
+				}
+			}
+		}
+
+		// Publish the key-less copy for sidecars that must not read the private key
+		if certificate.Spec.PublicSecretName != "" {
+			if err := r.writePublicSecret(ctx, certificate, issued); err != nil {
+				logger.Error(err, "Failed to write public secret", "secret", certificate.Spec.PublicSecretName)
+				r.fail(ctx, certificate, dependentSecretFailure(err), fmt.Errorf("failed to update public secret: %w", err))
+				return ctrl.Result{}, err
+			}
+		}
+
+		// With a separate client certificate, the kubeconfig is written when that is issued
+		if certificate.Spec.KubeconfigSecretName != "" && certificate.Spec.ClientCertSecretName == "" {
+			if err := r.writeKubeconfigSecret(ctx, certificate, issued); err != nil {
+				logger.Error(err, "Failed to write kubeconfig secret", "secret", certificate.Spec.KubeconfigSecretName)
+				r.fail(ctx, certificate, dependentSecretFailure(err), fmt.Errorf("failed to update kubeconfig secret: %w", err))
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Cascade the rotation to material derived from the certificate
+		if len(certificate.Spec.DependentSecrets) > 0 {
+			if err := r.writeDependentSecrets(ctx, certificate, issued); err != nil {
+				logger.Error(err, "Failed to write dependent secrets")
+				r.fail(ctx, certificate, dependentSecretFailure(err), fmt.Errorf("failed to update dependent secrets: %w", err))
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Verify what consumers will actually load from the secret
+		if certificate.Spec.SelfTest {
+			if err := r.selfTestSecret(ctx, certificate); err != nil {
+				logger.Error(err, "Certificate self-test failed")
+				r.fail(ctx, certificate, "SelfTestFailed", fmt.Errorf("stored certificate failed self-test: %w", err))
+				return ctrl.Result{}, err
+			}
+		}
+
+		// The secret was renamed, so drop the one written under the previous name
+		if err := r.deletePreviousSecret(ctx, certificate); err != nil {
+			logger.Error(err, "Failed to delete previous secret", "secret", certificate.Status.SecretName)
+			return ctrl.Result{}, err
+		}
+
+		// Another writer may have replaced the secret since; record only what consumers actually load
+		if err := r.verifyStoredSerial(ctx, certificate, issued); err != nil {
+			logger.Error(err, "Secret does not hold the issued certificate")
+			r.fail(ctx, certificate, "SecretMismatch", fmt.Errorf("secret does not hold the issued certificate: %w", err))
 			return ctrl.Result{}, err
 		}
 
 		// Update status
-		certificate.Status.NotBefore = &metav1.Time{Time: notBefore}
-		certificate.Status.NotAfter = &metav1.Time{Time: notAfter}
-		certificate.Status.RenewalTime = r.calculateRenewalTime(certificate, notAfter)
-		certificate.Status.SerialNumber = serialNumber
+		keyChanged := certificate.Status.PublicKeyPin != issued.publicKeyPin
+		lifetimeGained := restartLifetimeGained(certificate, issued.notAfter)
+		previousCommonName := certificate.Status.CommonName
+		certificate.Status.CommonName = certificate.Spec.CommonName
+		certificate.Status.SecretName = certificate.Spec.SecretName
+		certificate.Status.SecretNamespace = certificate.Spec.SecretNamespace
+		certificate.Status.NotBefore = &metav1.Time{Time: issued.notBefore}
+		certificate.Status.NotAfter = &metav1.Time{Time: issued.notAfter}
+		certificate.Status.RenewalTime = r.calculateRenewalTime(certificate, issued.notAfter)
+		certificate.Status.SerialNumber = issued.serialNumber
+		certificate.Status.Fingerprint = certificateFingerprint(issued.certPEM)
+		certificate.Status.PublicKeyPin = issued.publicKeyPin
+		certificate.Status.IssuerCommonName, certificate.Status.ChainLength = describeChain(issued.certPEM)
 		certificate.Status.LastRenewalTime = &metav1.Time{Time: time.Now()}
+		certificate.Status.SpecHash = issuanceSpecHash(certificate)
+
+		if issued.validityClamped {
+			meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
+				Type:               typeValidityClampedCert,
+				Status:             metav1.ConditionTrue,
+				Reason:             "CAExpiresFirst",
+				Message:            fmt.Sprintf("Validity was shortened to the CA's expiry at %s", issued.notAfter.Format(time.RFC3339)),
+				LastTransitionTime: metav1.Now(),
+			})
+			r.Recorder.Eventf(certificate, corev1.EventTypeWarning, "ValidityClamped",
+				"Requested duration exceeds the CA's lifetime; certificate expires with the CA at %s", issued.notAfter.Format(time.RFC3339))
+		} else {
+			meta.RemoveStatusCondition(&certificate.Status.Conditions, typeValidityClampedCert)
+		}
+
+		// Consumers keep mounting the same secret, so surface that it now serves a different name
+		if previousCommonName != "" {
+			r.setSubjectChangedCondition(certificate, previousCommonName)
+		}
 
 		// Set Ready condition
 		meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
@@ -158,28 +578,213 @@ func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			LastTransitionTime: metav1.Now(),
 		})
 
-		if err := r.Status().Update(ctx, certificate); err != nil {
+		if err := r.updateStatus(ctx, certificate); err != nil {
 			logger.Error(err, "Failed to update Certificate status")
-			return ctrl.Result{}, err
+			// The secret is already written, so retry soon and recover it rather than issuing a new key
+			return ctrl.Result{RequeueAfter: statusUpdateRetryDelay}, nil
 		}
 
-		// Restart deployments if enabled
-		if certificate.Spec.RestartDeployments {
+		// Restart deployments if enabled; consumers that hot-reload the certificate only need one for a new key,
+		// and a re-issue that barely extends the lifetime may not be worth one
+		if certificate.Spec.RestartDeployments && (keyChanged || !certificate.Spec.RestartOnlyOnKeyChange) && lifetimeGained {
 			if err := r.restartDeployments(ctx, certificate); err != nil {
 				logger.Error(err, "Failed to restart deployments")
 				// Don't fail the reconciliation, just log the error
 			}
 		}
 
-		logger.Info("Certificate issued successfully", "name", certificate.Name, "notAfter", notAfter)
+		logger.Info("Certificate issued successfully", "name", certificate.Name, "notAfter", issued.notAfter)
+	}
+
+	// Related certificates renew on their own schedules
+	if certificate.Spec.ImportFromSecret == "" && (len(certificate.Spec.AdditionalCertificates) > 0 ||
+		len(certificate.Status.AdditionalCertificates) > 0) {
+		changed, err := r.reconcileAdditionalCertificates(ctx, certificate)
+		if err != nil {
+			logger.Error(err, "Failed to issue additional certificate")
+			reason, _ := issuanceFailure(err)
+			meta.SetStatusCondition(&certificate.Status.Conditions, metav1.Condition{
+				Type:               typeReadyCert,
+				Status:             metav1.ConditionFalse,
+				Reason:             reason,
+				Message:            fmt.Sprintf("Failed to issue additional certificate: %v", err),
+				LastTransitionTime: metav1.Now(),
+			})
+			changed = true
+		}
+		if changed {
+			if err := r.updateStatus(ctx, certificate); err != nil {
+				logger.Error(err, "Failed to update Certificate status")
+				return ctrl.Result{}, err
+			}
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Point referenced Ingress/Gateway at the secret, so refs added after issuance are wired too
+	if certificate.Spec.ImportFromSecret == "" && certificate.Status.SecretName != "" {
+		if err := r.updateNetworkingRefs(ctx, certificate); err != nil {
+			logger.Error(err, "Failed to update networking references")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Metadata-only template changes apply without waiting for the next issuance
+	if certificate.Spec.ImportFromSecret == "" && certificate.Status.SecretName != "" {
+		if err := r.syncSecretMetadata(ctx, certificate); err != nil {
+			logger.Error(err, "Failed to sync secret metadata")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Consumers outside Kubernetes read a copy kept in an external secret manager
+	if err := r.syncExternalSecret(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to mirror certificate to external secret store")
+		return ctrl.Result{}, err
+	}
+
+	// Deployments that began using the secret mid-rotation may have loaded the previous certificate
+	if err := r.syncNewConsumers(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to restart new consumers")
+	}
+
+	// Restarts deferred by the namespace restart limit are retried until they go through
+	if restartPending(certificate) {
+		if err := r.restartDeployments(ctx, certificate); err != nil {
+			logger.Error(err, "Failed to restart deployments")
+		}
+	}
+
+	// Surface consumers rejecting the certificate next to the rest of its state
+	if err := r.syncFeedback(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to record validation feedback")
+		return ctrl.Result{}, err
+	}
+
+	// Warn about requested features the issuer ignores rather than dropping them silently
+	if err := r.syncUnsupportedFeatures(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to record unsupported features")
+		return ctrl.Result{}, err
+	}
+
+	// Give lead time to rotate a CA before every leaf it signed stops validating
+	if err := r.syncCAExpiry(ctx, certificate); err != nil {
+		logger.Error(err, "Failed to record CA expiry")
+		return ctrl.Result{}, err
+	}
+
+	// Keep the dashboard ConfigMap in sync with status
+	if certificate.Spec.StatusConfigMapName != "" {
+		if err := r.syncStatusConfigMap(ctx, certificate); err != nil {
+			logger.Error(err, "Failed to sync status ConfigMap")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The renew-if-before annotation is one-shot, so clear it once it has been evaluated
+	if window, ok := certificate.Annotations[renewIfBeforeAnnotation]; ok {
+		logger.Info("Clearing renew-if-before annotation", "window", window)
+		// needsRenewal ignores a value it can't parse, so tell the user the renewal didn't happen
+		if _, err := time.ParseDuration(window); err != nil {
+			r.Recorder.Eventf(certificate, corev1.EventTypeWarning, "InvalidRenewIfBefore",
+				"Ignored %s=%q, which is not a duration such as 720h; no renewal was forced", renewIfBeforeAnnotation, window)
+		}
+		// Patch so the template-merged spec isn't written back
+		patch := client.MergeFrom(certificate.DeepCopy())
+		delete(certificate.Annotations, renewIfBeforeAnnotation)
+		if err := r.Patch(ctx, certificate, patch); err != nil {
+			logger.Error(err, "Failed to clear renew-if-before annotation")
+			return ctrl.Result{}, err
+		}
 	}
 
 	// Requeue before renewal time
-	requeueAfter := r.getRequeueTime(certificate)
+	requeueAfter := r.getRequeueTime(ctx, certificate)
+	if restartPending(certificate) {
+		requeueAfter = min(requeueAfter, restartRetryDelay)
+	}
 	logger.Info("Requeuing reconciliation", "after", requeueAfter)
 	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
+// restartPending reports whether the certificate's consumers still wait for a deferred restart
+func restartPending(cert *certv1alpha1.Certificate) bool {
+	return cert.Spec.RestartDeployments && meta.IsStatusConditionTrue(cert.Status.Conditions, typeRestartPendingCert)
+}
+
+// finalizer returns the configured finalizer, or DefaultFinalizer
+func (r *CertificateReconciler) finalizer() string {
+	if r.Finalizer == "" {
+		return DefaultFinalizer
+	}
+	return r.Finalizer
+}
+
+// apiReader returns the reader for objects that aren't served from the cache
+func (r *CertificateReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// finalizerRemovalBackoff bounds the attempts to remove the finalizer within a single reconcile
+var finalizerRemovalBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 50 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// removeFinalizer updates the certificate without its finalizer, retrying with backoff.
+// The finalizer must already be removed from cert
+func (r *CertificateReconciler) removeFinalizer(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	err := retry.OnError(finalizerRemovalBackoff, func(err error) bool {
+		return !errors.IsNotFound(err)
+	}, func() error {
+		err := r.Update(ctx, cert)
+		if errors.IsConflict(err) {
+			// Refresh so the next attempt is based on the latest version
+			if getErr := r.Get(ctx, client.ObjectKeyFromObject(cert), cert); getErr == nil {
+				controllerutil.RemoveFinalizer(cert, r.finalizer())
+			}
+		}
+		return err
+	})
+	// The object is gone once the last finalizer is removed
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// setSubjectChangedCondition records whether a re-issue changed the CommonName, emitting an event when it did
+func (r *CertificateReconciler) setSubjectChangedCondition(cert *certv1alpha1.Certificate, previousCommonName string) {
+	if previousCommonName == cert.Spec.CommonName {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeSubjectChangedCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SubjectUnchanged",
+			Message:            "The latest issuance kept the previous common name",
+			LastTransitionTime: metav1.Now(),
+		})
+		return
+	}
+
+	message := fmt.Sprintf("Common name changed from %q to %q; consumers of secret %s receive the new certificate",
+		previousCommonName, cert.Spec.CommonName, cert.Spec.SecretName)
+	meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeSubjectChangedCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CommonNameChanged",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	r.Recorder.Event(cert, corev1.EventTypeNormal, "SubjectChanged", message)
+}
+
 // needsRenewal checks if certificate needs to be issued or renewed
 func (r *CertificateReconciler) needsRenewal(cert *certv1alpha1.Certificate) bool {
 	// If no renewal time set, needs initial issuance
@@ -187,82 +792,439 @@ func (r *CertificateReconciler) needsRenewal(cert *certv1alpha1.Certificate) boo
 		return true
 	}
 
-	// Check if current time is past renewal time
-	return time.Now().After(cert.Status.RenewalTime.Time)
+	// Re-issue when fields baked into the certificate changed since issuance
+	if cert.Status.SpecHash != "" && cert.Status.SpecHash != issuanceSpecHash(cert) {
+		return true
+	}
+
+	// Write the certificate to the new secret when SecretName or SecretNamespace was changed
+	if cert.Status.SecretName != "" && cert.Status.SecretName != cert.Spec.SecretName {
+		return true
+	}
+	if cert.Status.SecretName != "" && statusSecretNamespace(cert) != secretNamespace(cert) {
+		return true
+	}
+
+	// Re-issuing can't move a fixed expiry, so the certificate is only replaced when ExpiresAt changes
+	if cert.Spec.ExpiresAt != nil && cert.Status.NotAfter != nil && cert.Status.NotAfter.Equal(cert.Spec.ExpiresAt) {
+		return false
+	}
+
+	// Force early renewal if the certificate expires within the renew-if-before window
+	if window, ok := cert.Annotations[renewIfBeforeAnnotation]; ok && cert.Status.NotAfter != nil {
+		if duration, err := time.ParseDuration(window); err == nil && time.Until(cert.Status.NotAfter.Time) < duration {
+			return true
+		}
+	}
+
+	// Check if current time is past renewal time, then wait for the renewal window if there is one
+	if !time.Now().After(cert.Status.RenewalTime.Time) {
+		return false
+	}
+	held, _ := renewalHeld(cert, time.Now())
+	return !held
 }
 
-// generateCertificate creates a new self-signed certificate
-func (r *CertificateReconciler) generateCertificate(cert *certv1alpha1.Certificate) ([]byte, []byte, time.Time, time.Time, string, error) {
-	// Generate private key
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+// issuanceSpecHash hashes the spec fields that end up in the issued certificate
+func issuanceSpecHash(cert *certv1alpha1.Certificate) string {
+	fields := struct {
+		CommonName   string                           `json:"commonName"`
+		Subject      *certv1alpha1.Subject            `json:"subject,omitempty"`
+		DNSNames     []string                         `json:"dnsNames,omitempty"`
+		IPAddresses  []string                         `json:"ipAddresses,omitempty"`
+		Duration     string                           `json:"duration,omitempty"`
+		ExpiresAt    *metav1.Time                     `json:"expiresAt,omitempty"`
+		IssuerRef    certv1alpha1.IssuerRef           `json:"issuerRef"`
+		Serial       *certv1alpha1.SerialNumberSource `json:"serialNumberSource,omitempty"`
+		IsCA         bool                             `json:"isCA,omitempty"`
+		MaxPathLen   *int32                           `json:"maxPathLen,omitempty"`
+		ServiceRef   *certv1alpha1.ServiceRef         `json:"serviceRef,omitempty"`
+		KeyAlgorithm string                           `json:"keyAlgorithm,omitempty"`
+		KeySize      int32                            `json:"keySize,omitempty"`
+		CSRSecretRef *certv1alpha1.CSRSecretRef       `json:"csrSecretRef,omitempty"`
+		OCSPServers  []string                         `json:"ocspServers,omitempty"`
+		IssuingURLs  []string                         `json:"issuingCertificateURLs,omitempty"`
+		Extensions   []certv1alpha1.RawExtension      `json:"extraExtensions,omitempty"`
+		PEMHeaders   bool                             `json:"pemHeaders,omitempty"`
+		PEMFormat    *certv1alpha1.PEMFormat          `json:"pemFormat,omitempty"`
+		IncludePKCS7 bool                             `json:"includePKCS7,omitempty"`
+		Profile      string                           `json:"profile,omitempty"`
+		Usages       []string                         `json:"usages,omitempty"`
+		ClientSecret string                           `json:"clientCertSecretName,omitempty"`
+		Kubeconfig   string                           `json:"kubeconfigSecretName,omitempty"`
+		KubeServer   *certv1alpha1.Kubeconfig         `json:"kubeconfig,omitempty"`
+		PublicSecret string                           `json:"publicSecretName,omitempty"`
+		Dependents   []certv1alpha1.DependentSecret   `json:"dependentSecrets,omitempty"`
+		CAKey        string                           `json:"caKey,omitempty"`
+		Immutable    bool                             `json:"immutableSecret,omitempty"`
+		// The pinned CA version signs the certificate, so moving the pin re-issues it
+		CAPin string `json:"pinCAFingerprint,omitempty"`
+	}{
+		CommonName:   cert.Spec.CommonName,
+		Subject:      cert.Spec.Subject,
+		DNSNames:     cert.Spec.DNSNames,
+		IPAddresses:  cert.Spec.IPAddresses,
+		Duration:     cert.Spec.Duration,
+		ExpiresAt:    cert.Spec.ExpiresAt,
+		IssuerRef:    cert.Spec.IssuerRef,
+		Serial:       cert.Spec.SerialNumberSource,
+		IsCA:         cert.Spec.IsCA,
+		MaxPathLen:   cert.Spec.MaxPathLen,
+		ServiceRef:   cert.Spec.ServiceRef,
+		KeyAlgorithm: cert.Spec.KeyAlgorithm,
+		KeySize:      cert.Spec.KeySize,
+		CSRSecretRef: cert.Spec.CSRSecretRef,
+		OCSPServers:  cert.Spec.OCSPServers,
+		IssuingURLs:  cert.Spec.IssuingCertificateURLs,
+		Extensions:   cert.Spec.ExtraExtensions,
+		PEMHeaders:   cert.Spec.PEMHeaders,
+		PEMFormat:    cert.Spec.PEMFormat,
+		IncludePKCS7: cert.Spec.IncludePKCS7,
+		Profile:      cert.Spec.Profile,
+		Usages:       cert.Spec.Usages,
+		ClientSecret: cert.Spec.ClientCertSecretName,
+		Kubeconfig:   cert.Spec.KubeconfigSecretName,
+		KubeServer:   cert.Spec.Kubeconfig,
+		PublicSecret: cert.Spec.PublicSecretName,
+		Dependents:   cert.Spec.DependentSecrets,
+		Immutable:    cert.Spec.ImmutableSecret,
+		CAPin:        cert.Annotations[pinCAFingerprintAnnotation],
+	}
+	// The default key is left out so the hash of existing certificates doesn't change
+	if key := caSecretKey(cert); key != defaultCASecretKey {
+		fields.CAKey = key
+	}
+
+	// Marshalling a struct of plain fields can't fail
+	data, _ := json.Marshal(fields)
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// issuedCertificate holds the output of a single issuance
+type issuedCertificate struct {
+	// certPEM is the leaf certificate followed by the issuer chain, if any
+	certPEM []byte
+	keyPEM  []byte
+	// caPEM is the CA chain written to the CA key (ca.crt by default); empty for self-signed certificates
+	caPEM        []byte
+	notBefore    time.Time
+	notAfter     time.Time
+	serialNumber string
+	// publicKeyPin is the base64 SHA-256 of the certificate's SubjectPublicKeyInfo
+	publicKeyPin string
+	// validityClamped is set when notAfter was shortened to the signing CA's expiry
+	validityClamped bool
+}
+
+// generateCertificate creates a new certificate, self-signed or signed by the referenced CA
+func (r *CertificateReconciler) generateCertificate(ctx context.Context, cert *certv1alpha1.Certificate) (issued *issuedCertificate, err error) {
+	ctx, span := r.startSpan(ctx, "generateCertificate", cert)
+	defer func() { endSpan(span, err) }()
+
+	if err := validateIPAddresses(cert); err != nil {
+		return nil, err
+	}
+	if err := validateClientCertSpec(cert); err != nil {
+		return nil, err
+	}
+	if err := validateSecretKeys(cert); err != nil {
+		return nil, err
+	}
+	if err := validateProfile(cert); err != nil {
+		return nil, err
+	}
+	keyUsage, extKeyUsage, err := certificateUsages(cert)
 	if err != nil {
-		return nil, nil, time.Time{}, time.Time{}, "", fmt.Errorf("failed to generate private key: %w", err)
+		return nil, err
 	}
+	return r.generateCertificateWithUsage(ctx, cert, keyUsage, extKeyUsage)
+}
 
-	// Parse duration (default to 90 days)
-	duration := 90 * 24 * time.Hour
-	if cert.Spec.Duration != "" {
-		duration, err = time.ParseDuration(cert.Spec.Duration)
+// generateCertificateWithUsage creates a new certificate with the given key usages. A zero
+// keyUsage picks the default for the key type and IsCA
+func (r *CertificateReconciler) generateCertificateWithUsage(ctx context.Context, cert *certv1alpha1.Certificate,
+	keyUsage x509.KeyUsage, extKeyUsage []x509.ExtKeyUsage) (*issuedCertificate, error) {
+	// A referenced CSR supplies the public key and an external key signs in place of the private
+	// key; otherwise generate the key pair
+	var privateKey crypto.Signer
+	var publicKey crypto.PublicKey
+	var keyPEM []byte
+	var csr *x509.CertificateRequest
+	var err error
+	if cert.Spec.CSRSecretRef != nil {
+		if csr, err = r.loadCSR(ctx, cert); err != nil {
+			return nil, err
+		}
+		publicKey = csr.PublicKey
+	} else if cert.Spec.IssuerRef.Kind == issuerKindExternalKey {
+		if privateKey, err = r.externalSigner(ctx, cert); err != nil {
+			return nil, err
+		}
+		publicKey = privateKey.Public()
+	} else {
+		algorithm, size := r.keySpec(cert)
+		privateKey, err = retryEntropy(ErrKeyGeneration, func() (crypto.Signer, error) {
+			key, encoded, err := generatePrivateKey(algorithm, size, r.random())
+			keyPEM = encoded
+			return key, err
+		})
 		if err != nil {
-			return nil, nil, time.Time{}, time.Time{}, "", fmt.Errorf("invalid duration: %w", err)
+			return nil, err
 		}
+		publicKey = privateKey.Public()
+	}
+	if err := r.enforceKeyPolicy(publicKey); err != nil {
+		return nil, err
 	}
 
 	notBefore := time.Now()
-	notAfter := notBefore.Add(duration)
+	notAfter, err := certificateNotAfter(cert, notBefore)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate serial number
-	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	serialNumber, err := r.serialNumber(ctx, cert)
 	if err != nil {
-		return nil, nil, time.Time{}, time.Time{}, "", fmt.Errorf("failed to generate serial number: %w", err)
+		return nil, err
 	}
 
-	// Parse IP addresses
-	var ipAddresses []net.IP
-	for _, ipStr := range cert.Spec.IPAddresses {
-		if ip := net.ParseIP(ipStr); ip != nil {
-			ipAddresses = append(ipAddresses, ip)
-		}
+	if err := validateAIAURLs(cert); err != nil {
+		return nil, err
+	}
+	extensions, err := extraExtensions(cert)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create certificate template
 	template := x509.Certificate{
-		SerialNumber: serialNumber,
-		Subject: pkix.Name{
-			CommonName:   cert.Spec.CommonName,
-			Organization: []string{"Certificate Operator"},
-		},
-		DNSNames:              cert.Spec.DNSNames,
-		IPAddresses:           ipAddresses,
+		SerialNumber:          serialNumber,
+		Subject:               r.certificateSubject(cert),
+		DNSNames:              certificateDNSNames(cert),
+		IPAddresses:           certificateIPAddresses(cert),
 		NotBefore:             notBefore,
 		NotAfter:              notAfter,
 		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		ExtKeyUsage:           extKeyUsage,
 		BasicConstraintsValid: true,
+		OCSPServer:            cert.Spec.OCSPServers,
+		IssuingCertificateURL: cert.Spec.IssuingCertificateURLs,
+		ExtraExtensions:       extensions,
+	}
+	// Key encipherment only applies to RSA keys
+	if _, ok := publicKey.(*rsa.PublicKey); !ok {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	if cert.Spec.IsCA {
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+		template.MaxPathLen = -1
+		if cert.Spec.MaxPathLen != nil {
+			template.MaxPathLen = int(*cert.Spec.MaxPathLen)
+			template.MaxPathLenZero = *cert.Spec.MaxPathLen == 0
+		}
+	}
+	if keyUsage != 0 {
+		template.KeyUsage = keyUsage
+	}
+
+	if csr != nil {
+		mergeCSRNames(&template, csr)
+	}
+	if err := r.enforceWildcardPolicy(&template); err != nil {
+		return nil, err
+	}
+	normalizeSANs(&template)
+	// SAN-only certificates omit the CN, but a certificate must identify something
+	if template.Subject.CommonName == "" && len(template.DNSNames) == 0 && len(template.IPAddresses) == 0 {
+		return nil, fmt.Errorf("%w: at least one of commonName, dnsNames or ipAddresses is required", ErrInvalidSpec)
+	}
+
+	// Self-sign the certificate unless a CA issuer is referenced
+	parent, signer := &template, privateKey
+	var chainPEM, caPEM []byte
+	validityClamped := false
+	if cert.Spec.IssuerRef.Kind == issuerKindCA {
+		ca, err := r.loadCA(ctx, cert)
+		if err != nil {
+			return nil, err
+		}
+		// A certificate must not outlive the CA that signed it
+		if notAfter.After(ca.cert.NotAfter) {
+			notAfter = ca.cert.NotAfter
+			template.NotAfter = notAfter
+			validityClamped = true
+		}
+		parent, signer, chainPEM, caPEM = ca.cert, ca.key, ca.chainPEM, ca.caPEM
+	}
+
+	certDER, err := x509.CreateCertificate(r.random(), &template, parent, publicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigning, err)
+	}
+
+	// A rotated self-signed CA ships cross-signed by its predecessor, so trust stores that only
+	// hold the previous CA keep validating its leaves during the migration
+	if cert.Spec.IsCA && parent == &template {
+		rotated, err := x509.ParseCertificate(certDER)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSigning, err)
+		}
+		crossPEM, err := r.crossSignCA(ctx, cert, &template, rotated)
+		if err != nil {
+			return nil, err
+		}
+		if crossPEM != nil {
+			caPEM = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), crossPEM...)
+		}
+	}
+
+	spki, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigning, err)
+	}
+
+	// Encode certificate to PEM, followed by the issuer chain
+	leafBlock := &pem.Block{Type: "CERTIFICATE", Bytes: certDER}
+	if cert.Spec.PEMHeaders {
+		leafBlock.Headers = certificatePEMHeaders(parent.Subject.String(), serialNumber, notAfter)
+	}
+	certPEM := pem.EncodeToMemory(leafBlock)
+	certPEM = append(certPEM, chainPEM...)
+
+	// Don't write a bundle consumers would reject, e.g. because ca.crt holds an unrelated root
+	if cert.Spec.IssuerRef.Kind == issuerKindCA {
+		verifyAt := time.Now()
+		if verifyAt.Before(notBefore) {
+			verifyAt = notBefore
+		}
+		if err := verifyIssuedChain(certPEM, caPEM, verifyAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return &issuedCertificate{
+		certPEM:         certPEM,
+		keyPEM:          keyPEM,
+		caPEM:           caPEM,
+		notBefore:       notBefore,
+		notAfter:        notAfter,
+		serialNumber:    fmt.Sprintf("%x", serialNumber),
+		publicKeyPin:    publicKeyPin(spki),
+		validityClamped: validityClamped,
+	}, nil
+}
+
+// generateCertificateWithTimeout bounds generateCertificate by the certificate's issuance timeout
+func (r *CertificateReconciler) generateCertificateWithTimeout(ctx context.Context, cert *certv1alpha1.Certificate) (*issuedCertificate, error) {
+	ctx, cancel, err := issuanceContext(ctx, cert)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	return r.generateCertificate(ctx, cert)
+}
+
+// issuanceContext bounds ctx by the certificate's issuance timeout, if it sets one
+func issuanceContext(ctx context.Context, cert *certv1alpha1.Certificate) (context.Context, context.CancelFunc, error) {
+	if cert.Spec.IssuanceTimeout == "" {
+		return ctx, func() {}, nil
+	}
+
+	timeout, err := time.ParseDuration(cert.Spec.IssuanceTimeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid issuance timeout: %w", ErrInvalidSpec, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// certificateDuration parses the certificate's validity (default to 90 days, or 10 years for CA certificates)
+func certificateDuration(cert *certv1alpha1.Certificate) (time.Duration, error) {
+	if cert.Spec.Duration == "" {
+		if cert.Spec.IsCA {
+			return defaultCADuration, nil
+		}
+		return 90 * 24 * time.Hour, nil
 	}
 
-	// Self-sign the certificate
-	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	duration, err := time.ParseDuration(cert.Spec.Duration)
 	if err != nil {
-		return nil, nil, time.Time{}, time.Time{}, "", fmt.Errorf("failed to create certificate: %w", err)
+		return 0, fmt.Errorf("%w: invalid duration: %w", ErrInvalidSpec, err)
+	}
+	return duration, nil
+}
+
+// certificateNotAfter returns when a certificate issued at notBefore expires: ExpiresAt when
+// set, otherwise notBefore plus the duration
+func certificateNotAfter(cert *certv1alpha1.Certificate, notBefore time.Time) (time.Time, error) {
+	if cert.Spec.ExpiresAt != nil {
+		if !cert.Spec.ExpiresAt.After(notBefore) {
+			return time.Time{}, fmt.Errorf("%w: expiresAt %s is not in the future", ErrInvalidSpec, cert.Spec.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		return cert.Spec.ExpiresAt.Time, nil
 	}
 
-	// Encode certificate to PEM
-	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	duration, err := certificateDuration(cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return notBefore.Add(duration), nil
+}
+
+// certificateSubject merges the certificate's subject over the controller defaults
+func (r *CertificateReconciler) certificateSubject(cert *certv1alpha1.Certificate) pkix.Name {
+	subject, defaultOrganization := r.DefaultSubject, "Certificate Operator"
+	if cert.Spec.IsCA {
+		subject, defaultOrganization = r.DefaultCASubject, "Certificate Operator CA"
+	}
+	if cert.Spec.Subject != nil {
+		if len(cert.Spec.Subject.Organizations) > 0 {
+			subject.Organizations = cert.Spec.Subject.Organizations
+		}
+		if len(cert.Spec.Subject.Countries) > 0 {
+			subject.Countries = cert.Spec.Subject.Countries
+		}
+		if len(cert.Spec.Subject.OrganizationalUnits) > 0 {
+			subject.OrganizationalUnits = cert.Spec.Subject.OrganizationalUnits
+		}
+	}
 
-	// Encode private key to PEM
-	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	organizations := subject.Organizations
+	if len(organizations) == 0 {
+		organizations = []string{defaultOrganization}
+	}
 
-	return certPEM, keyPEM, notBefore, notAfter, fmt.Sprintf("%x", serialNumber), nil
+	return pkix.Name{
+		CommonName:         cert.Spec.CommonName,
+		Organization:       organizations,
+		Country:            subject.Countries,
+		OrganizationalUnit: subject.OrganizationalUnits,
+	}
 }
 
 // createOrUpdateSecret creates or updates the TLS secret
-func (r *CertificateReconciler) createOrUpdateSecret(ctx context.Context, cert *certv1alpha1.Certificate, certPEM, keyPEM []byte) error {
+func (r *CertificateReconciler) createOrUpdateSecret(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) (err error) {
+	ctx, span := r.startSpan(ctx, "createOrUpdateSecret", cert)
+	defer func() { endSpan(span, err) }()
+
+	return r.writeSecret(ctx, cert, cert.Spec.SecretName, issued)
+}
+
+// writeSecret creates or updates a TLS secret owned by the certificate. Only the
+// SecretName secret is marked for host sync and written to SecretNamespace
+func (r *CertificateReconciler) writeSecret(ctx context.Context, cert *certv1alpha1.Certificate, name string, issued *issuedCertificate) error {
+	hostSync := name == cert.Spec.SecretName
+	namespace := cert.Namespace
+	if hostSync {
+		namespace = secretNamespace(cert)
+	}
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cert.Spec.SecretName,
-			Namespace: cert.Namespace,
+			Name:      name,
+			Namespace: namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/managed-by": "certificate-operator",
 				"cert.example.com/certificate": cert.Name,
@@ -270,31 +1232,122 @@ func (r *CertificateReconciler) createOrUpdateSecret(ctx context.Context, cert *
 		},
 		Type: corev1.SecretTypeTLS,
 		Data: map[string][]byte{
-			"tls.crt": certPEM,
-			"tls.key": keyPEM,
+			"tls.crt": issued.certPEM,
+			"tls.key": issued.keyPEM,
 		},
 	}
+	// Certificates signed from a CSR have no key to store, which a TLS secret requires
+	if len(issued.keyPEM) == 0 {
+		secret.Type = corev1.SecretTypeOpaque
+		delete(secret.Data, "tls.key")
+	}
+	if len(issued.caPEM) > 0 {
+		secret.Data[caSecretKey(cert)] = issued.caPEM
+	}
+	if cert.Spec.IncludePKCS7 {
+		bundle, err := encodePKCS7(issued.certPEM)
+		if err != nil {
+			return fmt.Errorf("failed to encode PKCS#7 bundle: %w", err)
+		}
+		secret.Data[pkcs7BundleKey] = bundle
+	}
+	if err := r.applyProfile(ctx, cert, secret, issued); err != nil {
+		return err
+	}
+	applyPEMFormat(cert, secret)
+	applySecretTemplate(cert, secret)
+	applyImmutability(cert, secret)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, specHashAnnotation, issuanceSpecHash(cert))
+	applyCertificateAnnotations(secret, issued)
+	if hostSync {
+		applyHostSyncMetadata(cert, secret)
+	}
 
-	// Set owner reference
-	if err := ctrl.SetControllerReference(cert, secret, r.Scheme); err != nil {
+	// Set owner reference, or a label pointing back across namespaces where one isn't allowed
+	if namespace != cert.Namespace {
+		secret.Labels[certificateNamespaceLabel] = cert.Namespace
+	} else if err := ctrl.SetControllerReference(cert, secret, r.Scheme); err != nil {
 		return fmt.Errorf("failed to set controller reference: %w", err)
 	}
 
-	// Try to get existing secret
-	existingSecret := &corev1.Secret{}
-	err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, existingSecret)
+	key := types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}
 
-	if err != nil && errors.IsNotFound(err) {
-		// Create new secret
-		return r.Create(ctx, secret)
-	} else if err != nil {
-		return err
+	// Retry the get-modify-update when another actor touches the secret in between
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// Try to get existing secret
+		existingSecret := &corev1.Secret{}
+		err := r.Get(ctx, key, existingSecret)
+
+		if err != nil && errors.IsNotFound(err) {
+			// Create new secret
+			err = r.Create(ctx, secret)
+			if !errors.IsAlreadyExists(err) {
+				return err
+			}
+
+			// A concurrent reconcile created the secret first, so update it instead
+			if err := r.Get(ctx, key, existingSecret); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		// Someone else's secret of the same name would be overwritten, and garbage collected with the
+		// Certificate
+		if !managesSecret(cert, existingSecret) {
+			return fmt.Errorf("%w: %s/%s", ErrSecretNotManaged, key.Namespace, key.Name)
+		}
+
+		// An immutable secret's data can't be updated, so it is replaced instead
+		if isImmutable(existingSecret) {
+			return r.replaceSecret(ctx, cert, existingSecret, secret)
+		}
+
+		// Update existing secret
+		existingSecret.Data = secret.Data
+		existingSecret.Immutable = secret.Immutable
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = map[string]string{}
+		}
+		maps.Copy(existingSecret.Labels, secret.Labels)
+		applySecretTemplate(cert, existingSecret)
+		metav1.SetMetaDataAnnotation(&existingSecret.ObjectMeta, specHashAnnotation, secret.Annotations[specHashAnnotation])
+		applyCertificateAnnotations(existingSecret, issued)
+		if hostSync {
+			applyHostSyncMetadata(cert, existingSecret)
+		}
+		return r.Update(ctx, existingSecret)
+	})
+}
+
+// deletePreviousSecret deletes the secret recorded in status when SecretName or SecretNamespace has since changed.
+// Only secrets labelled as managed for this certificate are deleted
+func (r *CertificateReconciler) deletePreviousSecret(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	previous := types.NamespacedName{Name: cert.Status.SecretName, Namespace: statusSecretNamespace(cert)}
+	if previous.Name == "" || previous == secretKey(cert) {
+		return nil
 	}
+	if previous.Namespace != cert.Namespace {
+		return r.deleteCrossNamespaceSecret(ctx, cert, previous)
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, previous, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if secret.Labels["app.kubernetes.io/managed-by"] != "certificate-operator" ||
+		secret.Labels["cert.example.com/certificate"] != cert.Name {
+		log.FromContext(ctx).Info("Leaving previous secret not managed for this certificate", "secret", previous.Name)
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, secret, client.Preconditions{UID: &secret.UID}))
+}
 
-	// Update existing secret
-	existingSecret.Data = secret.Data
-	existingSecret.Labels = secret.Labels
-	return r.Update(ctx, existingSecret)
+// isNamespaceTerminating reports whether err rejected a write because the namespace is being deleted
+func isNamespaceTerminating(err error) bool {
+	return errors.IsForbidden(err) && errors.HasStatusCause(err, corev1.NamespaceTerminatingCause)
 }
 
 // calculateRenewalTime determines when the certificate should be renewed
@@ -314,12 +1367,29 @@ func (r *CertificateReconciler) calculateRenewalTime(cert *certv1alpha1.Certific
 }
 
 // getRequeueTime calculates when to requeue the reconciliation
-func (r *CertificateReconciler) getRequeueTime(cert *certv1alpha1.Certificate) time.Duration {
-	if cert.Status.RenewalTime == nil {
+func (r *CertificateReconciler) getRequeueTime(ctx context.Context, cert *certv1alpha1.Certificate) time.Duration {
+	// A due renewal held for the renewal window is retried when the window opens
+	if cert.Status.RenewalTime != nil && time.Now().After(cert.Status.RenewalTime.Time) {
+		if held, opensIn := renewalHeld(cert, time.Now()); held {
+			return r.clampRequeue(opensIn)
+		}
+	}
+	requeue := renewalRequeueTime(cert)
+	// CA-issued leaves also wake as their CA nears expiry, to follow its rotation
+	if untilCA, ok := r.caRequeueTime(ctx, cert); ok && untilCA < requeue {
+		requeue = untilCA
+	}
+	return r.clampRequeue(requeue)
+}
+
+// renewalRequeueTime derives the unclamped requeue interval from the renewal time
+func renewalRequeueTime(cert *certv1alpha1.Certificate) time.Duration {
+	renewalTime := earliestRenewalTime(cert)
+	if renewalTime == nil {
 		return time.Minute
 	}
 
-	timeUntilRenewal := time.Until(cert.Status.RenewalTime.Time)
+	timeUntilRenewal := time.Until(renewalTime.Time)
 	if timeUntilRenewal < 0 {
 		return time.Minute
 	}
@@ -332,74 +1402,160 @@ func (r *CertificateReconciler) getRequeueTime(cert *certv1alpha1.Certificate) t
 	return timeUntilRenewal - time.Hour
 }
 
+// clampRequeue bounds a requeue interval by MinRequeue and MaxRequeue
+func (r *CertificateReconciler) clampRequeue(requeue time.Duration) time.Duration {
+	if r.MaxRequeue > 0 && requeue > r.MaxRequeue {
+		requeue = r.MaxRequeue
+	}
+	if requeue < r.MinRequeue {
+		requeue = r.MinRequeue
+	}
+	return requeue
+}
+
 // restartDeployments triggers rolling restart of deployments using this certificate
 func (r *CertificateReconciler) restartDeployments(ctx context.Context, cert *certv1alpha1.Certificate) error {
 	logger := log.FromContext(ctx)
 
-	// List all deployments in the namespace
+	// Serialize restarts within the namespace so a shared secret doesn't restart everything at once.
+	// Over the limit the restart stays pending and Reconcile retries it
+	namespace := consumerNamespace(cert)
+	release, ok := r.restartLimiter.tryAcquire(namespace, r.MaxRestartsPerNamespace)
+	if !ok {
+		logger.Info("Deferring deployment restart, namespace restart limit reached")
+		return r.deferRestart(ctx, cert, "RestartLimitReached",
+			fmt.Sprintf("%d restart(s) already running in namespace %s", r.MaxRestartsPerNamespace, namespace))
+	}
+	defer release()
+
+	// List all deployments in the secret's namespace
 	deployments := &appsv1.DeploymentList{}
-	if err := r.List(ctx, deployments, client.InNamespace(cert.Namespace)); err != nil {
+	if err := r.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("failed to list deployments: %w", err)
 	}
 
-	restartedCount := 0
+	var restarted, evicting []string
+	matched := 0
 	for i := range deployments.Items {
 		deploy := &deployments.Items[i]
 
 		// Check if deployment uses this secret
-		if r.deploymentUsesSecret(deploy, cert.Spec.SecretName) {
-			logger.Info("Restarting deployment", "deployment", deploy.Name)
-
-			// Trigger rolling restart by updating annotation
-			if deploy.Spec.Template.Annotations == nil {
-				deploy.Spec.Template.Annotations = make(map[string]string)
-			}
-			deploy.Spec.Template.Annotations["cert.example.com/restartedAt"] = time.Now().Format(time.RFC3339)
+		if !r.deploymentUsesSecret(deploy, consumerSecretName(cert)) {
+			continue
+		}
+		matched++
+		// A retried restart leaves the deployments already restarted for this certificate alone
+		if restartedForSerial(deploy, cert.Status.SerialNumber) {
+			restarted = append(restarted, deploy.Name)
+			continue
+		}
+		logger.Info("Restarting deployment", "deployment", deploy.Name)
 
-			if err := r.Update(ctx, deploy); err != nil {
-				logger.Error(err, "Failed to restart deployment", "deployment", deploy.Name)
-				continue
-			}
-			restartedCount++
+		pending, err := r.restartDeployment(ctx, cert, deploy)
+		if err != nil {
+			logger.Error(err, "Failed to restart deployment", "deployment", deploy.Name)
+			continue
+		}
+		if pending {
+			evicting = append(evicting, deploy.Name)
+			continue
 		}
+		restarted = append(restarted, deploy.Name)
+	}
+
+	logger.Info("Deployment restart completed", "count", len(restarted))
+	if matched == 0 {
+		// Restarting worked but had nothing to do, which otherwise looks like the feature is broken
+		secretName := consumerSecretName(cert)
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeNoConsumersFoundCert,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoMatchingDeployments",
+			Message:            fmt.Sprintf("No deployment in namespace %s mounts or references secret %s", namespace, secretName),
+			LastTransitionTime: metav1.Now(),
+		})
+		r.Recorder.Eventf(cert, corev1.EventTypeNormal, typeNoConsumersFoundCert,
+			"No deployment uses secret %s, nothing to restart", secretName)
+	} else {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeNoConsumersFoundCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ConsumersFound",
+			Message:            fmt.Sprintf("%d deployment(s) use the secret", matched),
+			LastTransitionTime: metav1.Now(),
+		})
 	}
 
-	logger.Info("Deployment restart completed", "count", restartedCount)
+	// Pods are evicted one at a time as replacements become ready, so Reconcile keeps going
+	if len(evicting) > 0 {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeRestartPendingCert,
+			Status:             metav1.ConditionTrue,
+			Reason:             "EvictionInProgress",
+			Message:            fmt.Sprintf("Evicting the pods of deployment(s) %s", strings.Join(evicting, ", ")),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeRestartPendingCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Restarted",
+			Message:            fmt.Sprintf("Restarted %d of %d deployment(s)", len(restarted), matched),
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	// Keep an audit trail of what the last rotation bounced
+	if len(restarted) > 0 {
+		cert.Status.RestartedWorkloads = restarted
+		cert.Status.LastRestartTime = &metav1.Time{Time: time.Now()}
+	}
+	if err := r.updateStatus(ctx, cert); err != nil {
+		return fmt.Errorf("failed to record restarted deployments: %w", err)
+	}
 	return nil
 }
 
-// deploymentUsesSecret checks if a deployment references a specific secret
-func (r *CertificateReconciler) deploymentUsesSecret(deploy *appsv1.Deployment, secretName string) bool {
-	// Check volumes
-	for _, volume := range deploy.Spec.Template.Spec.Volumes {
-		if volume.Secret != nil && volume.Secret.SecretName == secretName {
-			return true
-		}
+// deferRestart records that the restart of the certificate's consumers is still pending, so
+// Reconcile retries it
+func (r *CertificateReconciler) deferRestart(ctx context.Context, cert *certv1alpha1.Certificate, reason, message string) error {
+	meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeRestartPendingCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.updateStatus(ctx, cert); err != nil {
+		return fmt.Errorf("failed to record pending restart: %w", err)
 	}
+	return nil
+}
 
-	// Check environment variables from secrets
-	for _, container := range deploy.Spec.Template.Spec.Containers {
-		for _, envFrom := range container.EnvFrom {
-			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
-				return true
-			}
-		}
-		for _, env := range container.Env {
-			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-				if env.ValueFrom.SecretKeyRef.Name == secretName {
-					return true
-				}
-			}
-		}
-	}
+// restartedForSerial reports whether the deployment's pods were already restarted for serial,
+// by rolling its template or by evicting its pods
+func restartedForSerial(deploy *appsv1.Deployment, serial string) bool {
+	return serial != "" && (deploy.Spec.Template.Annotations[consumerSerialAnnotation] == serial ||
+		deploy.Annotations[consumerSerialAnnotation] == serial)
+}
 
-	return false
+// deploymentUsesSecret checks if a deployment references a specific secret
+func (r *CertificateReconciler) deploymentUsesSecret(deploy *appsv1.Deployment, secretName string) bool {
+	return podSpecUsesSecret(&deploy.Spec.Template.Spec, secretName)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *CertificateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&certv1alpha1.Certificate{}).
+		For(&certv1alpha1.Certificate{}, builder.WithPredicates(r.selectorPredicate(), r.changePredicate())).
 		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.certificateForSecret)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.certificatesForImportedSecret)).
+		Watches(&certv1alpha1.CertificateTemplate{}, handler.EnqueueRequestsFromMapFunc(r.certificatesForTemplate)).
+		Watches(&certv1alpha1.Issuer{}, handler.EnqueueRequestsFromMapFunc(r.certificatesForIssuer)).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(r.certificatesForDeployment)).
+		Watches(&certv1alpha1.Certificate{}, handler.EnqueueRequestsFromMapFunc(r.certificatesForSecretName)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
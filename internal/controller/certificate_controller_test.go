@@ -18,12 +18,35 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -51,7 +74,10 @@ var _ = Describe("Certificate Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: certv1alpha1.CertificateSpec{
+						CommonName: "test.example.com",
+						SecretName: "test-resource-tls",
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -69,8 +95,9 @@ var _ = Describe("Certificate Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &CertificateReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +108,975 @@ var _ = Describe("Certificate Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When referencing networking resources", func() {
+		It("should point the Ingress TLS block at the certificate secret", func() {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			}
+			cert := newTestCertificate("web-cert")
+			cert.Spec.DNSNames = []string{"web.example.com"}
+			cert.Spec.IngressRef = &certv1alpha1.IngressRef{Name: "web"}
+
+			r := newCachedReconciler(cert, ingress)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &networkingv1.Ingress{}
+			Expect(r.APIReader.Get(ctx, client.ObjectKeyFromObject(ingress), updated)).To(Succeed())
+			Expect(updated.Spec.TLS).To(HaveLen(1))
+			Expect(updated.Spec.TLS[0].SecretName).To(Equal(cert.Spec.SecretName))
+			Expect(updated.Spec.TLS[0].Hosts).To(ConsistOf("web.example.com"))
+		})
+
+		It("should wire an Ingress referenced after issuance and retry a failed patch", func() {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "default"},
+			}
+			cert := newTestCertificate("late-ingress-cert")
+			cert.Spec.DNSNames = []string{"late.example.com"}
+			r := newCachedReconciler(cert)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			issued := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, req.NamespacedName, issued)).To(Succeed())
+			issued.Spec.IngressRef = &certv1alpha1.IngressRef{Name: ingress.Name}
+			Expect(r.Update(ctx, issued)).To(Succeed())
+
+			// The Ingress doesn't exist yet, so the reconcile fails and is retried
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).To(HaveOccurred())
+
+			Expect(r.Create(ctx, ingress)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &networkingv1.Ingress{}
+			Expect(r.APIReader.Get(ctx, client.ObjectKeyFromObject(ingress), updated)).To(Succeed())
+			Expect(updated.Spec.TLS).To(ConsistOf(networkingv1.IngressTLS{Hosts: []string{"late.example.com"}, SecretName: cert.Spec.SecretName}))
+		})
+
+		It("should reuse an existing TLS block covering the certificate hosts", func() {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}, SecretName: "stale"}},
+				},
+			}
+			cert := newTestCertificate("web-cert")
+			cert.Spec.DNSNames = []string{"web.example.com"}
+			cert.Spec.IngressRef = &certv1alpha1.IngressRef{Name: "web"}
+
+			r := newFakeReconciler(cert, ingress)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &networkingv1.Ingress{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(ingress), updated)).To(Succeed())
+			Expect(updated.Spec.TLS).To(HaveLen(1))
+			Expect(updated.Spec.TLS[0].SecretName).To(Equal(cert.Spec.SecretName))
+		})
+	})
+
+	Context("When the renew-if-before annotation is set", func() {
+		It("should force renewal of an otherwise healthy certificate and clear the annotation", func() {
+			cert := newTestCertificate("renew-cert")
+			cert.Finalizers = []string{DefaultFinalizer}
+			cert.Annotations = map[string]string{renewIfBeforeAnnotation: "8760h"}
+			cert.Status = certv1alpha1.CertificateStatus{
+				NotAfter:     &metav1.Time{Time: time.Now().Add(60 * 24 * time.Hour)},
+				RenewalTime:  &metav1.Time{Time: time.Now().Add(30 * 24 * time.Hour)},
+				SerialNumber: "original",
+			}
+
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).NotTo(Equal("original"))
+			Expect(updated.Annotations).NotTo(HaveKey(renewIfBeforeAnnotation))
+		})
+
+		It("should warn about and clear an unparseable window without renewing", func() {
+			cert := newTestCertificate("renew-invalid")
+			cert.Finalizers = []string{DefaultFinalizer}
+			cert.Annotations = map[string]string{renewIfBeforeAnnotation: "a year"}
+			cert.Status = certv1alpha1.CertificateStatus{
+				NotAfter:     &metav1.Time{Time: time.Now().Add(60 * 24 * time.Hour)},
+				RenewalTime:  &metav1.Time{Time: time.Now().Add(30 * 24 * time.Hour)},
+				SerialNumber: "original",
+			}
+
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).To(Equal("original"))
+			Expect(updated.Annotations).NotTo(HaveKey(renewIfBeforeAnnotation))
+			Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Warning InvalidRenewIfBefore")))
+		})
+
+		It("should not renew when the certificate expires outside the window", func() {
+			cert := newTestCertificate("renew-cert")
+			cert.Annotations = map[string]string{renewIfBeforeAnnotation: "24h"}
+			cert.Status = certv1alpha1.CertificateStatus{
+				NotAfter:    &metav1.Time{Time: time.Now().Add(60 * 24 * time.Hour)},
+				RenewalTime: &metav1.Time{Time: time.Now().Add(30 * 24 * time.Hour)},
+			}
+
+			r := newFakeReconciler()
+			Expect(r.needsRenewal(cert)).To(BeFalse())
+		})
+	})
+
+	Context("When a status ConfigMap is requested", func() {
+		It("should create the ConfigMap and keep it in sync with status", func() {
+			cert := newTestCertificate("dash-cert")
+			cert.Spec.StatusConfigMapName = "dash-cert-status"
+
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			issued := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), issued)).To(Succeed())
+
+			configMap := &corev1.ConfigMap{}
+			configMapKey := types.NamespacedName{Name: "dash-cert-status", Namespace: "default"}
+			Expect(r.Get(ctx, configMapKey, configMap)).To(Succeed())
+			Expect(configMap.Labels).To(HaveKeyWithValue("cert.example.com/certificate", "dash-cert"))
+			Expect(configMap.Data).To(HaveKeyWithValue("serialNumber", issued.Status.SerialNumber))
+			Expect(configMap.Data).To(HaveKeyWithValue("fingerprint", issued.Status.Fingerprint))
+			Expect(configMap.Data).To(HaveKeyWithValue("notAfter", issued.Status.NotAfter.Format(time.RFC3339)))
+			Expect(issued.Status.Fingerprint).NotTo(BeEmpty())
+
+			By("renewing the certificate")
+			issued.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(r.Status().Update(ctx, issued)).To(Succeed())
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			renewed := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), renewed)).To(Succeed())
+			Expect(renewed.Status.SerialNumber).NotTo(Equal(issued.Status.SerialNumber))
+			Expect(r.Get(ctx, configMapKey, configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveKeyWithValue("serialNumber", renewed.Status.SerialNumber))
+			Expect(configMap.Data).To(HaveKeyWithValue("fingerprint", renewed.Status.Fingerprint))
+		})
+	})
+
+	Context("When certificate generation fails", func() {
+		It("should report an invalid spec without retrying", func() {
+			cert := newTestCertificate("bad-duration")
+			cert.Spec.Duration = "ninety days"
+
+			r := newFakeReconciler(cert)
+			_, genErr := r.generateCertificate(ctx, cert)
+			Expect(genErr).To(MatchError(ErrInvalidSpec))
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal("InvalidSpec"))
+		})
+	})
+
+	Context("When the controller has a default subject", func() {
+		defaults := certv1alpha1.Subject{
+			Organizations:       []string{"Example Corp"},
+			Countries:           []string{"US"},
+			OrganizationalUnits: []string{"Platform"},
+		}
+
+		It("should apply the defaults to a certificate without a subject", func() {
+			cert := newTestCertificate("default-subject")
+			r := newFakeReconciler()
+			r.DefaultSubject = defaults
+
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed := parseCertificatePEM(issued.certPEM)
+			Expect(parsed.Subject.CommonName).To(Equal(cert.Spec.CommonName))
+			Expect(parsed.Subject.Organization).To(Equal([]string{"Example Corp"}))
+			Expect(parsed.Subject.Country).To(Equal([]string{"US"}))
+			Expect(parsed.Subject.OrganizationalUnit).To(Equal([]string{"Platform"}))
+		})
+
+		It("should let per-certificate subject fields override the defaults", func() {
+			cert := newTestCertificate("custom-subject")
+			cert.Spec.Subject = &certv1alpha1.Subject{Organizations: []string{"Team A"}}
+			r := newFakeReconciler()
+			r.DefaultSubject = defaults
+
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed := parseCertificatePEM(issued.certPEM)
+			Expect(parsed.Subject.Organization).To(Equal([]string{"Team A"}))
+			Expect(parsed.Subject.Country).To(Equal([]string{"US"}))
+		})
+	})
+
+	Context("When the spec changes after issuance", func() {
+		issue := func(r *CertificateReconciler, cert *certv1alpha1.Certificate) *certv1alpha1.Certificate {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			issued := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), issued)).To(Succeed())
+			return issued
+		}
+
+		It("should re-issue when a SAN is added", func() {
+			cert := newTestCertificate("san-cert")
+			cert.Spec.DNSNames = []string{"a.example.com"}
+			r := newFakeReconciler(cert)
+			issued := issue(r, cert)
+			Expect(issued.Status.SpecHash).NotTo(BeEmpty())
+
+			issued.Spec.DNSNames = append(issued.Spec.DNSNames, "b.example.com")
+			Expect(r.Update(ctx, issued)).To(Succeed())
+			Expect(r.needsRenewal(issued)).To(BeTrue())
+
+			reissued := issue(r, issued)
+			Expect(reissued.Status.SerialNumber).NotTo(Equal(issued.Status.SerialNumber))
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, types.NamespacedName{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			Expect(parseCertificatePEM(secret.Data["tls.crt"]).DNSNames).To(ConsistOf("a.example.com", "b.example.com"))
+		})
+
+		It("should not re-issue for unrelated metadata edits", func() {
+			cert := newTestCertificate("san-cert")
+			r := newFakeReconciler(cert)
+			issued := issue(r, cert)
+
+			issued.Labels = map[string]string{"team": "platform"}
+			issued.Spec.RestartDeployments = true
+			Expect(r.Update(ctx, issued)).To(Succeed())
+			Expect(r.needsRenewal(issued)).To(BeFalse())
+
+			reconciled := issue(r, issued)
+			Expect(reconciled.Status.SerialNumber).To(Equal(issued.Status.SerialNumber))
+		})
+	})
+
+	Context("When reconciling concurrently", func() {
+		It("should issue every certificate without secret conflicts", func() {
+			var certs []client.Object
+			for i := range 10 {
+				certs = append(certs, newTestCertificate(fmt.Sprintf("concurrent-%d", i)))
+			}
+			r := newFakeReconciler(certs...)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, len(certs))
+			for _, cert := range certs {
+				wg.Add(1)
+				go func(key client.ObjectKey) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+					errs <- err
+				}(client.ObjectKeyFromObject(cert))
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				Expect(err).NotTo(HaveOccurred())
+			}
+			secrets := &corev1.SecretList{}
+			Expect(r.List(ctx, secrets, client.InNamespace("default"))).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(len(certs)))
+		})
+
+		It("should update the secret when another worker created it first", func() {
+			cert := newTestCertificate("raced-cert")
+			r := newFakeReconciler(cert)
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						// Simulate a concurrent worker winning the create race
+						winner := secret.DeepCopy()
+						winner.Data = map[string][]byte{"tls.crt": []byte("stale")}
+						Expect(c.Create(ctx, winner, opts...)).To(Succeed())
+						return errors.NewAlreadyExists(corev1.Resource("secrets"), secret.Name)
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+
+			Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")})).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, types.NamespacedName{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("tls.crt", []byte("cert")))
+		})
+	})
+
+	Context("When the secret update conflicts", func() {
+		It("should retry and succeed", func() {
+			cert := newTestCertificate("conflict-cert")
+			existing := newOwnedSecret(cert)
+			r := newFakeReconciler(cert, existing)
+
+			updates := 0
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*corev1.Secret); ok {
+						updates++
+						if updates == 1 {
+							return errors.NewConflict(corev1.Resource("secrets"), obj.GetName(), fmt.Errorf("object was modified"))
+						}
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			})
+
+			Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")})).To(Succeed())
+			Expect(updates).To(Equal(2))
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("tls.key", []byte("key")))
+		})
+	})
+
+	Context("When removing the finalizer fails", func() {
+		newDeletingCertificate := func(name string) *certv1alpha1.Certificate {
+			cert := newTestCertificate(name)
+			cert.Finalizers = []string{DefaultFinalizer}
+			cert.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			return cert
+		}
+
+		It("should give up after bounded retries, emit a warning and requeue", func() {
+			cert := newDeletingCertificate("stuck-finalizer")
+			r := newFakeReconciler(cert)
+			recorder := r.Recorder.(*record.FakeRecorder)
+			attempts := 0
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*certv1alpha1.Certificate); ok {
+						attempts++
+						return fmt.Errorf("admission webhook denied the request")
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(finalizerRemovalRequeue))
+			Expect(attempts).To(Equal(finalizerRemovalBackoff.Steps))
+			Expect(recorder.Events).To(Receive(ContainSubstring("FinalizerRemovalFailed")))
+		})
+
+		It("should remove the finalizer once a retry succeeds", func() {
+			cert := newDeletingCertificate("flaky-finalizer")
+			r := newFakeReconciler(cert)
+			attempts := 0
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*certv1alpha1.Certificate); ok {
+						if attempts++; attempts < 3 {
+							return fmt.Errorf("transient failure")
+						}
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(attempts).To(Equal(3))
+			err = r.Get(ctx, client.ObjectKeyFromObject(cert), &certv1alpha1.Certificate{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the status update fails after issuance", func() {
+		It("should requeue and record the written certificate without a new key", func() {
+			cert := newTestCertificate("status-update-failure")
+			r := newFakeReconciler(cert)
+			failed := false
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if updated, ok := obj.(*certv1alpha1.Certificate); ok && !failed && updated.Status.SerialNumber != "" {
+						failed = true
+						return fmt.Errorf("etcd timeout")
+					}
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failed).To(BeTrue())
+			Expect(result.RequeueAfter).To(Equal(statusUpdateRetryDelay))
+
+			secret := &corev1.Secret{}
+			secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+			Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+			firstKey := secret.Data["tls.key"]
+
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+			Expect(secret.Data["tls.key"]).To(Equal(firstKey))
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).To(Equal(fmt.Sprintf("%x", parseCertificatePEM(secret.Data["tls.crt"]).SerialNumber)))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+		})
+
+		It("should not recover a secret issued from a different spec", func() {
+			cert := newTestCertificate("status-stale-spec")
+			r := newFakeReconciler(cert)
+			issued, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+			cert.Spec.DNSNames = []string{"changed.example.com"}
+			Expect(r.recoverIssuedCertificate(ctx, cert)).To(BeNil())
+		})
+	})
+
+	Context("When SecretName changes", func() {
+		rename := func(r *CertificateReconciler, cert *certv1alpha1.Certificate, secretName string) {
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			updated.Spec.SecretName = secretName
+			ExpectWithOffset(1, r.Update(ctx, updated)).To(Succeed())
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		}
+
+		It("should write the new secret and delete the old one", func() {
+			cert := newTestCertificate("renamed-secret")
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			rename(r, cert, "renamed-secret-v2")
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: "renamed-secret-v2", Namespace: "default"}, &corev1.Secret{})).To(Succeed())
+			err = r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SecretName).To(Equal("renamed-secret-v2"))
+		})
+
+		It("should keep a previous secret that isn't managed for the certificate", func() {
+			cert := newTestCertificate("renamed-foreign")
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Someone else took over the old secret
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			secret.Labels = map[string]string{"app.kubernetes.io/managed-by": "helm"}
+			Expect(r.Update(ctx, secret)).To(Succeed())
+
+			rename(r, cert, "renamed-foreign-v2")
+
+			Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})).To(Succeed())
+		})
+	})
+
+	Context("When the namespace is terminating", func() {
+		It("should report NamespaceTerminating and requeue without an error", func() {
+			cert := newTestCertificate("terminating-namespace")
+			r := newFakeReconciler(cert)
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if _, ok := obj.(*corev1.Secret); ok {
+						forbidden := errors.NewForbidden(corev1.Resource("secrets"), obj.GetName(),
+							fmt.Errorf("unable to create new content in namespace default because it is being terminated"))
+						forbidden.ErrStatus.Details.Causes = []metav1.StatusCause{{
+							Type:    corev1.NamespaceTerminatingCause,
+							Message: "namespace default is being terminated",
+							Field:   "metadata.namespace",
+						}}
+						return forbidden
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(namespaceTerminatingRequeue))
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal("NamespaceTerminating"))
+		})
+
+		It("should not treat other forbidden errors as a terminating namespace", func() {
+			err := errors.NewForbidden(corev1.Resource("secrets"), "test", fmt.Errorf("RBAC denied"))
+			Expect(isNamespaceTerminating(err)).To(BeFalse())
+		})
+	})
+
+	Context("When issuance exceeds the issuance timeout", func() {
+		It("should give up and report IssuanceTimedOut", func() {
+			ca := newKeyPairSecret("slow-ca", "Slow CA", true, 24*time.Hour)
+			cert := newTestCertificate("slow-issuer")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+			cert.Spec.IssuanceTimeout = "50ms"
+			r := newFakeReconciler(cert, ca)
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if key.Name == ca.Name {
+						// The issuer backend hangs until the caller gives up
+						<-ctx.Done()
+						return ctx.Err()
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			})
+
+			start := time.Now()
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("IssuanceTimedOut"))
+		})
+
+		It("should reject an invalid issuance timeout", func() {
+			cert := newTestCertificate("bad-timeout")
+			cert.Spec.IssuanceTimeout = "soon"
+			_, err := newFakeReconciler().generateCertificateWithTimeout(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		})
+	})
+
+	Context("When a certificate re-issues repeatedly", func() {
+		It("should stop issuing once the hourly budget is spent", func() {
+			cert := newTestCertificate("issuance-loop")
+			r := newFakeReconciler(cert)
+			r.MaxIssuancesPerHour = 3
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+			forceRenewal := func() {
+				updated := &certv1alpha1.Certificate{}
+				ExpectWithOffset(1, r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				updated.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+				ExpectWithOffset(1, r.Status().Update(ctx, updated)).To(Succeed())
+			}
+
+			for range 3 {
+				_, err := r.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				forceRenewal()
+			}
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.IssuancesInWindow).To(Equal(int32(3)))
+			lastSerial := updated.Status.SerialNumber
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", issuanceBudgetWindow))
+
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).To(Equal(lastSerial))
+			ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("IssuanceBudgetExceeded"))
+		})
+
+		It("should allow issuance again once the window has passed", func() {
+			cert := newTestCertificate("issuance-window-reset")
+			cert.Status.IssuanceWindowStart = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			cert.Status.IssuancesInWindow = 10
+			r := newFakeReconciler()
+			r.MaxIssuancesPerHour = 3
+
+			exhausted, _ := r.issuanceBudgetExhausted(cert)
+			Expect(exhausted).To(BeFalse())
+			Expect(cert.Status.IssuancesInWindow).To(BeZero())
+		})
+	})
+	Context("When computing the requeue interval", func() {
+		renewingIn := func(d time.Duration) *certv1alpha1.Certificate {
+			cert := newTestCertificate("requeue")
+			cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(d)}
+			return cert
+		}
+
+		It("should leave the interval unbounded by default", func() {
+			r := newFakeReconciler()
+			Expect(r.getRequeueTime(ctx, renewingIn(30*24*time.Hour))).To(BeNumerically(">", 24*time.Hour))
+			Expect(r.getRequeueTime(ctx, renewingIn(2*time.Second))).To(BeNumerically("<=", time.Second))
+		})
+
+		It("should clamp the interval to the configured ceiling", func() {
+			r := newFakeReconciler()
+			r.MaxRequeue = 6 * time.Hour
+			Expect(r.getRequeueTime(ctx, renewingIn(30*24*time.Hour))).To(Equal(6 * time.Hour))
+		})
+
+		It("should clamp the interval to the configured floor", func() {
+			r := newFakeReconciler()
+			r.MinRequeue = 30 * time.Second
+			Expect(r.getRequeueTime(ctx, renewingIn(2*time.Second))).To(Equal(30 * time.Second))
+			Expect(r.getRequeueTime(ctx, newTestCertificate("unissued"))).To(Equal(time.Minute))
+		})
+	})
+	Context("When the common name changes", func() {
+		It("should set SubjectChanged after the re-issue", func() {
+			cert := newTestCertificate("renamed")
+			r := newFakeReconciler(cert)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.CommonName).To(Equal("renamed.example.com"))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, typeSubjectChangedCert)).To(BeNil())
+
+			updated.Spec.CommonName = "renamed.example.org"
+			Expect(r.Update(ctx, updated)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			changed := meta.FindStatusCondition(updated.Status.Conditions, typeSubjectChangedCert)
+			Expect(changed).NotTo(BeNil())
+			Expect(changed.Status).To(Equal(metav1.ConditionTrue))
+			Expect(changed.Reason).To(Equal("CommonNameChanged"))
+			Expect(changed.Message).To(ContainSubstring("renamed-tls"))
+			Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("SubjectChanged")))
+
+			// A later re-issue that keeps the name clears it
+			updated.Spec.DNSNames = []string{"renamed.example.org"}
+			Expect(r.Update(ctx, updated)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, typeSubjectChangedCert)).To(BeTrue())
+		})
+	})
+	Context("When recording the issued serial", func() {
+		It("should always match the certificate in the secret after a reconcile", func() {
+			cert := newTestCertificate("serial-consistent")
+			r := newFakeReconciler(cert)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+			for range 3 {
+				updated := &certv1alpha1.Certificate{}
+				Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				updated.Annotations = map[string]string{renewIfBeforeAnnotation: "8760h"}
+				Expect(r.Update(ctx, updated)).To(Succeed())
+
+				_, err := r.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				secret := &corev1.Secret{}
+				Expect(r.Get(ctx, types.NamespacedName{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+				leaf := parseCertificatePEM(secret.Data["tls.crt"])
+				Expect(updated.Status.SerialNumber).To(Equal(fmt.Sprintf("%x", leaf.SerialNumber)))
+				Expect(updated.Status.Fingerprint).To(Equal(certificateFingerprint(secret.Data["tls.crt"])))
+			}
+		})
+
+		It("should not record a serial the secret no longer holds", func() {
+			cert := newTestCertificate("serial-overwritten")
+			r := newFakeReconciler(cert)
+			other, err := r.generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Another writer replaces the secret right after the controller's write
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						secret.Data["tls.crt"] = other.certPEM
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).To(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).To(BeEmpty())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretMismatch"))
+		})
+
+		It("should verify the serial against the API server rather than a stale cache", func() {
+			cert := newTestCertificate("serial-stale-cache")
+			r := newFakeReconciler(cert)
+			r.APIReader = r.Client
+			// The cache never catches up with the secret the controller writes
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*corev1.Secret); ok && key.Name == cert.Spec.SecretName {
+						return errors.NewNotFound(corev1.Resource("secrets"), key.Name)
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			})
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+		})
+	})
+	Context("When restarting deployments", func() {
+		It("should record the restarted deployments in status", func() {
+			cert := newTestCertificate("restart-audit")
+			cert.Spec.RestartDeployments = true
+			r := newFakeReconciler(cert,
+				newConsumerDeployment("api", cert.Spec.SecretName),
+				newConsumerDeployment("worker", cert.Spec.SecretName),
+				newConsumerDeployment("unrelated", "other-tls"))
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Status.RestartedWorkloads).To(ConsistOf("api", "worker"))
+			Expect(updated.Status.LastRestartTime).NotTo(BeNil())
+			Expect(updated.Status.LastRestartTime.Time).To(BeTemporally("~", time.Now(), 5*time.Second))
+			Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, typeNoConsumersFoundCert)).To(BeTrue())
+		})
+
+		It("should report when no deployment uses the secret", func() {
+			cert := newTestCertificate("restart-nothing")
+			cert.Spec.RestartDeployments = true
+			r := newFakeReconciler(cert, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+			})
+			recorder := r.Recorder.(*record.FakeRecorder)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, typeNoConsumersFoundCert)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("NoMatchingDeployments"))
+			Expect(condition.Message).To(ContainSubstring(cert.Spec.SecretName))
+			Expect(updated.Status.RestartedWorkloads).To(BeEmpty())
+			Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring(typeNoConsumersFoundCert)))
+		})
+	})
+
+	Context("When a custom finalizer is configured", func() {
+		It("should add and remove the configured finalizer", func() {
+			cert := newTestCertificate("custom-finalizer")
+			r := newFakeReconciler(cert)
+			r.Finalizer = "fork.example.org/cleanup"
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ConsistOf("fork.example.org/cleanup"))
+
+			By("deleting the certificate")
+			Expect(r.Delete(ctx, updated)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(r.Get(ctx, req.NamespacedName, &certv1alpha1.Certificate{}))).To(BeTrue())
+		})
+
+		It("should leave another build's finalizer alone", func() {
+			cert := newTestCertificate("foreign-finalizer")
+			cert.Finalizers = []string{DefaultFinalizer}
+			cert.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			r := newFakeReconciler(cert)
+			r.Finalizer = "fork.example.org/cleanup"
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ConsistOf(DefaultFinalizer))
+		})
+	})
 })
+
+// newTestCertificate returns a minimal Certificate in the default namespace
+func newTestCertificate(name string) *certv1alpha1.Certificate {
+	return &certv1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: certv1alpha1.CertificateSpec{
+			CommonName: name + ".example.com",
+			SecretName: name + "-tls",
+		},
+	}
+}
+
+// newFakeReconciler returns a reconciler backed by a fake client seeded with objs
+func newFakeReconciler(objs ...client.Object) *CertificateReconciler {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&certv1alpha1.Certificate{}).
+		Build()
+
+	return &CertificateReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme.Scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+}
+
+// newCachedReconciler is newFakeReconciler with the manager's RBAC from config/rbac/role.yaml
+// applied to its reads. Reads through Client are served by informers, which need list and watch
+// and block forever without them, so they fail instead; reads through APIReader need the verb
+func newCachedReconciler(objs ...client.Object) *CertificateReconciler {
+	r := newFakeReconciler(objs...)
+	verbs := managerRBAC()
+	allowed := func(obj runtime.Object, required ...string) error {
+		// Unstructured objects bypass the cache unless the manager opts in
+		if _, ok := obj.(runtime.Unstructured); ok && len(required) > 1 {
+			return nil
+		}
+		gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+		if err != nil {
+			return err
+		}
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		resource, _ := meta.UnsafeGuessKindToResource(gvk)
+		for _, verb := range required {
+			if !slices.Contains(verbs[resource.GroupResource()], verb) {
+				return errors.NewForbidden(resource.GroupResource(), "", fmt.Errorf("manager RBAC doesn't grant %s", verb))
+			}
+		}
+		return nil
+	}
+
+	fakeClient := r.Client.(client.WithWatch)
+	r.APIReader = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := allowed(obj, "get"); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := allowed(list, "list"); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	r.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := allowed(obj, "list", "watch"); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := allowed(list, "list", "watch"); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	return r
+}
+
+// managerRBAC returns the verbs config/rbac/role.yaml grants the manager on each resource
+func managerRBAC() map[schema.GroupResource][]string {
+	data, err := os.ReadFile("../../config/rbac/role.yaml")
+	ExpectWithOffset(2, err).NotTo(HaveOccurred())
+	role := &rbacv1.ClusterRole{}
+	ExpectWithOffset(2, yaml.Unmarshal(data, role)).To(Succeed())
+
+	verbs := map[schema.GroupResource][]string{}
+	for _, rule := range role.Rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				key := schema.GroupResource{Group: group, Resource: resource}
+				verbs[key] = append(verbs[key], rule.Verbs...)
+			}
+		}
+	}
+	return verbs
+}
+
+// newConsumerDeployment returns a Deployment in the default namespace whose pods mount secretName
+func newConsumerDeployment(name, secretName string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name:         "tls",
+						VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// newOwnedSecret returns an empty secret for cert that cert controls, as an earlier issuance leaves it
+func newOwnedSecret(cert *certv1alpha1.Certificate) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: cert.Spec.SecretName, Namespace: cert.Namespace}}
+	ExpectWithOffset(1, controllerutil.SetControllerReference(cert, secret, scheme.Scheme)).To(Succeed())
+	return secret
+}
+
+// parseCertificatePEM decodes the first certificate in a PEM bundle
+func parseCertificatePEM(certPEM []byte) *x509.Certificate {
+	block, _ := pem.Decode(certPEM)
+	ExpectWithOffset(1, block).NotTo(BeNil())
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return parsed
+}
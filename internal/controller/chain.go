@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// describeChain returns the issuer CN of the leaf and the number of certificates in a PEM chain
+func describeChain(certPEM []byte) (string, int32) {
+	var issuerCommonName string
+	var length int32
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if length == 0 {
+			if leaf, err := x509.ParseCertificate(block.Bytes); err == nil {
+				issuerCommonName = leaf.Issuer.CommonName
+			}
+		}
+		length++
+	}
+	return issuerCommonName, length
+}
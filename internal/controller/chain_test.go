@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Chain description", func() {
+	It("should report a self-signed certificate as its own issuer", func() {
+		cert := newTestCertificate("self-signed-chain")
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Status.ChainLength).To(Equal(int32(1)))
+		Expect(updated.Status.IssuerCommonName).To(Equal(cert.Spec.CommonName))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).NotTo(HaveKey("ca.crt"))
+	})
+
+	It("should count the certificates of a bundle and skip other blocks", func() {
+		leaf := newKeyPairSecret("describe-leaf", "Leaf", false, time.Hour)
+		root := newKeyPairSecret("describe-root", "Root", true, time.Hour)
+		bundle := append(append([]byte{}, leaf.Data[corev1.TLSCertKey]...), leaf.Data[corev1.TLSPrivateKeyKey]...)
+		bundle = append(bundle, root.Data[corev1.TLSCertKey]...)
+
+		issuerCommonName, length := describeChain(bundle)
+		Expect(issuerCommonName).To(Equal("Leaf"))
+		Expect(length).To(Equal(int32(2)))
+
+		issuerCommonName, length = describeChain(nil)
+		Expect(issuerCommonName).To(BeEmpty())
+		Expect(length).To(BeZero())
+	})
+})
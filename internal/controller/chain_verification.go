@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// verifyIssuedChain checks that the leaf at the start of certPEM chains to the roots in caPEM,
+// the bundle written to ca.crt, so a misconfigured CA can't produce a secret consumers reject.
+// Self-signed certificates in caPEM are the roots; the rest of both bundles are intermediates.
+// A bundle without a self-signed certificate, for a root kept offline, is trusted as a whole
+func verifyIssuedChain(certPEM, caPEM []byte, at time.Time) error {
+	var leaf *x509.Certificate
+	intermediates := x509.NewCertPool()
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("%w: failed to parse issued chain: %w", ErrChainVerification, err)
+		}
+		if leaf == nil {
+			leaf = parsed
+			continue
+		}
+		intermediates.AddCert(parsed)
+	}
+	if leaf == nil {
+		return fmt.Errorf("%w: no certificate was issued", ErrChainVerification)
+	}
+
+	roots := x509.NewCertPool()
+	var bundle []*x509.Certificate
+	for _, der := range certificatesDER(caPEM) {
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("%w: failed to parse CA bundle: %w", ErrChainVerification, err)
+		}
+		bundle = append(bundle, parsed)
+		if parsed.CheckSignatureFrom(parsed) == nil {
+			roots.AddCert(parsed)
+		} else {
+			intermediates.AddCert(parsed)
+		}
+	}
+	if len(bundle) == 0 {
+		return fmt.Errorf("%w: the CA bundle contains no certificates", ErrChainVerification)
+	}
+	if roots.Equal(x509.NewCertPool()) {
+		for _, ca := range bundle {
+			roots.AddCert(ca)
+		}
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrChainVerification, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Chain verification", func() {
+	issueFrom := func(ca *corev1.Secret) *issuedCertificate {
+		cert := newTestCertificate("chain-of-" + ca.Name)
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		issued, err := newFakeReconciler(cert, ca).generateCertificate(ctx, cert)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return issued
+	}
+
+	It("should accept a leaf issued by the CA in the bundle", func() {
+		ca := newKeyPairSecret("chain-ca", "Chain CA", true, 365*24*time.Hour)
+		issued := issueFrom(ca)
+
+		Expect(verifyIssuedChain(issued.certPEM, issued.caPEM, time.Now())).To(Succeed())
+	})
+
+	It("should reject a leaf verified against a different CA", func() {
+		ca := newKeyPairSecret("chain-signing-ca", "Chain CA", true, 365*24*time.Hour)
+		other := newKeyPairSecret("chain-other-ca", "Chain CA", true, 365*24*time.Hour)
+		issued := issueFrom(ca)
+
+		err := verifyIssuedChain(issued.certPEM, other.Data[corev1.TLSCertKey], time.Now())
+		Expect(err).To(MatchError(ErrChainVerification))
+		Expect(err.Error()).To(ContainSubstring("unknown authority"))
+	})
+
+	It("should reject an empty CA bundle", func() {
+		issued := issueFrom(newKeyPairSecret("chain-empty-ca", "Chain CA", true, 365*24*time.Hour))
+
+		Expect(verifyIssuedChain(issued.certPEM, nil, time.Now())).To(MatchError(ErrChainVerification))
+	})
+})
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"maps"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// reconciledAnnotation reports whether the controller acts on the Certificate annotation key, so
+// changing it alone must trigger a reconcile
+func reconciledAnnotation(key string) bool {
+	return key == renewIfBeforeAnnotation || key == pinCAFingerprintAnnotation ||
+		strings.HasPrefix(key, feedbackAnnotationPrefix)
+}
+
+// reconciledAnnotations returns the annotations of cert the controller acts on
+func reconciledAnnotations(cert *certv1alpha1.Certificate) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range cert.Annotations {
+		if reconciledAnnotation(key) {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// changePredicate drops Certificate updates that only churn metadata the controller doesn't read,
+// such as labels and annotations other controllers maintain. Spec, status, deletion and the
+// annotations in reconciledAnnotation still trigger a reconcile, as does a label change that
+// moves the certificate in or out of the CertificateSelector
+func (r *CertificateReconciler) changePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCert, ok := e.ObjectOld.(*certv1alpha1.Certificate)
+			if !ok {
+				return true
+			}
+			newCert, ok := e.ObjectNew.(*certv1alpha1.Certificate)
+			if !ok {
+				return true
+			}
+			return r.certificateChanged(oldCert, newCert)
+		},
+	}
+}
+
+// certificateChanged reports whether the update from oldCert to newCert can change what reconcile does
+func (r *CertificateReconciler) certificateChanged(oldCert, newCert *certv1alpha1.Certificate) bool {
+	// Status carries the Approved condition an approver sets, so it counts too
+	return oldCert.Generation != newCert.Generation ||
+		!equality.Semantic.DeepEqual(oldCert.Status, newCert.Status) ||
+		!equality.Semantic.DeepEqual(oldCert.DeletionTimestamp, newCert.DeletionTimestamp) ||
+		!equality.Semantic.DeepEqual(oldCert.Finalizers, newCert.Finalizers) ||
+		!maps.Equal(reconciledAnnotations(oldCert), reconciledAnnotations(newCert)) ||
+		r.selects(oldCert) != r.selects(newCert)
+}
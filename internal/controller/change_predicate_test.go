@@ -0,0 +1,80 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Certificate change predicate", func() {
+	var oldCert *certv1alpha1.Certificate
+
+	BeforeEach(func() {
+		oldCert = newTestCertificate("churn")
+		oldCert.Generation = 1
+		oldCert.Labels = map[string]string{"tier": "prod"}
+		oldCert.Annotations = map[string]string{"argocd.argoproj.io/tracking-id": "app:cert"}
+	})
+
+	updated := func(r *CertificateReconciler, mutate func(cert *certv1alpha1.Certificate)) bool {
+		newCert := oldCert.DeepCopy()
+		mutate(newCert)
+		return r.changePredicate().Update(event.UpdateEvent{ObjectOld: oldCert, ObjectNew: newCert})
+	}
+
+	It("should process the rotate trigger", func() {
+		Expect(updated(newFakeReconciler(), func(cert *certv1alpha1.Certificate) {
+			cert.Annotations[renewIfBeforeAnnotation] = "720h"
+		})).To(BeTrue())
+	})
+
+	It("should process consumer feedback", func() {
+		Expect(updated(newFakeReconciler(), func(cert *certv1alpha1.Certificate) {
+			cert.Annotations[feedbackAnnotationPrefix+"envoy"] = "unknown CA"
+		})).To(BeTrue())
+	})
+
+	It("should ignore labels and annotations other controllers maintain", func() {
+		r := newFakeReconciler()
+		Expect(updated(r, func(cert *certv1alpha1.Certificate) {
+			cert.Labels["team"] = "payments"
+			cert.Annotations["argocd.argoproj.io/tracking-id"] = "app:cert-v2"
+			cert.ResourceVersion = "42"
+			cert.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+		})).To(BeFalse())
+	})
+
+	It("should process spec and status changes", func() {
+		r := newFakeReconciler()
+		Expect(updated(r, func(cert *certv1alpha1.Certificate) { cert.Generation = 2 })).To(BeTrue())
+		Expect(updated(r, func(cert *certv1alpha1.Certificate) {
+			meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+				Type: typeApprovedCert, Status: metav1.ConditionTrue, Reason: "Approved",
+			})
+		})).To(BeTrue())
+	})
+
+	It("should process deletion", func() {
+		Expect(updated(newFakeReconciler(), func(cert *certv1alpha1.Certificate) {
+			cert.DeletionTimestamp = &metav1.Time{}
+		})).To(BeTrue())
+	})
+
+	It("should process label changes that move a certificate across the selector", func() {
+		r := newFakeReconciler()
+		r.CertificateSelector = labels.SelectorFromSet(labels.Set{"tier": "prod"})
+		Expect(updated(r, func(cert *certv1alpha1.Certificate) { cert.Labels["tier"] = "staging" })).To(BeTrue())
+		Expect(updated(r, func(cert *certv1alpha1.Certificate) { cert.Labels["team"] = "payments" })).To(BeFalse())
+	})
+
+	It("should pass creates and deletes", func() {
+		r := newFakeReconciler()
+		Expect(r.changePredicate().Create(event.CreateEvent{Object: oldCert})).To(BeTrue())
+		Expect(r.changePredicate().Delete(event.DeleteEvent{Object: oldCert})).To(BeTrue())
+	})
+})
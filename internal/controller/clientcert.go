@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// serverExtKeyUsage returns the default extended key usages of the certificate written to
+// SecretName. CA certificates get none, and it is restricted to server auth when a separate
+// client certificate is issued
+func serverExtKeyUsage(cert *certv1alpha1.Certificate) []x509.ExtKeyUsage {
+	if cert.Spec.IsCA {
+		return nil
+	}
+	if cert.Spec.ClientCertSecretName != "" {
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+}
+
+// validateClientCertSpec rejects combinations that can't produce a separate client certificate
+func validateClientCertSpec(cert *certv1alpha1.Certificate) error {
+	if cert.Spec.ClientCertSecretName == "" {
+		return nil
+	}
+	if cert.Spec.ClientCertSecretName == cert.Spec.SecretName {
+		return fmt.Errorf("%w: clientCertSecretName must differ from secretName", ErrInvalidSpec)
+	}
+	if cert.Spec.IsCA {
+		return fmt.Errorf("%w: clientCertSecretName is not supported for CA certificates", ErrInvalidSpec)
+	}
+	// Two certificates from the same issuer must not share a serial number
+	if source := cert.Spec.SerialNumberSource; source != nil && source.Type == serialSourceProvided {
+		return fmt.Errorf("%w: clientCertSecretName can't be used with a provided serial number", ErrInvalidSpec)
+	}
+	return nil
+}
+
+// issueClientCertificate issues the client-auth certificate and writes it to ClientCertSecretName,
+// and to the kubeconfig when KubeconfigSecretName is set
+func (r *CertificateReconciler) issueClientCertificate(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	issued, err := r.generateCertificateWithUsage(ctx, cert, 0, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth})
+	if err != nil {
+		return err
+	}
+	if err := r.writeSecret(ctx, cert, cert.Spec.ClientCertSecretName, issued); err != nil {
+		return err
+	}
+	if cert.Spec.KubeconfigSecretName != "" {
+		if err := r.writeKubeconfigSecret(ctx, cert, issued); err != nil {
+			return err
+		}
+	}
+	countIssuance(cert)
+	r.auditIssuance(ctx, cert, cert.Spec.ClientCertSecretName, issued)
+	return nil
+}
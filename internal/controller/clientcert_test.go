@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"crypto/x509"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Client certificates", func() {
+	It("should issue server and client certificates into separate secrets", func() {
+		cert := newTestCertificate("mtls")
+		cert.Spec.ClientCertSecretName = "mtls-client-tls"
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		server := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "mtls-tls", Namespace: "default"}, server)).To(Succeed())
+		serverCert := parseCertificatePEM(server.Data["tls.crt"])
+		Expect(serverCert.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}))
+
+		clientSecret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "mtls-client-tls", Namespace: "default"}, clientSecret)).To(Succeed())
+		clientCert := parseCertificatePEM(clientSecret.Data["tls.crt"])
+		Expect(clientCert.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}))
+		Expect(clientCert.Subject.CommonName).To(Equal(serverCert.Subject.CommonName))
+		Expect(clientCert.SerialNumber).NotTo(Equal(serverCert.SerialNumber))
+		Expect(clientSecret.Labels).To(HaveKeyWithValue("cert.example.com/certificate", "mtls"))
+	})
+
+	It("should keep both usages on a single certificate by default", func() {
+		issued, err := newFakeReconciler().generateCertificate(ctx, newTestCertificate("single"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCertificatePEM(issued.certPEM).ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth))
+	})
+
+	It("should reject a client certificate for a CA", func() {
+		cert := newTestCertificate("mtls-ca")
+		cert.Spec.IsCA = true
+		cert.Spec.ClientCertSecretName = "mtls-ca-client-tls"
+		_, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should reject a provided serial number", func() {
+		cert := newTestCertificate("mtls-serial")
+		cert.Spec.ClientCertSecretName = "mtls-serial-client-tls"
+		cert.Spec.SerialNumberSource = &certv1alpha1.SerialNumberSource{Type: serialSourceProvided, Value: "01"}
+		_, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// consumerSerialAnnotation records on a deployment's pod template, or on the deployment itself
+// when its pods were deleted rather than rolled, the serial of the certificate its pods were last
+// restarted for. A consuming deployment without it has never been restarted by the operator,
+// e.g. because it started referencing the secret during a rotation. It is distinct from the
+// secret's serialAnnotation, which records the serial stored rather than the one restarted for
+const consumerSerialAnnotation = "cert.example.com/restarted-for-serial"
+
+// syncNewConsumers rolls deployments that started using the certificate's secret since the last
+// restart, so pods created during a rotation don't keep a stale certificate. Deployments that
+// were already restarted are left to restartDeployments
+func (r *CertificateReconciler) syncNewConsumers(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if !cert.Spec.RestartDeployments || cert.Status.SerialNumber == "" {
+		return nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(consumerNamespace(cert))); err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	for i := range deployments.Items {
+		deploy := &deployments.Items[i]
+		if !r.isNewConsumer(deploy, cert) {
+			continue
+		}
+		log.FromContext(ctx).Info("Restarting new consumer of the secret", "deployment", deploy.Name)
+		pending, err := r.restartDeployment(ctx, cert, deploy)
+		if err != nil {
+			return fmt.Errorf("failed to restart deployment %s: %w", deploy.Name, err)
+		}
+		// Evictions continue with the rest of the pending restart
+		if pending && !restartPending(cert) {
+			if err := r.deferRestart(ctx, cert, "EvictionInProgress",
+				fmt.Sprintf("Evicting the pods of deployment(s) %s", deploy.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isNewConsumer reports whether deploy uses cert's secret, has never been restarted for it and
+// started before the current certificate was issued
+func (r *CertificateReconciler) isNewConsumer(deploy *appsv1.Deployment, cert *certv1alpha1.Certificate) bool {
+	if _, ok := deploy.Spec.Template.Annotations[consumerSerialAnnotation]; ok {
+		return false
+	}
+	// Deployments restarted by deleting their pods carry the serial on the deployment itself
+	if _, ok := deploy.Annotations[consumerSerialAnnotation]; ok {
+		return false
+	}
+	// Pods started since the issuance already load the current certificate. This also spares
+	// consumers rolled before the serial annotation existed a second rollout after an upgrade
+	if cert.Status.NotBefore != nil && !consumerStartTime(deploy).Before(cert.Status.NotBefore.Truncate(time.Second)) {
+		return false
+	}
+	return r.deploymentUsesSecret(deploy, consumerSecretName(cert))
+}
+
+// consumerStartTime returns when deploy's pods last started: when it was created, or when the
+// operator last rolled it if that is later. Both have second precision
+func consumerStartTime(deploy *appsv1.Deployment) time.Time {
+	started := deploy.CreationTimestamp.Time
+	if restartedAt, err := time.Parse(time.RFC3339, deploy.Spec.Template.Annotations[restartedAtAnnotation]); err == nil &&
+		restartedAt.After(started) {
+		started = restartedAt
+	}
+	return started
+}
+
+// certificatesForDeployment maps a Deployment to the Certificates it newly consumes. Certificates
+// in other namespaces are included, since SecretNamespace can write their secret to deploy's
+func (r *CertificateReconciler) certificatesForDeployment(ctx context.Context, obj client.Object) []reconcile.Request {
+	deploy, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cert := range certificates.Items {
+		if cert.Spec.RestartDeployments && consumerNamespace(&cert) == deploy.Namespace && r.isNewConsumer(deploy, &cert) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cert)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("New consumers", func() {
+	It("should restart a deployment that started before issuance and references the secret", func() {
+		cert := newTestCertificate("late-consumer")
+		cert.Spec.RestartDeployments = true
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+
+		late := newConsumerDeployment("late", cert.Spec.SecretName)
+		late.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(r.Create(ctx, late)).To(Succeed())
+		Expect(r.Create(ctx, newConsumerDeployment("unrelated", "other-tls"))).To(Succeed())
+
+		// The deployment watch maps the new consumer back to the certificate
+		Expect(r.certificatesForDeployment(ctx, late)).To(ConsistOf(req))
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(late), late)).To(Succeed())
+		Expect(late.Spec.Template.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, updated.Status.SerialNumber))
+		unrelated := &appsv1.Deployment{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "unrelated", Namespace: "default"}, unrelated)).To(Succeed())
+		Expect(unrelated.Spec.Template.Annotations).NotTo(HaveKey(consumerSerialAnnotation))
+
+		// Once restarted, further changes to the deployment don't requeue the certificate
+		Expect(r.certificatesForDeployment(ctx, late)).To(BeEmpty())
+	})
+
+	It("should keep evicting the pods of a new consumer on later reconciles", func() {
+		cert := newTestCertificate("late-evicted-consumer")
+		cert.Spec.RestartDeployments = true
+		cert.Spec.RestartStrategy = restartStrategyDeletePods
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		late := newConsumerDeployment("late-evicted", cert.Spec.SecretName)
+		late.Spec.Replicas = ptr.To(int32(2))
+		late.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(r.Create(ctx, late)).To(Succeed())
+		// createPod adds a ready pod of the deployment, created after the restart began when replacing
+		createPod := func(name string, replacing bool) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: late.Spec.Template.Labels},
+				Spec:       late.Spec.Template.Spec,
+				Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				}},
+			}
+			if replacing {
+				pod.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Minute))
+			}
+			ExpectWithOffset(1, r.Create(ctx, pod)).To(Succeed())
+		}
+		podGone := func(name string) bool {
+			return errors.IsNotFound(r.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &corev1.Pod{}))
+		}
+		createPod("late-evicted-1", false)
+		createPod("late-evicted-2", false)
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(restartRetryDelay))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(restartPending(updated)).To(BeTrue())
+		Expect(podGone("late-evicted-1")).To(BeTrue())
+		Expect(podGone("late-evicted-2")).To(BeFalse())
+
+		createPod("late-evicted-3", true)
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podGone("late-evicted-2")).To(BeTrue())
+
+		createPod("late-evicted-4", true)
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(restartPending(updated)).To(BeFalse())
+		Expect(podGone("late-evicted-3")).To(BeFalse())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(late), late)).To(Succeed())
+		Expect(late.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, updated.Status.SerialNumber))
+	})
+
+	It("should restart consumers in the secret's namespace", func() {
+		cert := newTestCertificate("cross-ns-consumer")
+		cert.Spec.RestartDeployments = true
+		cert.Spec.SecretNamespace = "app"
+		r := newFakeReconciler(cert)
+		r.AllowedSecretNamespaces = []string{"app"}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+
+		local := newConsumerDeployment("local", cert.Spec.SecretName)
+		Expect(r.Create(ctx, local)).To(Succeed())
+		remote := newConsumerDeployment("remote", cert.Spec.SecretName)
+		remote.Namespace = "app"
+		remote.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(r.Create(ctx, remote)).To(Succeed())
+
+		Expect(r.certificatesForDeployment(ctx, remote)).To(ConsistOf(req))
+		Expect(r.certificatesForDeployment(ctx, local)).To(BeEmpty())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(remote), remote)).To(Succeed())
+		Expect(remote.Spec.Template.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, updated.Status.SerialNumber))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(local), local)).To(Succeed())
+		Expect(local.Spec.Template.Annotations).NotTo(HaveKey(consumerSerialAnnotation))
+	})
+
+	It("should leave consumers whose pods started after issuance", func() {
+		cert := newTestCertificate("started-consumer")
+		cert.Spec.RestartDeployments = true
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		issued := updated.Status.NotBefore.Time
+
+		created := newConsumerDeployment("created", cert.Spec.SecretName)
+		created.CreationTimestamp = metav1.NewTime(issued.Add(time.Minute))
+		Expect(r.Create(ctx, created)).To(Succeed())
+		// A deployment that predates issuance but was rolled by an earlier operator version since,
+		// before it recorded the serial
+		rolled := newConsumerDeployment("rolled", cert.Spec.SecretName)
+		rolled.CreationTimestamp = metav1.NewTime(issued.Add(-time.Hour))
+		rolled.Spec.Template.Annotations = map[string]string{restartedAtAnnotation: issued.Format(time.RFC3339)}
+		Expect(r.Create(ctx, rolled)).To(Succeed())
+
+		Expect(r.certificatesForDeployment(ctx, created)).To(BeEmpty())
+		Expect(r.certificatesForDeployment(ctx, rolled)).To(BeEmpty())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		for _, deploy := range []*appsv1.Deployment{created, rolled} {
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+			Expect(deploy.Spec.Template.Annotations).NotTo(HaveKey(consumerSerialAnnotation))
+		}
+	})
+
+	It("should not map deployments for certificates that don't restart consumers", func() {
+		cert := newTestCertificate("no-restart-consumer")
+		deploy := newConsumerDeployment("api", cert.Spec.SecretName)
+		r := newFakeReconciler(cert, deploy)
+
+		Expect(r.certificatesForDeployment(ctx, deploy)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// certificateValidator evaluates the CEL rules of the Certificate CRD in config/crd/bases the
+// way the API server does on create and update
+func certificateValidator() (*cel.Validator, *schema.Structural) {
+	data, err := os.ReadFile("../../config/crd/bases/cert.example.com_certificates.yaml")
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	ExpectWithOffset(1, yaml.Unmarshal(data, crd)).To(Succeed())
+
+	props := &apiextensions.JSONSchemaProps{}
+	ExpectWithOffset(1, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+		crd.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil)).To(Succeed())
+	structural, err := schema.NewStructural(props)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return cel.NewValidator(structural, true, celconfig.PerCallLimit), structural
+}
+
+var _ = Describe("Certificate CRD validation", func() {
+	validateUpdate := func(oldCert, newCert *certv1alpha1.Certificate) field.ErrorList {
+		validator, structural := certificateValidator()
+		oldObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(oldCert)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		newObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newCert)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		errs, _ := validator.Validate(ctx, field.NewPath("root"), structural, newObj, oldObj, celconfig.RuntimeCELCostBudget)
+		return errs
+	}
+
+	It("should allow updates that keep the key and CA settings", func() {
+		cert := newTestCertificate("immutable-fields")
+		cert.Spec.KeyAlgorithm = "ECDSA"
+		cert.Spec.KeySize = 384
+		cert.Spec.IsCA = true
+		updated := cert.DeepCopy()
+		updated.Spec.DNSNames = []string{"renamed.example.com"}
+		updated.Spec.Duration = "2160h"
+
+		Expect(validateUpdate(cert, updated)).To(BeEmpty())
+	})
+
+	It("should reject changing the key algorithm, key size or isCA", func() {
+		cert := newTestCertificate("immutable-fields")
+		cert.Spec.KeyAlgorithm = "RSA"
+		cert.Spec.KeySize = 4096
+		cert.Spec.IsCA = true
+
+		for message, mutate := range map[string]func(*certv1alpha1.Certificate){
+			"keyAlgorithm is immutable": func(c *certv1alpha1.Certificate) { c.Spec.KeyAlgorithm = "ECDSA" },
+			"keySize is immutable":      func(c *certv1alpha1.Certificate) { c.Spec.KeySize = 2048 },
+			"isCA is immutable":         func(c *certv1alpha1.Certificate) { c.Spec.IsCA = false },
+		} {
+			updated := cert.DeepCopy()
+			mutate(updated)
+			Expect(validateUpdate(cert, updated).ToAggregate()).To(MatchError(ContainSubstring(message)))
+		}
+
+		By("rejecting a setting added after creation")
+		unset := newTestCertificate("immutable-unset")
+		updated := unset.DeepCopy()
+		updated.Spec.KeySize = 2048
+		Expect(validateUpdate(unset, updated).ToAggregate()).To(MatchError(ContainSubstring("keySize is immutable")))
+		updated = unset.DeepCopy()
+		updated.Spec.KeyAlgorithm = "ECDSA"
+		Expect(validateUpdate(unset, updated).ToAggregate()).To(MatchError(ContainSubstring("keyAlgorithm is immutable")))
+	})
+})
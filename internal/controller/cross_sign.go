@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// crossSignCA signs the rotated self-signed CA, issued from template, by the CA it replaces in
+// the certificate's secret. The cross-signed certificate lets trust stores that
+// still hold only the previous CA validate leaves of the new one until the previous CA expires.
+// It returns nil when there is no unexpired previous CA with a different key to cross-sign with
+func (r *CertificateReconciler) crossSignCA(ctx context.Context, cert *certv1alpha1.Certificate, template, rotated *x509.Certificate) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey(cert), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: failed to get previous CA: %w", ErrSigning, err)
+	}
+	previous, err := parseCAKeyPair("secret "+secret.Name, secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil)
+	if err != nil {
+		// Without a usable previous keypair there is nothing to cross-sign with
+		log.FromContext(ctx).Info("Not cross-signing rotated CA", "reason", err.Error())
+		return nil, nil
+	}
+	// A CA keeping its key is validated by trust stores holding the previous certificate as is
+	if bytes.Equal(previous.cert.RawSubjectPublicKeyInfo, rotated.RawSubjectPublicKeyInfo) || !time.Now().Before(previous.cert.NotAfter) {
+		return nil, nil
+	}
+
+	serialNumber, err := retryEntropy(ErrSerialNumber, func() (*big.Int, error) {
+		serialNumber, err := rand.Int(r.random(), new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSerialNumber, err)
+		}
+		return serialNumber, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cross := *template
+	cross.SerialNumber = serialNumber
+	// The cross-signed certificate can't outlive the CA vouching for it
+	if cross.NotAfter.After(previous.cert.NotAfter) {
+		cross.NotAfter = previous.cert.NotAfter
+	}
+	// Leaves name the rotated CA's key identifier, which both certificates must carry
+	cross.SubjectKeyId = rotated.SubjectKeyId
+	der, err := x509.CreateCertificate(r.random(), &cross, previous.cert, rotated.PublicKey, previous.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to cross-sign rotated CA: %w", ErrSigning, err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("CA rotation cross-signing", func() {
+	It("should let leaves validate against both the previous and the rotated CA", func() {
+		root := newTestCertificate("rotating-root")
+		root.Spec.IsCA = true
+		r := newFakeReconciler(root)
+		rootReq := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(root)}
+		rootSecretKey := client.ObjectKey{Name: root.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, rootReq)
+		Expect(err).NotTo(HaveOccurred())
+		first := &corev1.Secret{}
+		Expect(r.Get(ctx, rootSecretKey, first)).To(Succeed())
+		// The first CA has nothing to be cross-signed by
+		Expect(first.Data).NotTo(HaveKey("ca.crt"))
+		previous := parseCertificatePEM(first.Data[corev1.TLSCertKey])
+
+		// Rotate the CA
+		Expect(r.Get(ctx, rootReq.NamespacedName, root)).To(Succeed())
+		root.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, root)).To(Succeed())
+		_, err = r.Reconcile(ctx, rootReq)
+		Expect(err).NotTo(HaveOccurred())
+		rotatedSecret := &corev1.Secret{}
+		Expect(r.Get(ctx, rootSecretKey, rotatedSecret)).To(Succeed())
+		rotated := parseCertificatePEM(rotatedSecret.Data[corev1.TLSCertKey])
+		Expect(rotated.RawSubjectPublicKeyInfo).NotTo(Equal(previous.RawSubjectPublicKeyInfo))
+
+		// ca.crt is the rotated CA followed by its cross-signed twin, which expires with the previous CA
+		bundle := certificatesDER(rotatedSecret.Data["ca.crt"])
+		Expect(bundle).To(HaveLen(2))
+		Expect(bundle[0]).To(Equal(rotated.Raw))
+		cross, err := x509.ParseCertificate(bundle[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cross.Subject.String()).To(Equal(rotated.Subject.String()))
+		Expect(cross.RawSubjectPublicKeyInfo).To(Equal(rotated.RawSubjectPublicKeyInfo))
+		Expect(cross.SubjectKeyId).To(Equal(rotated.SubjectKeyId))
+		Expect(cross.CheckSignatureFrom(previous)).To(Succeed())
+		Expect(cross.NotAfter).To(BeTemporally("<=", previous.NotAfter))
+
+		leaf := newTestCertificate("rotating-root-leaf")
+		leaf.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: root.Spec.SecretName, Kind: issuerKindCA}
+		Expect(r.Create(ctx, leaf)).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(leaf)})
+		Expect(err).NotTo(HaveOccurred())
+		leafSecret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: leaf.Spec.SecretName, Namespace: "default"}, leafSecret)).To(Succeed())
+
+		intermediates := x509.NewCertPool()
+		Expect(intermediates.AppendCertsFromPEM(leafSecret.Data[corev1.TLSCertKey])).To(BeTrue())
+		Expect(intermediates.AppendCertsFromPEM(leafSecret.Data["ca.crt"])).To(BeTrue())
+		issuedLeaf := parseCertificatePEM(leafSecret.Data[corev1.TLSCertKey])
+		for _, anchor := range []*x509.Certificate{previous, rotated} {
+			roots := x509.NewCertPool()
+			roots.AddCert(anchor)
+			_, err := issuedLeaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+			Expect(err).NotTo(HaveOccurred(), "verifying against %s", anchor.Subject)
+		}
+	})
+
+	It("should not cross-sign when the previous CA has expired", func() {
+		expired := newKeyPairSecret("expired-root-tls", "expired-root.example.com", true, -time.Minute)
+		root := newTestCertificate("expired-root")
+		root.Spec.IsCA = true
+		issued, err := newFakeReconciler(root, expired).generateCertificate(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued.caPEM).To(BeEmpty())
+	})
+})
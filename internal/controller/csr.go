@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// defaultCSRKey is the secret key read when CSRSecretRef doesn't name one
+const defaultCSRKey = "tls.csr"
+
+// loadCSR reads and verifies the certificate signing request referenced by the certificate
+func (r *CertificateReconciler) loadCSR(ctx context.Context, cert *certv1alpha1.Certificate) (*x509.CertificateRequest, error) {
+	ref := cert.Spec.CSRSecretRef
+	if cert.Spec.IssuerRef.Kind != issuerKindCA {
+		return nil, fmt.Errorf("%w: csrSecretRef requires a CA issuer", ErrInvalidSpec)
+	}
+	if cert.Spec.IsCA || cert.Spec.ClientCertSecretName != "" {
+		return nil, fmt.Errorf("%w: csrSecretRef can't be combined with isCA or clientCertSecretName", ErrInvalidSpec)
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: cert.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCSRLoad, err)
+	}
+	key := ref.Key
+	if key == "" {
+		key = defaultCSRKey
+	}
+
+	block, _ := pem.Decode(secret.Data[key])
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("%w: secret %s has no PEM certificate request in %s", ErrInvalidSpec, ref.Name, key)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid certificate request: %w", ErrInvalidSpec, err)
+	}
+	// The signature proves the requester holds the private key
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: certificate request signature: %w", ErrInvalidSpec, err)
+	}
+	return csr, nil
+}
+
+// mergeCSRNames adds the CSR's SANs to the template's, skipping duplicates
+func mergeCSRNames(template *x509.Certificate, csr *x509.CertificateRequest) {
+	seen := map[string]bool{}
+	for _, name := range template.DNSNames {
+		seen[name] = true
+	}
+	for _, name := range csr.DNSNames {
+		if !seen[name] {
+			seen[name] = true
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+
+	for _, ip := range csr.IPAddresses {
+		if !containsIP(template.IPAddresses, ip) {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		}
+	}
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, existing := range ips {
+		if existing.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
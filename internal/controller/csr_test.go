@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// newCSRSecret returns a secret holding a CSR for the given SANs, and the requester's key
+func newCSRSecret(name string, dnsNames []string, ips []net.IP) (*corev1.Secret, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "requester"},
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+	}, key)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       map[string][]byte{defaultCSRKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})},
+	}, key
+}
+
+var _ = Describe("CSR signing", func() {
+	newCSRCertificate := func(name string) *certv1alpha1.Certificate {
+		cert := newTestCertificate(name)
+		cert.Spec.DNSNames = []string{"spec.example.com"}
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "csr-ca", Kind: issuerKindCA}
+		cert.Spec.CSRSecretRef = &certv1alpha1.CSRSecretRef{Name: name + "-csr"}
+		return cert
+	}
+
+	It("should sign the provided CSR and carry its SANs through", func() {
+		cert := newCSRCertificate("csr-signed")
+		csrSecret, key := newCSRSecret("csr-signed-csr", []string{"csr.example.com", "spec.example.com"}, []net.IP{net.ParseIP("10.0.0.7")})
+		r := newFakeReconciler(cert, csrSecret, newKeyPairSecret("csr-ca", "CSR CA", true, 365*24*time.Hour))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "csr-signed-tls", Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Type).To(Equal(corev1.SecretTypeOpaque))
+		Expect(secret.Data).NotTo(HaveKey(corev1.TLSPrivateKeyKey))
+
+		parsed := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		Expect(parsed.PublicKey).To(Equal(&key.PublicKey))
+		Expect(parsed.DNSNames).To(Equal([]string{"csr.example.com", "spec.example.com"}))
+		Expect(parsed.IPAddresses).To(HaveLen(1))
+		Expect(parsed.IPAddresses[0].Equal(net.ParseIP("10.0.0.7"))).To(BeTrue())
+		Expect(parsed.Issuer.CommonName).To(Equal("CSR CA"))
+	})
+
+	It("should require a CA issuer", func() {
+		cert := newCSRCertificate("csr-self-signed")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{}
+		csrSecret, _ := newCSRSecret("csr-self-signed-csr", nil, nil)
+
+		_, err := newFakeReconciler(csrSecret).generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should reject a CSR with an invalid signature", func() {
+		cert := newCSRCertificate("csr-tampered")
+		csrSecret, _ := newCSRSecret("csr-tampered-csr", []string{"csr.example.com"}, nil)
+		block, _ := pem.Decode(csrSecret.Data[defaultCSRKey])
+		block.Bytes[len(block.Bytes)-1] ^= 0xff
+		csrSecret.Data[defaultCSRKey] = pem.EncodeToMemory(block)
+
+		_, err := newFakeReconciler(csrSecret, newKeyPairSecret("csr-ca", "CSR CA", true, 365*24*time.Hour)).generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should report a missing CSR secret as retryable", func() {
+		_, err := newFakeReconciler().generateCertificate(ctx, newCSRCertificate("csr-missing"))
+		Expect(err).To(MatchError(ErrCSRLoad))
+		_, retryable := issuanceFailure(err)
+		Expect(retryable).To(BeTrue())
+	})
+
+	Context("When restarting only on key changes", func() {
+		const restartedAt = "cert.example.com/restartedAt"
+
+		// reissue changes the duration so the certificate is re-issued, and reports whether the consumer restarted
+		reissue := func(r *CertificateReconciler, cert *certv1alpha1.Certificate, deploy *appsv1.Deployment) bool {
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+			delete(deploy.Spec.Template.Annotations, restartedAt)
+			ExpectWithOffset(1, r.Update(ctx, deploy)).To(Succeed())
+
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			previousSerial := updated.Status.SerialNumber
+			updated.Spec.Duration = "1000h"
+			ExpectWithOffset(1, r.Update(ctx, updated)).To(Succeed())
+
+			_, err := r.Reconcile(ctx, req)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			ExpectWithOffset(1, r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			ExpectWithOffset(1, updated.Status.SerialNumber).NotTo(Equal(previousSerial))
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+			_, restarted := deploy.Spec.Template.Annotations[restartedAt]
+			return restarted
+		}
+
+		It("should not restart consumers when the re-issued certificate keeps its key", func() {
+			cert := newCSRCertificate("csr-reload")
+			cert.Spec.RestartDeployments = true
+			cert.Spec.RestartOnlyOnKeyChange = true
+			csrSecret, _ := newCSRSecret("csr-reload-csr", nil, nil)
+			deploy := newConsumerDeployment(cert.Spec.SecretName+"-consumer", cert.Spec.SecretName)
+			r := newFakeReconciler(cert, csrSecret, deploy, newKeyPairSecret("csr-ca", "CSR CA", true, 365*24*time.Hour))
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reissue(r, cert, deploy)).To(BeFalse())
+		})
+
+		It("should restart consumers when a new key is generated", func() {
+			cert := newTestCertificate("rekey-restart")
+			cert.Spec.RestartDeployments = true
+			cert.Spec.RestartOnlyOnKeyChange = true
+			deploy := newConsumerDeployment(cert.Spec.SecretName+"-consumer", cert.Spec.SecretName)
+			r := newFakeReconciler(cert, deploy)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reissue(r, cert, deploy)).To(BeTrue())
+		})
+	})
+})
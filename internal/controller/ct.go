@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typeCTSubmittedCert reports the outcome of submitting the latest certificate to CT logs
+const typeCTSubmittedCert = "CTSubmitted"
+
+// CTSubmitter submits certificates to certificate transparency logs. Embedding the returned
+// SCTs needs a precertificate flow; the submitter only receives the final chain
+type CTSubmitter interface {
+	// Submit sends the DER chain, leaf first, and returns the serialized SCTs the logs issued
+	Submit(ctx context.Context, chain [][]byte) ([][]byte, error)
+}
+
+// submitToCTLogs submits the issued chain and records the outcome in the CTSubmitted condition
+func (r *CertificateReconciler) submitToCTLogs(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) {
+	condition := metav1.Condition{
+		Type:               typeCTSubmittedCert,
+		Status:             metav1.ConditionFalse,
+		Reason:             "CTSubmissionFailed",
+		LastTransitionTime: metav1.Now(),
+	}
+
+	scts, err := r.submitChain(ctx, issued.certPEM)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to submit certificate to CT logs")
+		condition.Message = err.Error()
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "SCTsReceived"
+		condition.Message = fmt.Sprintf("Received %d SCTs for serial %s", len(scts), issued.serialNumber)
+	}
+	meta.SetStatusCondition(&cert.Status.Conditions, condition)
+}
+
+func (r *CertificateReconciler) submitChain(ctx context.Context, certPEM []byte) ([][]byte, error) {
+	if r.CTSubmitter == nil {
+		return nil, fmt.Errorf("no CT submitter is configured")
+	}
+
+	var chain [][]byte
+	for rest := certPEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		chain = append(chain, block.Bytes)
+	}
+
+	scts, err := r.CTSubmitter.Submit(ctx, chain)
+	if err != nil {
+		return nil, fmt.Errorf("CT submission failed: %w", err)
+	}
+	if len(scts) == 0 {
+		return nil, fmt.Errorf("CT logs returned no SCTs")
+	}
+	return scts, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// fakeCTSubmitter records submitted chains and returns a fixed response
+type fakeCTSubmitter struct {
+	chains [][][]byte
+	scts   [][]byte
+	err    error
+}
+
+func (s *fakeCTSubmitter) Submit(_ context.Context, chain [][]byte) ([][]byte, error) {
+	s.chains = append(s.chains, chain)
+	return s.scts, s.err
+}
+
+var _ = Describe("Certificate transparency", func() {
+	reconcileWithSubmitter := func(name string, submitter CTSubmitter, mutate func(*certv1alpha1.Certificate)) *certv1alpha1.Certificate {
+		cert := newTestCertificate(name)
+		cert.Spec.SubmitToCTLogs = true
+		if mutate != nil {
+			mutate(cert)
+		}
+		objs := []client.Object{cert}
+		if cert.Spec.IssuerRef.Kind == issuerKindCA {
+			objs = append(objs, newKeyPairSecret(cert.Spec.IssuerRef.Name, "CT CA", true, 365*24*time.Hour))
+		}
+		r := newFakeReconciler(objs...)
+		if submitter != nil {
+			r.CTSubmitter = submitter
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return updated
+	}
+
+	It("should submit the issued chain and report the SCTs", func() {
+		submitter := &fakeCTSubmitter{scts: [][]byte{[]byte("sct-1"), []byte("sct-2")}}
+		updated := reconcileWithSubmitter("ct", submitter, func(cert *certv1alpha1.Certificate) {
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "ct-ca", Kind: issuerKindCA}
+		})
+
+		Expect(submitter.chains).To(HaveLen(1))
+		Expect(submitter.chains[0]).To(HaveLen(2))
+		leaf, err := x509.ParseCertificate(submitter.chains[0][0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fmt.Sprintf("%x", leaf.SerialNumber)).To(Equal(updated.Status.SerialNumber))
+
+		condition := meta.FindStatusCondition(updated.Status.Conditions, typeCTSubmittedCert)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("2 SCTs"))
+	})
+
+	It("should report a failed submission without failing issuance", func() {
+		updated := reconcileWithSubmitter("ct-failed", &fakeCTSubmitter{err: fmt.Errorf("log unavailable")}, nil)
+
+		condition := meta.FindStatusCondition(updated.Status.Conditions, typeCTSubmittedCert)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("log unavailable"))
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+	})
+
+	It("should report a missing submitter", func() {
+		updated := reconcileWithSubmitter("ct-unset", nil, nil)
+		Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, typeCTSubmittedCert)).To(BeTrue())
+	})
+
+	It("should not submit unless requested", func() {
+		submitter := &fakeCTSubmitter{scts: [][]byte{[]byte("sct")}}
+		updated := reconcileWithSubmitter("ct-off", submitter, func(cert *certv1alpha1.Certificate) {
+			cert.Spec.SubmitToCTLogs = false
+		})
+		Expect(submitter.chains).To(BeEmpty())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeCTSubmittedCert)).To(BeNil())
+	})
+})
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// dependentSecretData is what DependentSecret templates are rendered with
+type dependentSecretData struct {
+	Name         string
+	Namespace    string
+	Certificate  string
+	PrivateKey   string
+	CA           string
+	SerialNumber string
+	NotAfter     string
+}
+
+var dependentSecretFuncs = template.FuncMap{
+	"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+}
+
+// writeDependentSecrets re-renders each dependent secret's templates from the issued certificate
+func (r *CertificateReconciler) writeDependentSecrets(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) error {
+	data := dependentSecretData{
+		Name:         cert.Name,
+		Namespace:    cert.Namespace,
+		Certificate:  string(issued.certPEM),
+		PrivateKey:   string(issued.keyPEM),
+		CA:           string(issued.caPEM),
+		SerialNumber: issued.serialNumber,
+		NotAfter:     issued.notAfter.UTC().Format(time.RFC3339),
+	}
+
+	for _, dependent := range cert.Spec.DependentSecrets {
+		if dependent.Name == cert.Spec.SecretName || dependent.Name == cert.Spec.PublicSecretName ||
+			dependent.Name == cert.Spec.KubeconfigSecretName {
+			return fmt.Errorf("%w: dependent secret %s is already written by the certificate", ErrInvalidSpec, dependent.Name)
+		}
+
+		rendered, err := renderDependentSecret(dependent, data)
+		if err != nil {
+			return err
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: dependent.Name, Namespace: cert.Namespace},
+		}
+		err = r.createOrUpdateManagedSecret(ctx, cert, secret, func() error {
+			if secret.Labels == nil {
+				secret.Labels = map[string]string{}
+			}
+			secret.Labels["app.kubernetes.io/managed-by"] = "certificate-operator"
+			secret.Labels["cert.example.com/certificate"] = cert.Name
+			// Only the templated keys are owned; anything else in the secret is left alone
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			for key, value := range rendered {
+				secret.Data[key] = value
+			}
+			return ctrl.SetControllerReference(cert, secret, r.Scheme)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write dependent secret %s: %w", dependent.Name, err)
+		}
+	}
+	return nil
+}
+
+// renderDependentSecret executes each of the dependent secret's templates
+func renderDependentSecret(dependent certv1alpha1.DependentSecret, data dependentSecretData) (map[string][]byte, error) {
+	rendered := make(map[string][]byte, len(dependent.Data))
+	for key, text := range dependent.Data {
+		tmpl, err := template.New(key).Funcs(dependentSecretFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid template for %s/%s: %w", ErrInvalidSpec, dependent.Name, key, err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, fmt.Errorf("%w: failed to render %s/%s: %w", ErrInvalidSpec, dependent.Name, key, err)
+		}
+		rendered[key] = out.Bytes()
+	}
+	return rendered, nil
+}
+
+// dependentSecretFailure returns the Ready reason for a failed write of a secret derived from
+// the certificate, such as the public, kubeconfig or dependent secrets
+func dependentSecretFailure(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidSpec):
+		return "InvalidSpec"
+	case errors.Is(err, ErrSecretNotManaged):
+		return "SecretNotManaged"
+	default:
+		return "SecretUpdateFailed"
+	}
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"encoding/base64"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Dependent secrets", func() {
+	const kubeconfigTemplate = `users:
+- name: {{ .Name }}
+  user:
+    client-certificate-data: {{ b64enc .Certificate }}
+    client-key-data: {{ b64enc .PrivateKey }}
+`
+
+	It("should re-render the dependent secret with the new certificate on renewal", func() {
+		cert := newTestCertificate("dependent")
+		cert.Spec.DependentSecrets = []certv1alpha1.DependentSecret{{
+			Name: "dependent-kubeconfig",
+			Data: map[string]string{"kubeconfig": kubeconfigTemplate, "serial": "{{ .SerialNumber }}"},
+		}}
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		expectRendered := func() (*certv1alpha1.Certificate, *corev1.Secret) {
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			tlsSecret := &corev1.Secret{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, tlsSecret)).To(Succeed())
+			dependent := &corev1.Secret{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: "dependent-kubeconfig", Namespace: "default"}, dependent)).To(Succeed())
+
+			ExpectWithOffset(1, string(dependent.Data["serial"])).To(Equal(updated.Status.SerialNumber))
+			kubeconfig := string(dependent.Data["kubeconfig"])
+			ExpectWithOffset(1, kubeconfig).To(ContainSubstring("- name: dependent\n"))
+			ExpectWithOffset(1, kubeconfig).To(ContainSubstring("client-certificate-data: " + base64.StdEncoding.EncodeToString(tlsSecret.Data[corev1.TLSCertKey])))
+			ExpectWithOffset(1, kubeconfig).To(ContainSubstring("client-key-data: " + base64.StdEncoding.EncodeToString(tlsSecret.Data[corev1.TLSPrivateKeyKey])))
+			return updated, dependent
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		issued, dependent := expectRendered()
+		dependent.Data["unrelated"] = []byte("kept")
+		Expect(r.Update(ctx, dependent)).To(Succeed())
+
+		By("renewing the certificate")
+		issued.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, issued)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		renewed, dependent := expectRendered()
+		Expect(renewed.Status.SerialNumber).NotTo(Equal(issued.Status.SerialNumber))
+		// Only the templated keys are owned
+		Expect(dependent.Data).To(HaveKeyWithValue("unrelated", []byte("kept")))
+	})
+
+	It("should render a dependent secret added after issuance", func() {
+		cert := newTestCertificate("dependent-later")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Spec.DependentSecrets = []certv1alpha1.DependentSecret{{
+			Name: "dependent-later-serial",
+			Data: map[string]string{"serial": "{{ .SerialNumber }}"},
+		}}
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		dependent := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "dependent-later-serial", Namespace: "default"}, dependent)).To(Succeed())
+		Expect(string(dependent.Data["serial"])).To(Equal(updated.Status.SerialNumber))
+	})
+
+	It("should not take over a secret it doesn't own", func() {
+		cert := newTestCertificate("dependent-taken")
+		cert.Spec.DependentSecrets = []certv1alpha1.DependentSecret{{
+			Name: "dependent-taken-config",
+			Data: map[string]string{"serial": "{{ .SerialNumber }}"},
+		}}
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dependent-taken-config", Namespace: "default"},
+			Data:       map[string][]byte{"serial": []byte("theirs")},
+		}
+		r := newFakeReconciler(cert, existing)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(MatchError(ErrSecretNotManaged))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretNotManaged"))
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(existing.Data))
+		Expect(secret.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should report an invalid template", func() {
+		cert := newTestCertificate("dependent-invalid")
+		cert.Spec.DependentSecrets = []certv1alpha1.DependentSecret{{
+			Name: "dependent-invalid-config",
+			Data: map[string]string{"config": "{{ .Missing }}"},
+		}}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(MatchError(ErrInvalidSpec))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("InvalidSpec"))
+	})
+
+	It("should reject overwriting the certificate's own secret", func() {
+		cert := newTestCertificate("dependent-self")
+		cert.Spec.DependentSecrets = []certv1alpha1.DependentSecret{{Name: cert.Spec.SecretName}}
+		err := newFakeReconciler().writeDependentSecrets(ctx, cert, &issuedCertificate{})
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// entropyBackoff bounds the retries of key and serial generation when the random source fails.
+// Failures are rare and usually transient, e.g. in constrained environments short on entropy
+var entropyBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 10 * time.Millisecond,
+	Factor:   4.0,
+	Jitter:   0.1,
+}
+
+// random returns the reconciler's source of randomness, falling back to crypto/rand
+func (r *CertificateReconciler) random() io.Reader {
+	if r.Rand != nil {
+		return r.Rand
+	}
+	return rand.Reader
+}
+
+// retryEntropy runs fn, retrying with entropyBackoff while it fails with sentinel. Other errors,
+// such as an invalid spec, are returned immediately
+func retryEntropy[T any](sentinel error, fn func() (T, error)) (T, error) {
+	var result T
+	attempts := 0
+	err := retry.OnError(entropyBackoff, func(err error) bool { return errors.Is(err, sentinel) }, func() error {
+		attempts++
+		var err error
+		result, err = fn()
+		return err
+	})
+	if err != nil && attempts > 1 {
+		return result, fmt.Errorf("%w (after %d attempts)", err, attempts)
+	}
+	return result, err
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// flakyReader fails the first failures reads, then reads from crypto/rand
+type flakyReader struct {
+	failures int
+	reads    int
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	f.reads++
+	if f.reads <= f.failures {
+		return 0, errors.New("entropy unavailable")
+	}
+	return rand.Read(p)
+}
+
+var _ = Describe("Entropy retries", func() {
+	It("should retry key generation when the random source fails transiently", func() {
+		cert := newTestCertificate("entropy-key")
+		cert.Spec.KeyAlgorithm = keyAlgorithmEd25519
+		random := &flakyReader{failures: 2}
+		r := newFakeReconciler()
+		r.Rand = random
+
+		issued, err := r.generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCertificatePEM(issued.certPEM).PublicKeyAlgorithm).To(Equal(x509.Ed25519))
+		Expect(random.reads).To(BeNumerically(">", 2))
+	})
+
+	It("should retry random serial numbers when the random source fails transiently", func() {
+		cert := newTestCertificate("entropy-serial")
+		r := newFakeReconciler()
+		r.Rand = &flakyReader{failures: 1}
+
+		serialNumber, err := r.serialNumber(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serialNumber.Sign()).To(Equal(1))
+	})
+
+	It("should not retry an invalid spec", func() {
+		cert := newTestCertificate("entropy-invalid")
+		cert.Spec.KeyAlgorithm = "DSA"
+		random := &flakyReader{}
+		r := newFakeReconciler()
+		r.Rand = random
+
+		_, err := r.generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+		Expect(random.reads).To(BeZero())
+	})
+
+	It("should report a persistent failure in the Ready condition", func() {
+		cert := newTestCertificate("entropy-exhausted")
+		cert.Spec.KeyAlgorithm = keyAlgorithmEd25519
+		r := newFakeReconciler(cert)
+		r.Rand = &flakyReader{failures: entropyBackoff.Steps}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(MatchError(ErrKeyGeneration))
+		Expect(err.Error()).To(ContainSubstring("after 4 attempts"))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("KeyGenerationFailed"))
+	})
+})
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// transientAPIErrorRequeue is how soon to retry after a transient API server error
+const transientAPIErrorRequeue = 2 * time.Second
+
+// Sentinel errors returned by the issuance path. They are wrapped with the
+// underlying cause so callers can use errors.Is to tell failures apart.
+var (
+	// ErrInvalidSpec means the Certificate spec can't be turned into a certificate
+	ErrInvalidSpec = errors.New("invalid certificate spec")
+
+	// ErrKeyGeneration means the private key could not be generated
+	ErrKeyGeneration = errors.New("failed to generate private key")
+
+	// ErrSerialNumber means the serial number could not be generated
+	ErrSerialNumber = errors.New("failed to generate serial number")
+
+	// ErrCALoad means the CA keypair of a CA issuer could not be loaded
+	ErrCALoad = errors.New("failed to load CA")
+
+	// ErrExternalKey means the key of an ExternalKey issuer could not be resolved
+	ErrExternalKey = errors.New("failed to load external key")
+
+	// ErrCSRLoad means the referenced certificate signing request could not be read
+	ErrCSRLoad = errors.New("failed to load certificate request")
+
+	// ErrSigning means the certificate could not be signed
+	ErrSigning = errors.New("failed to create certificate")
+
+	// ErrChainVerification means a CA-issued certificate doesn't chain to the CA bundle written with it
+	ErrChainVerification = errors.New("issued certificate failed chain verification")
+
+	// ErrIssuerLoad means the Issuer referenced by IssuerRef could not be read
+	ErrIssuerLoad = errors.New("failed to load issuer")
+
+	// ErrUnsupportedIssuer means the referenced Issuer configures a backend the controller can't issue from
+	ErrUnsupportedIssuer = errors.New("unsupported issuer")
+
+	// ErrInvalidUsageForCA means the requested usages contradict whether the certificate is a CA
+	ErrInvalidUsageForCA = errors.New("key usages are invalid for the certificate's CA setting")
+
+	// ErrPolicyViolation means the controller's policy forbids issuing the Certificate as specified
+	ErrPolicyViolation = errors.New("certificate violates controller policy")
+
+	// ErrSecretNotManaged means a secret the certificate writes to already exists and
+	// belongs to someone else
+	ErrSecretNotManaged = errors.New("secret is not managed by the certificate")
+)
+
+// issuanceFailure maps an issuance error to the Ready condition reason and
+// whether retrying without a spec change can succeed
+func issuanceFailure(err error) (reason string, retryable bool) {
+	switch {
+	case errors.Is(err, ErrInvalidUsageForCA):
+		return "InvalidUsageForCA", false
+	case errors.Is(err, ErrInvalidIPAddress):
+		return "InvalidIPAddress", false
+	case errors.Is(err, ErrInvalidSpec):
+		return "InvalidSpec", false
+	case errors.Is(err, ErrPolicyViolation):
+		return "PolicyViolation", false
+	case errors.Is(err, ErrUnsupportedIssuer):
+		return "UnsupportedIssuer", false
+	case errors.Is(err, context.DeadlineExceeded):
+		return "IssuanceTimedOut", true
+	case errors.Is(err, ErrKeyGeneration):
+		return "KeyGenerationFailed", true
+	case errors.Is(err, ErrSerialNumber):
+		return "SerialNumberFailed", true
+	case errors.Is(err, ErrCALoad):
+		return "CALoadFailed", true
+	case errors.Is(err, ErrIssuerLoad):
+		return "IssuerLoadFailed", true
+	case errors.Is(err, ErrExternalKey):
+		return "ExternalKeyFailed", true
+	case errors.Is(err, ErrCSRLoad):
+		return "CSRLoadFailed", true
+	case errors.Is(err, ErrSigning):
+		return "SigningFailed", true
+	case errors.Is(err, ErrChainVerification):
+		return "ChainVerificationFailed", true
+	case errors.Is(err, ErrSecretNotManaged):
+		// The secret's owner isn't watched, so freeing the name is picked up by a retry
+		return "SecretNotManaged", true
+	default:
+		return "GenerationFailed", true
+	}
+}
+
+// isTransientAPIError reports whether err is an API server failure that a prompt retry can
+// get past, such as a timeout or the server being briefly unavailable
+func isTransientAPIError(err error) bool {
+	return apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err)
+}
+
+// transientAPIErrorDelay returns the delay the API server asked for, or transientAPIErrorRequeue
+func transientAPIErrorDelay(err error) time.Duration {
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return transientAPIErrorRequeue
+}
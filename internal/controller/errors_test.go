@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Issuance errors", func() {
+	DescribeTable("mapping errors to condition reasons",
+		func(err error, expectedReason string, expectedRetryable bool) {
+			reason, retryable := issuanceFailure(err)
+			Expect(reason).To(Equal(expectedReason))
+			Expect(retryable).To(Equal(expectedRetryable))
+		},
+		Entry("invalid spec", fmt.Errorf("%w: invalid duration", ErrInvalidSpec), "InvalidSpec", false),
+		Entry("invalid usage for CA", fmt.Errorf("%w: cert sign requires isCA", ErrInvalidUsageForCA), "InvalidUsageForCA", false),
+		Entry("invalid IP address", fmt.Errorf("%w: \"10.0.0\"", ErrInvalidIPAddress), "InvalidIPAddress", false),
+		Entry("policy violation", fmt.Errorf("%w: wildcard", ErrPolicyViolation), "PolicyViolation", false),
+		Entry("unsupported issuer", fmt.Errorf("%w: ACME", ErrUnsupportedIssuer), "UnsupportedIssuer", false),
+		Entry("key generation", fmt.Errorf("%w: entropy exhausted", ErrKeyGeneration), "KeyGenerationFailed", true),
+		Entry("serial number", fmt.Errorf("%w: entropy exhausted", ErrSerialNumber), "SerialNumberFailed", true),
+		Entry("CA load", fmt.Errorf("%w: secret not found", ErrCALoad), "CALoadFailed", true),
+		Entry("issuer load", fmt.Errorf("%w: not found", ErrIssuerLoad), "IssuerLoadFailed", true),
+		Entry("signing", fmt.Errorf("%w: bad template", ErrSigning), "SigningFailed", true),
+		Entry("chain verification", fmt.Errorf("%w: unknown authority", ErrChainVerification), "ChainVerificationFailed", true),
+		Entry("timeout", fmt.Errorf("%w: %w", ErrCALoad, context.DeadlineExceeded), "IssuanceTimedOut", true),
+		Entry("unclassified", fmt.Errorf("boom"), "GenerationFailed", true),
+	)
+
+	secrets := schema.GroupResource{Resource: "secrets"}
+
+	DescribeTable("classifying API errors",
+		func(err error, transient bool) {
+			Expect(isTransientAPIError(err)).To(Equal(transient))
+		},
+		Entry("timeout", apierrors.NewTimeoutError("slow etcd", 0), true),
+		Entry("server timeout", apierrors.NewServerTimeout(secrets, "create", 0), true),
+		Entry("service unavailable", apierrors.NewServiceUnavailable("restarting"), true),
+		Entry("too many requests", apierrors.NewTooManyRequests("throttled", 3), true),
+		Entry("internal error", apierrors.NewInternalError(fmt.Errorf("boom")), true),
+		Entry("wrapped", fmt.Errorf("writing secret: %w", apierrors.NewServiceUnavailable("restarting")), true),
+		Entry("forbidden", apierrors.NewForbidden(secrets, "tls", fmt.Errorf("denied")), false),
+		Entry("invalid", apierrors.NewBadRequest("bad"), false),
+		Entry("not an API error", fmt.Errorf("boom"), false),
+	)
+
+	It("should honour the delay suggested by the API server", func() {
+		Expect(transientAPIErrorDelay(apierrors.NewTooManyRequests("throttled", 7))).To(Equal(7 * time.Second))
+		Expect(transientAPIErrorDelay(apierrors.NewServiceUnavailable("restarting"))).To(Equal(transientAPIErrorRequeue))
+	})
+
+	Context("When writing the secret fails", func() {
+		reconcileWithCreateError := func(name string, createErr error) (reconcile.Result, *certv1alpha1.Certificate, error) {
+			cert := newTestCertificate(name)
+			r := newFakeReconciler(cert)
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if _, ok := obj.(*corev1.Secret); ok {
+						return createErr
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			return result, updated, err
+		}
+
+		It("should requeue shortly on a retryable API error", func() {
+			result, updated, err := reconcileWithCreateError("transient-api", apierrors.NewServiceUnavailable("restarting"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(transientAPIErrorRequeue))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretUpdateFailed"))
+		})
+
+		It("should surface a non-retryable API error", func() {
+			result, updated, err := reconcileWithCreateError("denied-api", apierrors.NewForbidden(secrets, "denied-api-tls", fmt.Errorf("denied")))
+			Expect(err).To(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretUpdateFailed"))
+		})
+	})
+})
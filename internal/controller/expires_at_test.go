@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Fixed expiry", func() {
+	expiresAt := metav1.NewTime(time.Now().Add(400 * 24 * time.Hour).Truncate(time.Second))
+
+	It("should issue the certificate to expire exactly at ExpiresAt", func() {
+		cert := newTestCertificate("expires-at")
+		cert.Spec.ExpiresAt = &expiresAt
+		cert.Spec.Duration = "24h"
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Status.NotAfter.Equal(&expiresAt)).To(BeTrue())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data["tls.crt"]).NotAfter.Equal(expiresAt.Time)).To(BeTrue())
+	})
+
+	It("should reject an ExpiresAt in the past", func() {
+		cert := newTestCertificate("expires-at-past")
+		past := metav1.NewTime(time.Now().Add(-time.Hour))
+		cert.Spec.ExpiresAt = &past
+
+		_, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should not renew a certificate that already expires at ExpiresAt", func() {
+		cert := newTestCertificate("expires-at-renew")
+		cert.Spec.ExpiresAt = &expiresAt
+		renewal := metav1.NewTime(time.Now().Add(-time.Minute))
+		cert.Status.RenewalTime = &renewal
+		cert.Status.NotAfter = expiresAt.DeepCopy()
+		cert.Status.SecretName = cert.Spec.SecretName
+		cert.Status.SpecHash = issuanceSpecHash(cert)
+
+		r := newFakeReconciler()
+		Expect(r.needsRenewal(cert)).To(BeFalse())
+
+		// Moving the expiry re-issues
+		later := metav1.NewTime(expiresAt.Add(24 * time.Hour))
+		cert.Spec.ExpiresAt = &later
+		Expect(r.needsRenewal(cert)).To(BeTrue())
+	})
+})
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// extraExtensions decodes the certificate's raw extensions, rejecting malformed OIDs and values
+// and OIDs listed more than once
+func extraExtensions(cert *certv1alpha1.Certificate) ([]pkix.Extension, error) {
+	var extensions []pkix.Extension
+	seen := map[string]bool{}
+	for _, raw := range cert.Spec.ExtraExtensions {
+		oid, err := parseOID(raw.OID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: extraExtensions: %w", ErrInvalidSpec, err)
+		}
+		if seen[oid.String()] {
+			return nil, fmt.Errorf("%w: extraExtensions: OID %s is listed more than once", ErrInvalidSpec, oid)
+		}
+		seen[oid.String()] = true
+
+		value, err := base64.StdEncoding.DecodeString(raw.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: extraExtensions: value of %s is not valid base64: %w", ErrInvalidSpec, oid, err)
+		}
+		extensions = append(extensions, pkix.Extension{Id: oid, Critical: raw.Critical, Value: value})
+	}
+	return extensions, nil
+}
+
+// parseOID parses a dotted object identifier such as 1.3.6.1.4.1.99999.1
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q: needs at least two components", s)
+	}
+	oid := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			return nil, fmt.Errorf("invalid OID %q: component %q is not a number", s, part)
+		}
+		oid[i] = n
+	}
+	// The first arc is 0, 1 or 2, and the second is below 40 under the first two
+	if oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	return oid, nil
+}
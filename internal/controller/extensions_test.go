@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/asn1"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Extra extensions", func() {
+	It("should add raw extensions to the certificate", func() {
+		value, err := asn1.Marshal("enterprise-device")
+		Expect(err).NotTo(HaveOccurred())
+		cert := newTestCertificate("extra-extensions")
+		cert.Spec.ExtraExtensions = []certv1alpha1.RawExtension{
+			{OID: "1.3.6.1.4.1.99999.1", Value: base64.StdEncoding.EncodeToString(value)},
+			{OID: "1.3.6.1.4.1.99999.2", Critical: true, Value: base64.StdEncoding.EncodeToString([]byte{0x05, 0x00})},
+		}
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		parsed := parseCertificatePEM(issued.certPEM)
+
+		found := map[string]bool{}
+		for _, ext := range parsed.Extensions {
+			switch ext.Id.String() {
+			case "1.3.6.1.4.1.99999.1":
+				Expect(ext.Critical).To(BeFalse())
+				Expect(ext.Value).To(Equal(value))
+				found[ext.Id.String()] = true
+			case "1.3.6.1.4.1.99999.2":
+				Expect(ext.Critical).To(BeTrue())
+				Expect(ext.Value).To(Equal([]byte{0x05, 0x00}))
+				found[ext.Id.String()] = true
+			}
+		}
+		Expect(found).To(HaveLen(2))
+	})
+
+	It("should re-issue when the extensions change", func() {
+		cert := newTestCertificate("extension-hash")
+		before := issuanceSpecHash(cert)
+		cert.Spec.ExtraExtensions = []certv1alpha1.RawExtension{{OID: "1.2.3.4", Value: "BQA="}}
+		Expect(issuanceSpecHash(cert)).NotTo(Equal(before))
+	})
+
+	DescribeTable("should reject malformed extensions",
+		func(extensions []certv1alpha1.RawExtension) {
+			cert := newTestCertificate("bad-extension")
+			cert.Spec.ExtraExtensions = extensions
+			_, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		},
+		Entry("single-arc OID", []certv1alpha1.RawExtension{{OID: "1", Value: "BQA="}}),
+		Entry("non-numeric OID", []certv1alpha1.RawExtension{{OID: "1.3.six.1", Value: "BQA="}}),
+		Entry("first arc above 2", []certv1alpha1.RawExtension{{OID: "3.1.2", Value: "BQA="}}),
+		Entry("second arc too large", []certv1alpha1.RawExtension{{OID: "1.40.1", Value: "BQA="}}),
+		Entry("invalid base64", []certv1alpha1.RawExtension{{OID: "1.2.3.4", Value: "not base64!"}}),
+		Entry("duplicate OID", []certv1alpha1.RawExtension{{OID: "1.2.3.4", Value: "BQA="}, {OID: "1.2.3.4", Value: "BQA="}}),
+	)
+})
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// issuerKindExternalKey signs certificates with a key that never leaves an external key manager
+const issuerKindExternalKey = "ExternalKey"
+
+// KeyManager resolves keys held outside the cluster, such as in a KMS. The returned signer
+// performs signing remotely, so the operator never holds raw key bytes
+type KeyManager interface {
+	Signer(ctx context.Context, keyRef string) (crypto.Signer, error)
+}
+
+// externalSigner resolves the signer for an ExternalKey issuer
+func (r *CertificateReconciler) externalSigner(ctx context.Context, cert *certv1alpha1.Certificate) (crypto.Signer, error) {
+	if r.KeyManager == nil {
+		return nil, fmt.Errorf("%w: no key manager is configured for issuer %s", ErrExternalKey, cert.Spec.IssuerRef.Name)
+	}
+	if cert.Spec.IsCA || cert.Spec.KeyAlgorithm != "" || cert.Spec.KeySize != 0 {
+		return nil, fmt.Errorf("%w: isCA, keyAlgorithm and keySize can't be used with an ExternalKey issuer", ErrInvalidSpec)
+	}
+
+	signer, err := r.KeyManager.Signer(ctx, cert.Spec.IssuerRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExternalKey, err)
+	}
+	return signer, nil
+}
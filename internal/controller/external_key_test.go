@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// recordingSigner stands in for a KMS key, counting the signatures it produces
+type recordingSigner struct {
+	key   *ecdsa.PrivateKey
+	calls atomic.Int32
+}
+
+func (s *recordingSigner) Public() crypto.PublicKey { return s.key.Public() }
+
+func (s *recordingSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.calls.Add(1)
+	return s.key.Sign(rand, digest, opts)
+}
+
+// fakeKeyManager serves signers by key reference
+type fakeKeyManager map[string]crypto.Signer
+
+func (m fakeKeyManager) Signer(_ context.Context, keyRef string) (crypto.Signer, error) {
+	if signer, ok := m[keyRef]; ok {
+		return signer, nil
+	}
+	return nil, fmt.Errorf("key %s not found", keyRef)
+}
+
+var _ = Describe("External key issuer", func() {
+	newExternalKeyCertificate := func(name string) *certv1alpha1.Certificate {
+		cert := newTestCertificate(name)
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "kms-key", Kind: issuerKindExternalKey}
+		return cert
+	}
+
+	It("should sign with the external key and re-sign without rotating it", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		signer := &recordingSigner{key: key}
+		cert := newExternalKeyCertificate("kms")
+		r := newFakeReconciler(cert)
+		r.KeyManager = fakeKeyManager{"kms-key": signer}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signer.calls.Load()).To(Equal(int32(1)))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Data).NotTo(HaveKey(corev1.TLSPrivateKeyKey))
+		first := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		Expect(first.PublicKey).To(Equal(&key.PublicKey))
+		Expect(first.CheckSignature(first.SignatureAlgorithm, first.RawTBSCertificate, first.Signature)).To(Succeed())
+
+		// Renewal only re-signs with the same key
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signer.calls.Load()).To(Equal(int32(2)))
+
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		second := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		Expect(second.SerialNumber).NotTo(Equal(first.SerialNumber))
+		Expect(second.PublicKey).To(Equal(&key.PublicKey))
+	})
+
+	It("should fail retryably without a key manager", func() {
+		_, err := newFakeReconciler().generateCertificate(ctx, newExternalKeyCertificate("kms-unset"))
+		Expect(err).To(MatchError(ErrExternalKey))
+		reason, retryable := issuanceFailure(err)
+		Expect(reason).To(Equal("ExternalKeyFailed"))
+		Expect(retryable).To(BeTrue())
+	})
+
+	It("should reject key settings it can't honour", func() {
+		cert := newExternalKeyCertificate("kms-keysize")
+		cert.Spec.KeySize = 4096
+		r := newFakeReconciler()
+		r.KeyManager = fakeKeyManager{}
+		_, err := r.generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
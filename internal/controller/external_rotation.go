@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// externalRotationAnnotation marks a secret an external system has rotated, e.g. with the time of
+// the rotation. While it is set the operator records the certificate the secret holds instead of
+// overwriting it; removing it hands renewal back to the operator
+const externalRotationAnnotation = "cert.example.com/externally-rotated"
+
+// externallyRotatedSecret returns the certificate's secret when an external system has taken
+// over its rotation, or nil
+func (r *CertificateReconciler) externallyRotatedSecret(ctx context.Context, cert *certv1alpha1.Certificate) *corev1.Secret {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey(cert), secret); err != nil {
+		return nil
+	}
+	if _, ok := secret.Annotations[externalRotationAnnotation]; !ok {
+		return nil
+	}
+	return secret
+}
+
+// syncExternallyRotatedCertificate updates status from the certificate an external system wrote
+// to secret, without issuing, and restarts consumers when it changed
+func (r *CertificateReconciler) syncExternallyRotatedCertificate(ctx context.Context, cert *certv1alpha1.Certificate, secret *corev1.Secret) error {
+	logger := log.FromContext(ctx)
+
+	leaf, err := parseStoredCertificate(secret)
+	if err != nil {
+		logger.Error(err, "Failed to read externally rotated certificate")
+		r.fail(ctx, cert, "ExternalRotationInvalid", fmt.Errorf("secret %s is marked externally rotated but unreadable: %w", secret.Name, err))
+		// The secret is watched, so the next rotation triggers a reconcile
+		return nil
+	}
+
+	// Consumers load whatever is in the secret, so it is only Ready if it would actually serve the spec
+	if err := verifyExternallyRotatedSecret(cert, secret, leaf); err != nil {
+		logger.Error(err, "Externally rotated certificate doesn't match the Certificate")
+		r.fail(ctx, cert, "ExternalRotationInvalid", fmt.Errorf("secret %s is marked externally rotated but unusable: %w", secret.Name, err))
+		return nil
+	}
+
+	certPEM := secret.Data[corev1.TLSCertKey]
+	fingerprint := certificateFingerprint(certPEM)
+	rotated := cert.Status.Fingerprint != fingerprint
+	if rotated {
+		logger.Info("Recording externally rotated certificate", "serialNumber", fmt.Sprintf("%x", leaf.SerialNumber))
+		r.Recorder.Eventf(cert, corev1.EventTypeNormal, "ExternallyRotated",
+			"Secret %s was rotated externally; recorded certificate expiring %s", secret.Name, leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	cert.Status.SecretName = cert.Spec.SecretName
+	cert.Status.SecretNamespace = cert.Spec.SecretNamespace
+	cert.Status.NotBefore = &metav1.Time{Time: leaf.NotBefore}
+	cert.Status.NotAfter = &metav1.Time{Time: leaf.NotAfter}
+	cert.Status.RenewalTime = r.calculateRenewalTime(cert, leaf.NotAfter)
+	cert.Status.SerialNumber = fmt.Sprintf("%x", leaf.SerialNumber)
+	cert.Status.Fingerprint = fingerprint
+	cert.Status.PublicKeyPin = publicKeyPin(leaf.RawSubjectPublicKeyInfo)
+	cert.Status.IssuerCommonName, cert.Status.ChainLength = describeChain(certPEM)
+
+	if time.Now().After(cert.Status.RenewalTime.Time) {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeReadyCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonCertificateExpiring,
+			Message:            fmt.Sprintf("Externally rotated certificate expires at %s and must be rotated again, or the annotation removed", leaf.NotAfter.Format(time.RFC3339)),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeReadyCert,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ExternallyRotated",
+			Message:            fmt.Sprintf("Certificate in secret %s is rotated externally", secret.Name),
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	if err := r.updateStatus(ctx, cert); err != nil {
+		logger.Error(err, "Failed to update Certificate status")
+		return err
+	}
+
+	if rotated && cert.Spec.RestartDeployments {
+		if err := r.restartDeployments(ctx, cert); err != nil {
+			logger.Error(err, "Failed to restart deployments")
+		}
+	}
+	return nil
+}
+
+// verifyExternallyRotatedSecret checks that the certificate an external system wrote to secret
+// matches its key, chains to the stored CA and has the spec's SANs. A certificate signed from a CSR
+// has no key in the secret and the requester's names, so it is taken as is
+func verifyExternallyRotatedSecret(cert *certv1alpha1.Certificate, secret *corev1.Secret, leaf *x509.Certificate) error {
+	if cert.Spec.CSRSecretRef == nil {
+		if err := verifySecretKeyPairWithCA(secret, caSecretKey(cert)); err != nil {
+			return err
+		}
+		if sansMismatch(cert, leaf) {
+			return fmt.Errorf("DNS names %v and IP addresses %v differ from the spec", leaf.DNSNames, leaf.IPAddresses)
+		}
+	}
+	return nil
+}
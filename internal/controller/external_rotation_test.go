@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("External rotation", func() {
+	It("should record an externally rotated certificate without re-issuing", func() {
+		cert := newTestCertificate("rotated-externally")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		// An external system replaces the certificate and marks the secret
+		external := newKeyPairSecret("external", "external.example.com", false, 45*24*time.Hour)
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		secret.Data = external.Data
+		secret.Annotations[externalRotationAnnotation] = time.Now().Format(time.RFC3339)
+		Expect(r.Update(ctx, secret)).To(Succeed())
+		leaf := parseCertificatePEM(external.Data[corev1.TLSCertKey])
+
+		// Forcing a renewal would otherwise overwrite the secret
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Spec.Duration = "2160h"
+		Expect(r.Update(ctx, updated)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(secret.Data[corev1.TLSCertKey]).To(Equal(external.Data[corev1.TLSCertKey]))
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.SerialNumber).To(Equal(leaf.SerialNumber.Text(16)))
+		Expect(updated.Status.NotAfter.Time).To(BeTemporally("~", leaf.NotAfter, time.Second))
+		Expect(updated.Status.Fingerprint).To(Equal(certificateFingerprint(external.Data[corev1.TLSCertKey])))
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Reason).To(Equal("ExternallyRotated"))
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("ExternallyRotated")))
+	})
+
+	It("should not be Ready with an externally rotated certificate that doesn't serve the spec", func() {
+		mismatchedKey := newKeyPairSecret("external-key", "external.example.com", false, 45*24*time.Hour)
+		mismatchedKey.Data[corev1.TLSPrivateKeyKey] = newKeyPairSecret("other-key", "other", false, time.Hour).Data[corev1.TLSPrivateKeyKey]
+		for _, tc := range []struct {
+			name     string
+			dnsNames []string
+			data     map[string][]byte
+		}{
+			{name: "rotated-wrong-key", data: mismatchedKey.Data},
+			{name: "rotated-wrong-sans", dnsNames: []string{"app.example.com"},
+				data: newKeyPairSecret("external-sans", "external.example.com", false, 45*24*time.Hour).Data},
+		} {
+			name := tc.name
+			cert := newTestCertificate(name)
+			cert.Spec.DNSNames = tc.dnsNames
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        cert.Spec.SecretName,
+					Namespace:   "default",
+					Annotations: map[string]string{externalRotationAnnotation: "true"},
+				},
+				Data: tc.data,
+			}
+			r := newFakeReconciler(cert, secret)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred(), name)
+
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse), name)
+			Expect(ready.Reason).To(Equal("ExternalRotationInvalid"), name)
+			Expect(updated.Status.Fingerprint).To(BeEmpty(), name)
+		}
+	})
+
+	It("should report an unreadable externally rotated secret", func() {
+		cert := newTestCertificate("rotated-garbage")
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        cert.Spec.SecretName,
+				Namespace:   "default",
+				Annotations: map[string]string{externalRotationAnnotation: "true"},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")},
+		}
+		r := newFakeReconciler(cert, secret)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("ExternalRotationInvalid"))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(string(secret.Data[corev1.TLSCertKey])).To(Equal("not a certificate"))
+	})
+})
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typeExternalSecretSyncedCert reports whether the certificate is mirrored to ExternalSecretPath
+const typeExternalSecretSyncedCert = "ExternalSecretSynced"
+
+// ExternalSecretStore mirrors TLS material to a secret manager outside the cluster, such as AWS
+// Secrets Manager, for consumers that don't read Kubernetes secrets
+type ExternalSecretStore interface {
+	// Put creates or replaces the secret at path with payload
+	Put(ctx context.Context, path string, payload []byte) error
+}
+
+// externalSecretPayload is the JSON document mirrored to the external store
+type externalSecretPayload struct {
+	Certificate  string `json:"tls.crt"`
+	PrivateKey   string `json:"tls.key,omitempty"`
+	CA           string `json:"ca.crt,omitempty"`
+	SerialNumber string `json:"serialNumber"`
+	NotAfter     string `json:"notAfter"`
+}
+
+// externalSecretName returns where ExternalSecretPath is mirrored to, under the Certificate's
+// namespace so Certificates in different namespaces can't overwrite each other's secrets
+func externalSecretName(cert *certv1alpha1.Certificate) string {
+	return cert.Namespace + "/" + cert.Spec.ExternalSecretPath
+}
+
+// syncExternalSecret mirrors the certificate's secret to ExternalSecretPath when the certificate
+// or the path changed since the last mirror, and records the outcome in status
+func (r *CertificateReconciler) syncExternalSecret(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if cert.Spec.ExternalSecretPath == "" || cert.Status.Fingerprint == "" {
+		return nil
+	}
+	path := externalSecretName(cert)
+	if cert.Status.ExternalSecretPath == path && cert.Status.ExternalSecretFingerprint == cert.Status.Fingerprint {
+		return nil
+	}
+
+	if r.ExternalSecretStore == nil {
+		// Retrying can't help until the controller is configured with a store
+		changed := meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeExternalSecretSyncedCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoExternalSecretStore",
+			Message:            "No external secret store is configured on the controller",
+			LastTransitionTime: metav1.Now(),
+		})
+		if !changed {
+			return nil
+		}
+		return r.updateStatus(ctx, cert)
+	}
+
+	condition := metav1.Condition{
+		Type:               typeExternalSecretSyncedCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Mirrored",
+		Message:            fmt.Sprintf("Certificate with serial %s is mirrored to %s", cert.Status.SerialNumber, path),
+		LastTransitionTime: metav1.Now(),
+	}
+	err := r.mirrorToExternalStore(ctx, cert, path)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ExternalSecretSyncFailed"
+		condition.Message = err.Error()
+	} else {
+		cert.Status.ExternalSecretPath = path
+		cert.Status.ExternalSecretFingerprint = cert.Status.Fingerprint
+	}
+	meta.SetStatusCondition(&cert.Status.Conditions, condition)
+	if updateErr := r.updateStatus(ctx, cert); updateErr != nil {
+		return updateErr
+	}
+	// Returned so the mirror is retried with backoff
+	return err
+}
+
+// mirrorToExternalStore writes the TLS material of the certificate's secret to path. The secret is
+// read from the API server and must hold the certificate in status, so a stale cache can't mirror
+// the previous certificate under the current fingerprint
+func (r *CertificateReconciler) mirrorToExternalStore(ctx context.Context, cert *certv1alpha1.Certificate, path string) error {
+	secret := &corev1.Secret{}
+	if err := r.apiReader().Get(ctx, secretKey(cert), secret); err != nil {
+		return fmt.Errorf("failed to read secret: %w", err)
+	}
+	if certificateFingerprint(secret.Data[corev1.TLSCertKey]) != cert.Status.Fingerprint {
+		return fmt.Errorf("secret %s doesn't hold the certificate with serial %s yet", secret.Name, cert.Status.SerialNumber)
+	}
+
+	payload := externalSecretPayload{
+		Certificate:  string(secret.Data[corev1.TLSCertKey]),
+		PrivateKey:   string(secret.Data[corev1.TLSPrivateKeyKey]),
+		CA:           string(secret.Data[caSecretKey(cert)]),
+		SerialNumber: cert.Status.SerialNumber,
+	}
+	if cert.Status.NotAfter != nil {
+		payload.NotAfter = cert.Status.NotAfter.UTC().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode external secret: %w", err)
+	}
+	return r.ExternalSecretStore.Put(ctx, path, data)
+}
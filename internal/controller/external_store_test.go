@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// fakeSecretStore records the payloads put to each path, failing while err is set
+type fakeSecretStore struct {
+	puts map[string][][]byte
+	err  error
+}
+
+func (s *fakeSecretStore) Put(_ context.Context, path string, payload []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.puts == nil {
+		s.puts = map[string][][]byte{}
+	}
+	s.puts[path] = append(s.puts[path], payload)
+	return nil
+}
+
+var _ = Describe("External secret store", func() {
+	reconcileAndGet := func(r *CertificateReconciler, cert *certv1alpha1.Certificate) (*certv1alpha1.Certificate, error) {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return updated, err
+	}
+
+	It("should mirror the TLS material once per certificate", func() {
+		cert := newTestCertificate("mirrored")
+		cert.Spec.ExternalSecretPath = "prod/mirrored/tls"
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "mirror-ca", Kind: issuerKindCA}
+		store := &fakeSecretStore{}
+		r := newFakeReconciler(cert, newKeyPairSecret("mirror-ca", "Mirror CA", true, 365*24*time.Hour))
+		r.ExternalSecretStore = store
+
+		updated, err := reconcileAndGet(r, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.puts["default/prod/mirrored/tls"]).To(HaveLen(1))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		payload := map[string]string{}
+		Expect(json.Unmarshal(store.puts["default/prod/mirrored/tls"][0], &payload)).To(Succeed())
+		Expect(payload).To(Equal(map[string]string{
+			"tls.crt":      string(secret.Data[corev1.TLSCertKey]),
+			"tls.key":      string(secret.Data[corev1.TLSPrivateKeyKey]),
+			"ca.crt":       string(secret.Data["ca.crt"]),
+			"serialNumber": updated.Status.SerialNumber,
+			"notAfter":     updated.Status.NotAfter.UTC().Format(time.RFC3339),
+		}))
+
+		Expect(updated.Status.ExternalSecretPath).To(Equal("default/prod/mirrored/tls"))
+		Expect(updated.Status.ExternalSecretFingerprint).To(Equal(updated.Status.Fingerprint))
+		synced := meta.FindStatusCondition(updated.Status.Conditions, typeExternalSecretSyncedCert)
+		Expect(synced).NotTo(BeNil())
+		Expect(synced.Status).To(Equal(metav1.ConditionTrue))
+
+		// An unchanged certificate isn't mirrored again
+		_, err = reconcileAndGet(r, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.puts["default/prod/mirrored/tls"]).To(HaveLen(1))
+	})
+
+	It("should retry a failed mirror", func() {
+		cert := newTestCertificate("mirror-retry")
+		cert.Spec.ExternalSecretPath = "prod/mirror-retry/tls"
+		store := &fakeSecretStore{err: errors.New("throttled")}
+		r := newFakeReconciler(cert)
+		r.ExternalSecretStore = store
+
+		updated, err := reconcileAndGet(r, cert)
+		Expect(err).To(MatchError("throttled"))
+		Expect(updated.Status.ExternalSecretFingerprint).To(BeEmpty())
+		synced := meta.FindStatusCondition(updated.Status.Conditions, typeExternalSecretSyncedCert)
+		Expect(synced).NotTo(BeNil())
+		Expect(synced.Reason).To(Equal("ExternalSecretSyncFailed"))
+
+		store.err = nil
+		updated, err = reconcileAndGet(r, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.puts["default/prod/mirror-retry/tls"]).To(HaveLen(1))
+		Expect(updated.Status.ExternalSecretFingerprint).To(Equal(updated.Status.Fingerprint))
+	})
+
+	It("should not mirror a secret that doesn't hold the certificate in status yet", func() {
+		cert := newTestCertificate("mirror-stale")
+		cert.Spec.ExternalSecretPath = "prod/mirror-stale/tls"
+		store := &fakeSecretStore{}
+		r := newFakeReconciler(cert)
+		r.ExternalSecretStore = store
+		updated, err := reconcileAndGet(r, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.puts["default/prod/mirror-stale/tls"]).To(HaveLen(1))
+
+		// Status already records a renewal the secret doesn't show yet
+		updated.Status.Fingerprint = "renewed"
+		Expect(r.syncExternalSecret(ctx, updated)).To(MatchError(ContainSubstring("doesn't hold the certificate")))
+		Expect(store.puts["default/prod/mirror-stale/tls"]).To(HaveLen(1))
+		Expect(updated.Status.ExternalSecretFingerprint).NotTo(Equal("renewed"))
+	})
+
+	It("should report a missing store", func() {
+		cert := newTestCertificate("mirror-unconfigured")
+		cert.Spec.ExternalSecretPath = "prod/mirror-unconfigured/tls"
+		r := newFakeReconciler(cert)
+
+		updated, err := reconcileAndGet(r, cert)
+		Expect(err).NotTo(HaveOccurred())
+		synced := meta.FindStatusCondition(updated.Status.Conditions, typeExternalSecretSyncedCert)
+		Expect(synced).NotTo(BeNil())
+		Expect(synced.Reason).To(Equal("NoExternalSecretStore"))
+	})
+})
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// feedbackAnnotationPrefix prefixes annotations consumers set on a Certificate to report that
+	// they rejected it, e.g. feedback.cert.example.com/envoy-sidecar: "unknown CA". The suffix names
+	// the consumer and the value says why. Consumers remove their annotation once they accept it again
+	feedbackAnnotationPrefix = "feedback.cert.example.com/"
+
+	// typeValidationFeedbackCert aggregates the rejections reported by consumers
+	typeValidationFeedbackCert = "ValidationFeedback"
+)
+
+// reconcileFeedback sets the ValidationFeedback condition from the consumers' feedback
+// annotations and reports whether it changed. Certificates that never had feedback get no condition
+func reconcileFeedback(cert *certv1alpha1.Certificate) bool {
+	var reports []string
+	for key, value := range cert.Annotations {
+		consumer, ok := strings.CutPrefix(key, feedbackAnnotationPrefix)
+		if !ok || consumer == "" {
+			continue
+		}
+		reports = append(reports, fmt.Sprintf("%s: %s", consumer, value))
+	}
+
+	if len(reports) == 0 {
+		if meta.FindStatusCondition(cert.Status.Conditions, typeValidationFeedbackCert) == nil {
+			return false
+		}
+		return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeValidationFeedbackCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoRejections",
+			Message:            "No consumer reports rejecting the certificate",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	sort.Strings(reports)
+	return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeValidationFeedbackCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ConsumerRejected",
+		Message:            fmt.Sprintf("%d consumer(s) rejected the certificate: %s", len(reports), strings.Join(reports, "; ")),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// syncFeedback records consumer feedback in status when it changed
+func (r *CertificateReconciler) syncFeedback(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if !reconcileFeedback(cert) {
+		return nil
+	}
+	if err := r.updateStatus(ctx, cert); err != nil {
+		return fmt.Errorf("failed to record validation feedback: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Validation feedback", func() {
+	It("should surface consumer feedback annotations in status and clear them once withdrawn", func() {
+		cert := newTestCertificate("feedback")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeValidationFeedbackCert)).To(BeNil())
+
+		// Two sidecars report rejecting the certificate
+		updated.Annotations = map[string]string{
+			feedbackAnnotationPrefix + "envoy":   "certificate signed by unknown authority",
+			feedbackAnnotationPrefix + "haproxy": "missing SAN api.internal",
+		}
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		feedback := meta.FindStatusCondition(updated.Status.Conditions, typeValidationFeedbackCert)
+		Expect(feedback).NotTo(BeNil())
+		Expect(feedback.Status).To(Equal(metav1.ConditionTrue))
+		Expect(feedback.Reason).To(Equal("ConsumerRejected"))
+		Expect(feedback.Message).To(Equal("2 consumer(s) rejected the certificate: " +
+			"envoy: certificate signed by unknown authority; haproxy: missing SAN api.internal"))
+
+		// The consumers accept the certificate again
+		updated.Annotations = nil
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		feedback = meta.FindStatusCondition(updated.Status.Conditions, typeValidationFeedbackCert)
+		Expect(feedback).NotTo(BeNil())
+		Expect(feedback.Status).To(Equal(metav1.ConditionFalse))
+		Expect(feedback.Reason).To(Equal("NoRejections"))
+	})
+})
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// heartbeatInterval is the shortest time between two heartbeat Lease updates, so busy
+// reconcile loops don't write the Lease on every reconcile
+const heartbeatInterval = 10 * time.Second
+
+// heartbeatLeaseDuration tells monitors how long to wait for the next heartbeat before
+// considering the controller stalled
+const heartbeatLeaseDuration = int32(5 * time.Minute / time.Second)
+
+// recordHeartbeat stamps the heartbeat Lease with the time of a successful reconcile
+func (r *CertificateReconciler) recordHeartbeat(ctx context.Context) error {
+	if r.HeartbeatLease.Name == "" {
+		return nil
+	}
+	now := metav1.NewMicroTime(time.Now())
+	leaseDuration := heartbeatLeaseDuration
+
+	// The manager can't list and watch Leases, so a cached read would never sync
+	lease := &coordinationv1.Lease{}
+	err := r.apiReader().Get(ctx, r.HeartbeatLease, lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      r.HeartbeatLease.Name,
+				Namespace: r.HeartbeatLease.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "certificate-operator"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: &leaseDuration,
+				RenewTime:            &now,
+			},
+		}
+		return r.Create(ctx, lease)
+	}
+	if err != nil {
+		return err
+	}
+
+	if lease.Spec.RenewTime != nil && now.Sub(lease.Spec.RenewTime.Time) < heartbeatInterval {
+		return nil
+	}
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseDurationSeconds = &leaseDuration
+	if err := r.Update(ctx, lease); err != nil {
+		// Another replica or reconcile renewed it first, which is just as good
+		if errors.IsConflict(err) {
+			log.FromContext(ctx).V(1).Info("Heartbeat lease renewed concurrently")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Heartbeat lease", func() {
+	leaseKey := types.NamespacedName{Name: "certificate-operator-heartbeat", Namespace: "default"}
+
+	It("should advance the lease's renewTime after a reconcile", func() {
+		stale := metav1.NewMicroTime(time.Now().Add(-time.Hour))
+		cert := newTestCertificate("heartbeat")
+		// Reads are checked against the manager's RBAC, like a cached client would need
+		r := newCachedReconciler(cert, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: leaseKey.Name, Namespace: leaseKey.Namespace},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &stale},
+		})
+		r.HeartbeatLease = leaseKey
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		lease := &coordinationv1.Lease{}
+		Expect(r.APIReader.Get(ctx, leaseKey, lease)).To(Succeed())
+		Expect(lease.Spec.RenewTime.Time).To(BeTemporally(">", stale.Time))
+		Expect(lease.Spec.RenewTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(*lease.Spec.LeaseDurationSeconds).To(Equal(heartbeatLeaseDuration))
+	})
+
+	It("should create the lease when it doesn't exist", func() {
+		cert := newTestCertificate("heartbeat-create")
+		r := newCachedReconciler(cert)
+		r.HeartbeatLease = leaseKey
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		lease := &coordinationv1.Lease{}
+		Expect(r.APIReader.Get(ctx, leaseKey, lease)).To(Succeed())
+		Expect(lease.Spec.RenewTime).NotTo(BeNil())
+	})
+
+	It("should leave a freshly renewed lease alone", func() {
+		recent := metav1.NewMicroTime(time.Now().Add(-time.Second).Truncate(time.Microsecond))
+		r := newFakeReconciler(&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: leaseKey.Name, Namespace: leaseKey.Namespace},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &recent},
+		})
+		r.HeartbeatLease = leaseKey
+
+		Expect(r.recordHeartbeat(ctx)).To(Succeed())
+		lease := &coordinationv1.Lease{}
+		Expect(r.Get(ctx, leaseKey, lease)).To(Succeed())
+		Expect(lease.Spec.RenewTime.Time).To(BeTemporally("==", recent.Time))
+	})
+
+	It("should not create a lease when disabled", func() {
+		r := newFakeReconciler()
+		Expect(r.recordHeartbeat(ctx)).To(Succeed())
+		leases := &coordinationv1.LeaseList{}
+		Expect(r.List(ctx, leases)).To(Succeed())
+		Expect(leases.Items).To(BeEmpty())
+	})
+})
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// Host path sync contract
+//
+// Node-level consumers such as the kubelet or the container runtime read certificates
+// from disk rather than from secrets. The operator doesn't write to nodes itself; instead it
+// marks secrets of certificates with Spec.HostPath for a distribution DaemonSet, which is
+// expected to:
+//   - watch Secrets labelled hostSyncLabel=true in all namespaces
+//   - write the keys listed in hostSyncKeys that are present into the directory named by
+//     hostPathAnnotation on every node, using the key as file name
+//   - replace files atomically (write to a temporary file, then rename) so readers never
+//     observe a certificate without its matching key
+//   - keep tls.key readable by root only
+//   - stop syncing, leaving existing files in place, once the label is removed
+const (
+	hostSyncLabel      = "cert.example.com/host-sync"
+	hostPathAnnotation = "cert.example.com/host-path"
+)
+
+// hostSyncKeys are the secret keys the distribution DaemonSet copies to the host
+var hostSyncKeys = []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"}
+
+// applyHostSyncMetadata marks the secret for host path sync, or clears the marks when the
+// certificate no longer asks for it
+func applyHostSyncMetadata(cert *certv1alpha1.Certificate, obj metav1.Object) {
+	labels := obj.GetLabels()
+	annotations := obj.GetAnnotations()
+
+	if cert.Spec.HostPath == "" {
+		delete(labels, hostSyncLabel)
+		delete(annotations, hostPathAnnotation)
+		return
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	labels[hostSyncLabel] = "true"
+	annotations[hostPathAnnotation] = cert.Spec.HostPath
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+}
+
+// expectedHostFiles describes the files a distribution DaemonSet should write for a
+// marked secret, keyed by absolute host path. It returns nil for unmarked secrets
+func expectedHostFiles(secret *corev1.Secret) map[string][]byte {
+	dir := secret.Annotations[hostPathAnnotation]
+	if secret.Labels[hostSyncLabel] != "true" || dir == "" {
+		return nil
+	}
+
+	files := map[string][]byte{}
+	for _, key := range hostSyncKeys {
+		if data, ok := secret.Data[key]; ok {
+			files[path.Join(dir, key)] = data
+		}
+	}
+	return files
+}
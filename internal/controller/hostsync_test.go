@@ -0,0 +1,52 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Host path sync", func() {
+	It("should mark the secret for the distribution DaemonSet", func() {
+		cert := newTestCertificate("host-sync")
+		cert.Spec.HostPath = "/etc/kubernetes/pki/operator"
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(hostSyncLabel, "true"))
+		Expect(secret.Annotations).To(HaveKeyWithValue(hostPathAnnotation, "/etc/kubernetes/pki/operator"))
+		Expect(expectedHostFiles(secret)).To(Equal(map[string][]byte{
+			"/etc/kubernetes/pki/operator/tls.crt": secret.Data[corev1.TLSCertKey],
+			"/etc/kubernetes/pki/operator/tls.key": secret.Data[corev1.TLSPrivateKeyKey],
+		}))
+	})
+
+	It("should clear the marks when host sync is disabled", func() {
+		cert := newTestCertificate("host-sync-off")
+		cert.Spec.HostPath = "/etc/operator"
+		r := newFakeReconciler(cert)
+		issued := &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")}
+		Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+		Expect(r.Get(ctx, key, secret)).To(Succeed())
+		secret.Annotations["example.com/owner"] = "platform"
+		Expect(r.Update(ctx, secret)).To(Succeed())
+
+		cert.Spec.HostPath = ""
+		Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+		Expect(r.Get(ctx, key, secret)).To(Succeed())
+		Expect(secret.Labels).NotTo(HaveKey(hostSyncLabel))
+		Expect(secret.Annotations).NotTo(HaveKey(hostPathAnnotation))
+		Expect(secret.Annotations).To(HaveKeyWithValue("example.com/owner", "platform"))
+		Expect(expectedHostFiles(secret)).To(BeNil())
+	})
+})
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// applyImmutability marks the secret immutable when the spec asks for it
+func applyImmutability(cert *certv1alpha1.Certificate, secret *corev1.Secret) {
+	if !cert.Spec.ImmutableSecret {
+		secret.Immutable = nil
+		return
+	}
+	immutable := true
+	secret.Immutable = &immutable
+}
+
+// isImmutable reports whether the secret's data can no longer be updated
+func isImmutable(secret *corev1.Secret) bool {
+	return secret.Immutable != nil && *secret.Immutable
+}
+
+// recreateBackoff bounds the attempts to create the replacement of a deleted immutable secret
+// within a single reconcile, since consumers find no secret until it succeeds
+var recreateBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 50 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// replaceSecret swaps an immutable secret for its replacement. The delete is conditional on the
+// UID and resourceVersion that were read, so a secret changed concurrently fails with a conflict
+// and is re-read instead of being lost. The replacement is created straight after it and retried
+// with backoff to keep the gap where consumers find no secret as short as possible; a create that
+// still fails is reported as a Warning event so the missing secret doesn't go unnoticed
+func (r *CertificateReconciler) replaceSecret(ctx context.Context, cert *certv1alpha1.Certificate, existing, replacement *corev1.Secret) error {
+	preconditions := client.Preconditions{UID: &existing.UID, ResourceVersion: &existing.ResourceVersion}
+	if err := r.Delete(ctx, existing, preconditions); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	err := retry.OnError(recreateBackoff, func(err error) bool {
+		return !errors.IsAlreadyExists(err) && !errors.IsInvalid(err)
+	}, func() error {
+		return r.Create(ctx, replacement.DeepCopy())
+	})
+	if err != nil {
+		r.Recorder.Eventf(cert, corev1.EventTypeWarning, "SecretRecreateFailed",
+			"Deleted immutable secret %s but failed to recreate it: %v", replacement.Name, err)
+		return fmt.Errorf("failed to recreate immutable secret %s: %w", replacement.Name, err)
+	}
+	return nil
+}
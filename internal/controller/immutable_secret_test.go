@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Immutable secrets", func() {
+	It("should mark the secret immutable and replace it on renewal", func() {
+		cert := newTestCertificate("immutable")
+		cert.Spec.ImmutableSecret = true
+		r := newFakeReconciler(cert)
+		// The fake client doesn't enforce immutability, so reject updates the way the API server does
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					current := &corev1.Secret{}
+					if err := c.Get(ctx, client.ObjectKeyFromObject(secret), current); err == nil && isImmutable(current) {
+						return errors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Secret").GroupKind(), secret.Name,
+							field.ErrorList{field.Forbidden(field.NewPath("data"), "field is immutable when `immutable` is set")})
+					}
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		})
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(isImmutable(secret)).To(BeTrue())
+		first := secret.Data["tls.crt"]
+
+		By("renewing the certificate")
+		issued := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, issued)).To(Succeed())
+		issued.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, issued)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		renewed := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, renewed)).To(Succeed())
+		Expect(isImmutable(renewed)).To(BeTrue())
+		Expect(renewed.Data["tls.crt"]).NotTo(Equal(first))
+		Expect(verifySecretKeyPair(renewed)).To(Succeed())
+		Expect(renewed.OwnerReferences).To(HaveLen(1))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(renewed.Annotations).To(HaveKeyWithValue(serialAnnotation, updated.Status.SerialNumber))
+	})
+
+	It("should make an existing mutable secret immutable in place", func() {
+		cert := newTestCertificate("immutable-existing")
+		cert.Spec.ImmutableSecret = true
+		existing := newOwnedSecret(cert)
+		r := newFakeReconciler(cert, existing)
+
+		Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")})).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+		Expect(isImmutable(secret)).To(BeTrue())
+		Expect(secret.Data).To(HaveKeyWithValue("tls.crt", []byte("cert")))
+	})
+
+	It("should retry recreating a replaced secret and warn when it can't", func() {
+		cert := newTestCertificate("immutable-recreate")
+		cert.Spec.ImmutableSecret = true
+		immutable := true
+		existing := newOwnedSecret(cert)
+		existing.Immutable = &immutable
+		r := newFakeReconciler(cert, existing)
+		failures := 2
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Secret); ok && failures > 0 {
+					failures--
+					return errors.NewServiceUnavailable("etcd is unavailable")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+
+		Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")})).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("tls.crt", []byte("cert")))
+
+		By("failing every attempt")
+		failures = recreateBackoff.Steps
+		Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("renewed"), keyPEM: []byte("key")})).NotTo(Succeed())
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Warning SecretRecreateFailed")))
+	})
+
+	It("should re-issue when immutability is toggled", func() {
+		cert := newTestCertificate("immutable-hash")
+		before := issuanceSpecHash(cert)
+		cert.Spec.ImmutableSecret = true
+		Expect(issuanceSpecHash(cert)).NotTo(Equal(before))
+	})
+})
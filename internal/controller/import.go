@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// reasonCertificateExpiring marks an imported certificate that has entered its renewal window
+const reasonCertificateExpiring = "CertificateExpiring"
+
+// syncImportedCertificate tracks the expiry of an externally managed certificate without
+// issuing anything, restarting consumers when it is rotated or enters its renewal window
+func (r *CertificateReconciler) syncImportedCertificate(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	logger := log.FromContext(ctx)
+
+	leaf, certPEM, err := r.loadImportedCertificate(ctx, cert)
+	if err != nil {
+		logger.Error(err, "Failed to import certificate")
+		r.fail(ctx, cert, "ImportFailed", fmt.Errorf("failed to import certificate: %w", err))
+		return err
+	}
+
+	fingerprint := certificateFingerprint(certPEM)
+	rotated := cert.Status.Fingerprint != "" && cert.Status.Fingerprint != fingerprint
+	renewalTime := r.calculateRenewalTime(cert, leaf.NotAfter)
+	expiring := time.Now().After(renewalTime.Time)
+	ready := meta.FindStatusCondition(cert.Status.Conditions, typeReadyCert)
+	wasExpiring := ready != nil && ready.Reason == reasonCertificateExpiring
+
+	cert.Status.NotBefore = &metav1.Time{Time: leaf.NotBefore}
+	cert.Status.NotAfter = &metav1.Time{Time: leaf.NotAfter}
+	cert.Status.RenewalTime = renewalTime
+	cert.Status.SerialNumber = fmt.Sprintf("%x", leaf.SerialNumber)
+	cert.Status.Fingerprint = fingerprint
+	cert.Status.PublicKeyPin = publicKeyPin(leaf.RawSubjectPublicKeyInfo)
+	cert.Status.IssuerCommonName, cert.Status.ChainLength = describeChain(certPEM)
+
+	if expiring {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeReadyCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonCertificateExpiring,
+			Message:            fmt.Sprintf("Imported certificate expires at %s and must be renewed externally", leaf.NotAfter.Format(time.RFC3339)),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeReadyCert,
+			Status:             metav1.ConditionTrue,
+			Reason:             "CertificateImported",
+			Message:            fmt.Sprintf("Certificate imported from secret %s", cert.Spec.ImportFromSecret),
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+	meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeAvailableCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reconciling",
+		Message:            fmt.Sprintf("Certificate for (%s) imported successfully", cert.Name),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	if err := r.updateStatus(ctx, cert); err != nil {
+		logger.Error(err, "Failed to update Certificate status")
+		return err
+	}
+
+	// Restart once per rotation and once when entering the renewal window
+	if cert.Spec.RestartDeployments && (rotated || (expiring && !wasExpiring)) {
+		if err := r.restartDeployments(ctx, cert); err != nil {
+			logger.Error(err, "Failed to restart deployments")
+		}
+	}
+
+	return nil
+}
+
+// loadImportedCertificate reads and parses the leaf certificate of the imported secret
+func (r *CertificateReconciler) loadImportedCertificate(ctx context.Context, cert *certv1alpha1.Certificate) (*x509.Certificate, []byte, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: cert.Spec.ImportFromSecret, Namespace: cert.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to get secret %s: %w", key.Name, err)
+	}
+
+	leaf, err := parseStoredCertificate(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return leaf, secret.Data[corev1.TLSCertKey], nil
+}
+
+// parseStoredCertificate parses the leaf certificate at the start of the secret's tls.crt
+func parseStoredCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("secret %s has no PEM certificate in %s", secret.Name, corev1.TLSCertKey)
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate in secret %s: %w", secret.Name, err)
+	}
+	return leaf, nil
+}
+
+// consumerSecretName is the secret workloads mount for this certificate
+func consumerSecretName(cert *certv1alpha1.Certificate) string {
+	if cert.Spec.ImportFromSecret != "" {
+		return cert.Spec.ImportFromSecret
+	}
+	return cert.Spec.SecretName
+}
+
+// consumerNamespace is the namespace of the secret workloads mount for this certificate
+func consumerNamespace(cert *certv1alpha1.Certificate) string {
+	if cert.Spec.ImportFromSecret != "" {
+		return cert.Namespace
+	}
+	return secretNamespace(cert)
+}
+
+// certificatesForImportedSecret maps a secret to the Certificates importing it, which neither own
+// nor label it, so an external rotation is recorded as it happens
+func (r *CertificateReconciler) certificatesForImportedSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cert := range certificates.Items {
+		if cert.Spec.ImportFromSecret == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cert)})
+		}
+	}
+	return requests
+}
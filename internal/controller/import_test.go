@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Imported certificates", func() {
+	newImportingCertificate := func(name, secretName string) *certv1alpha1.Certificate {
+		cert := newTestCertificate(name)
+		cert.Spec.ImportFromSecret = secretName
+		return cert
+	}
+
+	It("should populate status from the imported secret and requeue before expiry", func() {
+		imported := newKeyPairSecret("external-tls", "external.example.com", false, 90*24*time.Hour)
+		cert := newImportingCertificate("imported", imported.Name)
+		r := newFakeReconciler(cert, imported)
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		leaf := parseCertificatePEM(imported.Data[corev1.TLSCertKey])
+		Expect(updated.Status.NotAfter.Unix()).To(Equal(leaf.NotAfter.Unix()))
+		Expect(updated.Status.RenewalTime.Time).To(BeTemporally("~", leaf.NotAfter.Add(-30*24*time.Hour), time.Second))
+		Expect(updated.Status.IssuerCommonName).To(Equal("external.example.com"))
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<", time.Until(updated.Status.RenewalTime.Time)))
+
+		// Nothing is issued for imported certificates
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})).NotTo(Succeed())
+	})
+
+	It("should flag an expiring certificate and restart its consumers once", func() {
+		imported := newKeyPairSecret("expiring-tls", "expiring.example.com", false, 10*24*time.Hour)
+		cert := newImportingCertificate("expiring", imported.Name)
+		cert.Spec.RestartDeployments = true
+		deploy := newConsumerDeployment("consumer", imported.Name)
+		r := newFakeReconciler(cert, imported, deploy)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(reasonCertificateExpiring))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+		Expect(deploy.Spec.Template.Annotations).To(HaveKey("cert.example.com/restartedAt"))
+
+		// A later reconcile inside the same window doesn't restart again
+		delete(deploy.Spec.Template.Annotations, "cert.example.com/restartedAt")
+		Expect(r.Update(ctx, deploy)).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+		Expect(deploy.Spec.Template.Annotations).NotTo(HaveKey("cert.example.com/restartedAt"))
+	})
+
+	It("should map a rotation of the imported secret to the importing certificates", func() {
+		imported := newKeyPairSecret("rotated-tls", "rotated.example.com", false, 90*24*time.Hour)
+		cert := newImportingCertificate("rotated", imported.Name)
+		other := newImportingCertificate("other-import", "other-tls")
+		r := newFakeReconciler(cert, other, imported)
+
+		Expect(r.certificatesForImportedSecret(ctx, imported)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}))
+	})
+
+	It("should report a missing imported secret", func() {
+		cert := newImportingCertificate("import-missing", "absent")
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("ImportFailed"))
+	})
+})
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// issuanceSlotRetryDelay is how soon a certificate that didn't get an issuance slot retries
+const issuanceSlotRetryDelay = 5 * time.Second
+
+// issuanceWaiterTTL forgets waiters that stopped retrying, e.g. because they were deleted
+const issuanceWaiterTTL = 3 * issuanceSlotRetryDelay
+
+// issuanceQueue bounds concurrent issuances and hands free slots to the waiting certificate that
+// expires first, so after downtime the most urgent certificates get issuer capacity before the
+// rest. Reconciles don't block on a slot: a certificate turned away is remembered as waiting and
+// retries, and while it waits certificates expiring later are turned away too. Certificates that
+// were never issued have nothing to fall back on and go first. Waiters with the same expiry are
+// served in arrival order. The zero value is ready to use
+type issuanceQueue struct {
+	mu      sync.Mutex
+	active  int
+	waiting map[types.NamespacedName]issuanceWaiter
+}
+
+// issuanceWaiter is a certificate retrying for a free issuance slot
+type issuanceWaiter struct {
+	// notAfter is the expiry of the certificate currently in use; zero when none was issued
+	notAfter time.Time
+	// since is when it first asked for a slot, and seen when it last did
+	since time.Time
+	seen  time.Time
+}
+
+// before reports whether w is served ahead of other; names break ties so the order is total
+func (w issuanceWaiter) before(key types.NamespacedName, other issuanceWaiter, otherKey types.NamespacedName) bool {
+	if !w.notAfter.Equal(other.notAfter) {
+		return w.notAfter.Before(other.notAfter)
+	}
+	if !w.since.Equal(other.since) {
+		return w.since.Before(other.since)
+	}
+	return key.String() < otherKey.String()
+}
+
+// tryAcquire takes an issuance slot for the certificate key without waiting, and returns the
+// function releasing it. notAfter is the expiry of the certificate being renewed, or zero for a
+// first issuance. It reports false when every slot is taken or a more urgent certificate is
+// waiting, and the caller retries after issuanceSlotRetryDelay. A limit of zero or less doesn't
+// bound concurrency
+func (q *issuanceQueue) tryAcquire(key types.NamespacedName, notAfter time.Time, limit int, now time.Time) (func(), bool) {
+	if limit <= 0 {
+		return func() {}, true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.waiting == nil {
+		q.waiting = map[types.NamespacedName]issuanceWaiter{}
+	}
+	waiter, ok := q.waiting[key]
+	if !ok {
+		waiter.since = now
+	}
+	waiter.notAfter = notAfter
+	waiter.seen = now
+	q.waiting[key] = waiter
+
+	if q.active >= limit || q.waitingAhead(key, waiter, now) {
+		return nil, false
+	}
+	delete(q.waiting, key)
+	q.active++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.active--
+		})
+	}, true
+}
+
+// waitingAhead reports whether another waiter is served before key, forgetting the ones that
+// stopped retrying. Callers hold q.mu
+func (q *issuanceQueue) waitingAhead(key types.NamespacedName, waiter issuanceWaiter, now time.Time) bool {
+	ahead := false
+	for otherKey, other := range q.waiting {
+		if otherKey == key {
+			continue
+		}
+		if now.Sub(other.seen) > issuanceWaiterTTL {
+			delete(q.waiting, otherKey)
+			continue
+		}
+		if other.before(otherKey, waiter, key) {
+			ahead = true
+		}
+	}
+	return ahead
+}
+
+// acquireIssuanceSlot takes an issuance slot for cert, ordered by the expiry of its current certificate
+func (r *CertificateReconciler) acquireIssuanceSlot(cert *certv1alpha1.Certificate) (func(), bool) {
+	var notAfter time.Time
+	if cert.Status.NotAfter != nil {
+		notAfter = cert.Status.NotAfter.Time
+	}
+	return r.issuanceQueue.tryAcquire(types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace},
+		notAfter, r.MaxConcurrentIssuances, time.Now())
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Issuance ordering", func() {
+	It("should issue overdue certificates nearest-expiry-first under the issuance limit", func() {
+		overdue := func(name string, expiresIn time.Duration) *certv1alpha1.Certificate {
+			cert := newTestCertificate(name)
+			cert.Status.NotAfter = &metav1.Time{Time: time.Now().Add(expiresIn)}
+			cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			return cert
+		}
+		later, first, next := overdue("expires-later", 72*time.Hour), overdue("expires-first", time.Hour),
+			overdue("expires-next", 24*time.Hour)
+
+		r := newFakeReconciler(later, first, next)
+		r.MaxConcurrentIssuances = 1
+		var order []string
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					order = append(order, secret.Name)
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		reconcileCert := func(cert *certv1alpha1.Certificate) reconcile.Result {
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return result
+		}
+
+		By("turning every certificate away while the only slot is taken")
+		release, ok := r.issuanceQueue.tryAcquire(types.NamespacedName{Name: "busy"}, time.Time{}, 1, time.Now())
+		Expect(ok).To(BeTrue())
+		for _, cert := range []*certv1alpha1.Certificate{later, next, first} {
+			Expect(reconcileCert(cert).RequeueAfter).To(Equal(issuanceSlotRetryDelay))
+		}
+		Expect(order).To(BeEmpty())
+		release()
+
+		By("handing the free slot to the waiter that expires first, whoever retries first")
+		Expect(reconcileCert(later).RequeueAfter).To(Equal(issuanceSlotRetryDelay))
+		Expect(reconcileCert(next).RequeueAfter).To(Equal(issuanceSlotRetryDelay))
+		reconcileCert(first)
+		Expect(reconcileCert(later).RequeueAfter).To(Equal(issuanceSlotRetryDelay))
+		reconcileCert(next)
+		reconcileCert(later)
+
+		Expect(order).To(Equal([]string{"expires-first-tls", "expires-next-tls", "expires-later-tls"}))
+	})
+
+	It("should forget waiters that stopped retrying", func() {
+		var q issuanceQueue
+		now := time.Now()
+		release, ok := q.tryAcquire(types.NamespacedName{Name: "holder"}, time.Time{}, 1, now)
+		Expect(ok).To(BeTrue())
+		_, ok = q.tryAcquire(types.NamespacedName{Name: "gone"}, now, 1, now)
+		Expect(ok).To(BeFalse())
+		release()
+		release()
+
+		_, ok = q.tryAcquire(types.NamespacedName{Name: "later"}, now.Add(time.Hour), 1, now)
+		Expect(ok).To(BeFalse())
+		release, ok = q.tryAcquire(types.NamespacedName{Name: "later"}, now.Add(time.Hour), 1, now.Add(issuanceWaiterTTL+time.Second))
+		Expect(ok).To(BeTrue())
+		release()
+		Expect(q.active).To(BeZero())
+		Expect(q.waiting).To(BeEmpty())
+	})
+})
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// issuerKindIssuer references an Issuer resource configuring the backend
+const issuerKindIssuer = "Issuer"
+
+// applyIssuer resolves an IssuerRef of kind Issuer in memory to the backend the Issuer configures,
+// so issuance, policy and metrics see the same IssuerRef an inline reference would give them.
+// Like the template, the resolved spec must never be written back
+func (r *CertificateReconciler) applyIssuer(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if cert.Spec.IssuerRef.Kind != issuerKindIssuer {
+		return nil
+	}
+
+	issuer := &certv1alpha1.Issuer{}
+	key := types.NamespacedName{Name: cert.Spec.IssuerRef.Name, Namespace: cert.Namespace}
+	if err := r.Get(ctx, key, issuer); err != nil {
+		return fmt.Errorf("%w: failed to get issuer %s: %w", ErrIssuerLoad, key.Name, err)
+	}
+
+	ref, err := resolveIssuer(issuer)
+	if err != nil {
+		return err
+	}
+	cert.Spec.IssuerRef = ref
+	return nil
+}
+
+// resolveIssuer returns the inline IssuerRef equivalent to issuer's backend
+func resolveIssuer(issuer *certv1alpha1.Issuer) (certv1alpha1.IssuerRef, error) {
+	spec := issuer.Spec
+	switch {
+	case spec.CA != nil:
+		return certv1alpha1.IssuerRef{
+			Kind:          issuerKindCA,
+			Name:          spec.CA.SecretName,
+			CertKey:       spec.CA.CertKey,
+			PrivateKeyKey: spec.CA.PrivateKeyKey,
+		}, nil
+	case spec.SelfSigned != nil:
+		return certv1alpha1.IssuerRef{Kind: issuerKindSelfSigned}, nil
+	case spec.ACME != nil:
+		return certv1alpha1.IssuerRef{}, fmt.Errorf("%w: issuer %s uses ACME, which this controller can't issue from yet",
+			ErrUnsupportedIssuer, issuer.Name)
+	case spec.Vault != nil:
+		return certv1alpha1.IssuerRef{}, fmt.Errorf("%w: issuer %s uses Vault, which this controller can't issue from yet",
+			ErrUnsupportedIssuer, issuer.Name)
+	default:
+		return certv1alpha1.IssuerRef{}, fmt.Errorf("%w: issuer %s configures no backend", ErrUnsupportedIssuer, issuer.Name)
+	}
+}
+
+// certificatesForIssuer maps an Issuer to the Certificates referencing it
+func (r *CertificateReconciler) certificatesForIssuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cert := range certificates.Items {
+		if cert.Spec.IssuerRef.Kind == issuerKindIssuer && cert.Spec.IssuerRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cert)})
+		}
+	}
+	return requests
+}
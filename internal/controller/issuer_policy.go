@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// maxDurationAnnotation on a CA issuer secret caps the duration of the certificates it signs
+	maxDurationAnnotation = "cert.example.com/max-duration"
+
+	// defaultRenewBeforeAnnotation on a CA issuer secret supplies renewBefore to certificates that don't set one
+	defaultRenewBeforeAnnotation = "cert.example.com/default-renew-before"
+
+	// typeDurationClampedCert reports that the issuer's max duration shortened the requested duration
+	typeDurationClampedCert = "DurationClamped"
+)
+
+// applyIssuerPolicy applies the duration policy annotated on the CA issuer secret to the
+// certificate's spec in memory, like applyTemplate. A missing issuer is left for issuance to report
+func (r *CertificateReconciler) applyIssuerPolicy(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	// A CA loaded from files has no secret to carry a policy
+	if cert.Spec.IssuerRef.Kind != issuerKindCA || cert.Spec.IssuerRef.Name == "" {
+		return nil
+	}
+
+	ctx, cancel, err := issuanceContext(ctx, cert)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	issuer := &corev1.Secret{}
+	key := types.NamespacedName{Name: cert.Spec.IssuerRef.Name, Namespace: cert.Namespace}
+	if err := r.Get(ctx, key, issuer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("%w: failed to get CA secret %s: %w", ErrCALoad, key.Name, err)
+	}
+
+	if value, ok := issuer.Annotations[defaultRenewBeforeAnnotation]; ok && cert.Spec.RenewBefore == "" {
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%w: invalid %s annotation on issuer %s: %w", ErrInvalidSpec, defaultRenewBeforeAnnotation, key.Name, err)
+		}
+		cert.Spec.RenewBefore = value
+	}
+
+	value, ok := issuer.Annotations[maxDurationAnnotation]
+	if !ok {
+		meta.RemoveStatusCondition(&cert.Status.Conditions, typeDurationClampedCert)
+		return nil
+	}
+	maxDuration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s annotation on issuer %s: %w", ErrInvalidSpec, maxDurationAnnotation, key.Name, err)
+	}
+	now := time.Now()
+	notAfter, err := certificateNotAfter(cert, now)
+	if err != nil || notAfter.Sub(now) <= maxDuration {
+		// An invalid duration is reported by issuance
+		meta.RemoveStatusCondition(&cert.Status.Conditions, typeDurationClampedCert)
+		return nil
+	}
+	requested := notAfter.Sub(now).Round(time.Second)
+
+	// A fixed expiry beyond the limit gives way to the max duration too
+	cert.Spec.ExpiresAt = nil
+	cert.Spec.Duration = maxDuration.String()
+	meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeDurationClampedCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "IssuerMaxDuration",
+		Message:            fmt.Sprintf("Requested duration %s exceeds the max duration %s of issuer %s", requested, maxDuration, key.Name),
+		LastTransitionTime: metav1.Now(),
+	})
+	return nil
+}
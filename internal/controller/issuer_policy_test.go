@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Issuer duration policy", func() {
+	reconcilePolicy := func(name, duration, renewBefore string, annotations map[string]string) (*certv1alpha1.Certificate, error) {
+		issuer := newKeyPairSecret(name+"-ca", "Policy CA", true, 365*24*time.Hour)
+		issuer.Annotations = annotations
+		cert := newTestCertificate(name)
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: issuer.Name, Kind: issuerKindCA}
+		cert.Spec.Duration = duration
+		cert.Spec.RenewBefore = renewBefore
+		r := newFakeReconciler(cert, issuer)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return updated, err
+	}
+
+	It("should clamp the requested duration to the issuer's max duration", func() {
+		updated, err := reconcilePolicy("policy-clamp", "2160h", "", map[string]string{maxDurationAnnotation: "720h"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(updated.Status.NotAfter.Sub(updated.Status.NotBefore.Time)).To(Equal(720 * time.Hour))
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeDurationClampedCert)).To(BeTrue())
+		// The clamp is applied in memory only
+		Expect(updated.Spec.Duration).To(Equal("2160h"))
+	})
+
+	It("should leave shorter durations alone", func() {
+		updated, err := reconcilePolicy("policy-within", "240h", "", map[string]string{maxDurationAnnotation: "720h"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.NotAfter.Sub(updated.Status.NotBefore.Time)).To(Equal(240 * time.Hour))
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeDurationClampedCert)).To(BeNil())
+	})
+
+	It("should clamp a fixed expiry beyond the issuer's max duration", func() {
+		issuer := newKeyPairSecret("policy-expires-at-ca", "Policy CA", true, 365*24*time.Hour)
+		issuer.Annotations = map[string]string{maxDurationAnnotation: "720h"}
+		cert := newTestCertificate("policy-expires-at")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: issuer.Name, Kind: issuerKindCA}
+		expiresAt := metav1.NewTime(time.Now().Add(2160 * time.Hour))
+		cert.Spec.ExpiresAt = &expiresAt
+
+		Expect(newFakeReconciler(issuer).applyIssuerPolicy(ctx, cert)).To(Succeed())
+		Expect(cert.Spec.ExpiresAt).To(BeNil())
+		Expect(cert.Spec.Duration).To(Equal((720 * time.Hour).String()))
+		Expect(meta.IsStatusConditionTrue(cert.Status.Conditions, typeDurationClampedCert)).To(BeTrue())
+	})
+
+	It("should default renewBefore from the issuer", func() {
+		updated, err := reconcilePolicy("policy-renew", "720h", "", map[string]string{defaultRenewBeforeAnnotation: "240h"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.RenewalTime.Time).To(BeTemporally("~", updated.Status.NotAfter.Add(-240*time.Hour), time.Second))
+
+		updated, err = reconcilePolicy("policy-renew-own", "720h", "24h", map[string]string{defaultRenewBeforeAnnotation: "240h"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.RenewalTime.Time).To(BeTemporally("~", updated.Status.NotAfter.Add(-24*time.Hour), time.Second))
+	})
+
+	It("should report an invalid policy", func() {
+		updated, err := reconcilePolicy("policy-invalid", "", "", map[string]string{maxDurationAnnotation: "a month"})
+		Expect(err).To(MatchError(ErrInvalidSpec))
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Reason).To(Equal("InvalidSpec"))
+		Expect(ready.Message).To(ContainSubstring(maxDurationAnnotation))
+		Expect(updated.Status.SerialNumber).To(BeEmpty())
+	})
+})
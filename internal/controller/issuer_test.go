@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Issuers", func() {
+	newIssuer := func(name string, spec certv1alpha1.IssuerSpec) *certv1alpha1.Issuer {
+		return &certv1alpha1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+
+	readyCondition := func(r *CertificateReconciler, cert *certv1alpha1.Certificate) *metav1.Condition {
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		return meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+	}
+
+	It("should sign with the CA an Issuer configures", func() {
+		ca := newKeyPairSecret("issuer-ca", "Issuer Root CA", true, 365*24*time.Hour)
+		issuer := newIssuer("team-ca", certv1alpha1.IssuerSpec{CA: &certv1alpha1.CAIssuer{SecretName: ca.Name}})
+		cert := newTestCertificate("from-issuer")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindIssuer, Name: issuer.Name}
+		r := newFakeReconciler(ca, issuer, cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data["tls.crt"]).Issuer.CommonName).To(Equal("Issuer Root CA"))
+
+		// The resolved reference is not written back to the Certificate
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Spec.IssuerRef.Kind).To(Equal(issuerKindIssuer))
+	})
+
+	It("should self-sign for a SelfSigned Issuer", func() {
+		issuer := newIssuer("self", certv1alpha1.IssuerSpec{SelfSigned: &certv1alpha1.SelfSignedIssuer{}})
+		cert := newTestCertificate("from-self-signed-issuer")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindIssuer, Name: issuer.Name}
+		r := newFakeReconciler(issuer, cert)
+
+		Expect(r.applyIssuer(ctx, cert)).To(Succeed())
+		Expect(cert.Spec.IssuerRef).To(Equal(certv1alpha1.IssuerRef{Kind: issuerKindSelfSigned}))
+	})
+
+	It("should report a missing Issuer and retry", func() {
+		cert := newTestCertificate("missing-issuer")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindIssuer, Name: "absent"}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(MatchError(ErrIssuerLoad))
+		ready := readyCondition(r, cert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("IssuerLoadFailed"))
+	})
+
+	It("should not retry an Issuer whose backend isn't supported", func() {
+		issuer := newIssuer("acme", certv1alpha1.IssuerSpec{ACME: &certv1alpha1.ACMEIssuer{
+			Server:               "https://acme.example.com/directory",
+			PrivateKeySecretName: "acme-account",
+		}})
+		cert := newTestCertificate("from-acme-issuer")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindIssuer, Name: issuer.Name}
+		r := newFakeReconciler(issuer, cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		ready := readyCondition(r, cert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("UnsupportedIssuer"))
+		Expect(ready.Message).To(ContainSubstring("ACME"))
+	})
+
+	It("should enqueue the certificates referencing an Issuer", func() {
+		issuer := newIssuer("shared", certv1alpha1.IssuerSpec{SelfSigned: &certv1alpha1.SelfSignedIssuer{}})
+		referencing := newTestCertificate("references-issuer")
+		referencing.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindIssuer, Name: issuer.Name}
+		sameNameCA := newTestCertificate("references-ca-secret")
+		sameNameCA.Spec.IssuerRef = certv1alpha1.IssuerRef{Kind: issuerKindCA, Name: issuer.Name}
+		r := newFakeReconciler(referencing, sameNameCA)
+
+		Expect(r.certificatesForIssuer(ctx, issuer)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(referencing)},
+		))
+	})
+})
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	jksMagic   = 0xfeedfeed
+	jksVersion = 2
+
+	jksPrivateKeyTag  = 1
+	jksTrustedCertTag = 2
+
+	// jksIntegrityWhitener is mixed into the keystore digest by the JDK's JKS implementation
+	jksIntegrityWhitener = "Mighty Aphrodite"
+)
+
+// oidJKSKeyProtector identifies the JDK's proprietary key protection algorithm
+var oidJKSKeyProtector = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+
+// jksEntry is a keystore entry. Entries with a key are private key entries whose chain starts with
+// the key's certificate; entries without one are trusted certificates holding chain[0]
+type jksEntry struct {
+	alias string
+	// key is the PKCS#8 DER private key
+	key   []byte
+	chain [][]byte
+}
+
+// jksEncryptedPrivateKeyInfo is the PKCS#8 EncryptedPrivateKeyInfo wrapping a protected key
+type jksEncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// encodeJKS writes the entries as a Java KeyStore protected by password. Private keys are
+// protected with the same password, salted from random
+func encodeJKS(random io.Reader, password string, entries []jksEntry, created time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	write := func(v any) { _ = binary.Write(&buf, binary.BigEndian, v) }
+	writeUTF := func(s string) {
+		write(uint16(len(s)))
+		buf.WriteString(s)
+	}
+	writeCertificate := func(der []byte) {
+		writeUTF("X.509")
+		write(uint32(len(der)))
+		buf.Write(der)
+	}
+
+	write(uint32(jksMagic))
+	write(uint32(jksVersion))
+	write(uint32(len(entries)))
+	for _, entry := range entries {
+		if len(entry.chain) == 0 {
+			return nil, fmt.Errorf("keystore entry %s has no certificate", entry.alias)
+		}
+		if entry.key == nil {
+			write(uint32(jksTrustedCertTag))
+			writeUTF(entry.alias)
+			write(created.UnixMilli())
+			writeCertificate(entry.chain[0])
+			continue
+		}
+
+		protected, err := protectJKSKey(random, password, entry.key)
+		if err != nil {
+			return nil, err
+		}
+		write(uint32(jksPrivateKeyTag))
+		writeUTF(entry.alias)
+		write(created.UnixMilli())
+		write(uint32(len(protected)))
+		buf.Write(protected)
+		write(uint32(len(entry.chain)))
+		for _, der := range entry.chain {
+			writeCertificate(der)
+		}
+	}
+
+	digest := sha1.New()
+	digest.Write(jksPassword(password))
+	digest.Write([]byte(jksIntegrityWhitener))
+	digest.Write(buf.Bytes())
+	buf.Write(digest.Sum(nil))
+	return buf.Bytes(), nil
+}
+
+// protectJKSKey encrypts a PKCS#8 key the way the JDK's KeyProtector does: the key is XORed with
+// a chain of SHA-1 digests seeded by a random salt, followed by a digest of the plaintext
+func protectJKSKey(random io.Reader, password string, key []byte) ([]byte, error) {
+	salt := make([]byte, sha1.Size)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate keystore salt: %w", err)
+	}
+
+	passwordBytes := jksPassword(password)
+	encrypted := make([]byte, len(key))
+	digest := salt
+	for offset := 0; offset < len(key); offset += sha1.Size {
+		sum := sha1.Sum(append(append([]byte{}, passwordBytes...), digest...))
+		digest = sum[:]
+		for i := 0; i < sha1.Size && offset+i < len(key); i++ {
+			encrypted[offset+i] = key[offset+i] ^ digest[i]
+		}
+	}
+	check := sha1.Sum(append(append([]byte{}, passwordBytes...), key...))
+
+	protected := append(append(salt, encrypted...), check[:]...)
+	return asn1.Marshal(jksEncryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidJKSKeyProtector, Parameters: asn1.NullRawValue},
+		EncryptedData: protected,
+	})
+}
+
+// jksPassword encodes a password as the big-endian UTF-16 bytes the JDK digests
+func jksPassword(password string) []byte {
+	var encoded []byte
+	for _, unit := range utf16.Encode([]rune(password)) {
+		encoded = append(encoded, byte(unit>>8), byte(unit))
+	}
+	return encoded
+}
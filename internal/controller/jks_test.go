@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/asn1"
+	"encoding/binary"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// decodeJKS checks the keystore's integrity digest against password and returns its entries with
+// their private keys unprotected
+func decodeJKS(data []byte, password string) []jksEntry {
+	ExpectWithOffset(1, len(data)).To(BeNumerically(">", sha1.Size))
+	body, digest := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	expected := sha1.New()
+	expected.Write(jksPassword(password))
+	expected.Write([]byte(jksIntegrityWhitener))
+	expected.Write(body)
+	ExpectWithOffset(1, digest).To(Equal(expected.Sum(nil)), "keystore integrity digest")
+
+	reader := bytes.NewReader(body)
+	readUint32 := func() uint32 {
+		var v uint32
+		ExpectWithOffset(2, binary.Read(reader, binary.BigEndian, &v)).To(Succeed())
+		return v
+	}
+	readBytes := func(n int) []byte {
+		b := make([]byte, n)
+		_, err := reader.Read(b)
+		ExpectWithOffset(2, err).NotTo(HaveOccurred())
+		return b
+	}
+	readUTF := func() string {
+		var n uint16
+		ExpectWithOffset(2, binary.Read(reader, binary.BigEndian, &n)).To(Succeed())
+		return string(readBytes(int(n)))
+	}
+	readCertificate := func() []byte {
+		ExpectWithOffset(2, readUTF()).To(Equal("X.509"))
+		return readBytes(int(readUint32()))
+	}
+
+	ExpectWithOffset(1, readUint32()).To(Equal(uint32(jksMagic)))
+	ExpectWithOffset(1, readUint32()).To(Equal(uint32(jksVersion)))
+	var entries []jksEntry
+	for count := readUint32(); count > 0; count-- {
+		tag := readUint32()
+		entry := jksEntry{alias: readUTF()}
+		readBytes(8) // creation time
+		switch tag {
+		case jksTrustedCertTag:
+			entry.chain = [][]byte{readCertificate()}
+		case jksPrivateKeyTag:
+			var info jksEncryptedPrivateKeyInfo
+			_, err := asn1.Unmarshal(readBytes(int(readUint32())), &info)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			ExpectWithOffset(1, info.Algorithm.Algorithm.Equal(oidJKSKeyProtector)).To(BeTrue())
+			entry.key = unprotectJKSKey(info.EncryptedData, password)
+			for n := readUint32(); n > 0; n-- {
+				entry.chain = append(entry.chain, readCertificate())
+			}
+		default:
+			Fail("unknown keystore entry tag")
+		}
+		entries = append(entries, entry)
+	}
+	ExpectWithOffset(1, reader.Len()).To(BeZero())
+	return entries
+}
+
+// unprotectJKSKey reverses protectJKSKey and checks the plaintext digest
+func unprotectJKSKey(protected []byte, password string) []byte {
+	salt := protected[:sha1.Size]
+	encrypted := protected[sha1.Size : len(protected)-sha1.Size]
+	check := protected[len(protected)-sha1.Size:]
+
+	passwordBytes := jksPassword(password)
+	key := make([]byte, len(encrypted))
+	digest := salt
+	for offset := 0; offset < len(encrypted); offset += sha1.Size {
+		sum := sha1.Sum(append(append([]byte{}, passwordBytes...), digest...))
+		digest = sum[:]
+		for i := 0; i < sha1.Size && offset+i < len(encrypted); i++ {
+			key[offset+i] = encrypted[offset+i] ^ digest[i]
+		}
+	}
+	expected := sha1.Sum(append(append([]byte{}, passwordBytes...), key...))
+	ExpectWithOffset(2, check).To(Equal(expected[:]), "key protection digest")
+	return key
+}
+
+var _ = Describe("JKS encoding", func() {
+	It("should round-trip private key and trusted certificate entries", func() {
+		entries := []jksEntry{
+			{alias: "certificate", key: []byte("not really a PKCS#8 key, but longer than one digest"), chain: [][]byte{[]byte("leaf"), []byte("ca")}},
+			{alias: "ca-0", chain: [][]byte{[]byte("ca")}},
+		}
+
+		data, err := encodeJKS(rand.Reader, "s3cret-pässword", entries, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodeJKS(data, "s3cret-pässword")).To(Equal(entries))
+	})
+
+	It("should encode passwords as UTF-16", func() {
+		Expect(jksPassword("aé")).To(Equal([]byte{0x00, 'a', 0x00, 0xe9}))
+	})
+
+	It("should reject an entry without a certificate", func() {
+		_, err := encodeJKS(rand.Reader, "changeit", []jksEntry{{alias: "empty"}}, time.Now())
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// Key algorithms accepted in Spec.KeyAlgorithm
+const (
+	keyAlgorithmRSA     = "RSA"
+	keyAlgorithmECDSA   = "ECDSA"
+	keyAlgorithmEd25519 = "Ed25519"
+)
+
+// keySpec returns the key algorithm and size to generate for the certificate. The controller's
+// defaults apply to certificates that set neither, so a size meant for RSA is never paired with
+// a default algorithm
+func (r *CertificateReconciler) keySpec(cert *certv1alpha1.Certificate) (string, int32) {
+	if cert.Spec.KeyAlgorithm == "" && cert.Spec.KeySize == 0 {
+		return r.DefaultKeyAlgorithm, r.DefaultKeySize
+	}
+	return cert.Spec.KeyAlgorithm, cert.Spec.KeySize
+}
+
+// ValidateKeySpec rejects a key algorithm and size that can't be generated. Empty and zero select
+// RSA and the algorithm's default size
+func ValidateKeySpec(algorithm string, size int32) error {
+	switch algorithm {
+	case "", keyAlgorithmRSA:
+		if size != 0 && size != 2048 && size != 3072 && size != 4096 {
+			return fmt.Errorf("%w: unsupported RSA key size %d", ErrInvalidSpec, size)
+		}
+	case keyAlgorithmECDSA:
+		if size != 0 && size != 256 && size != 384 && size != 521 {
+			return fmt.Errorf("%w: unsupported ECDSA key size %d", ErrInvalidSpec, size)
+		}
+	case keyAlgorithmEd25519:
+	default:
+		return fmt.Errorf("%w: unsupported key algorithm %q", ErrInvalidSpec, algorithm)
+	}
+	return nil
+}
+
+// generatePrivateKey creates a private key of the algorithm and size from random and returns it
+// with its PEM encoding
+func generatePrivateKey(algorithm string, size int32, random io.Reader) (crypto.Signer, []byte, error) {
+	if err := ValidateKeySpec(algorithm, size); err != nil {
+		return nil, nil, err
+	}
+
+	switch algorithm {
+	case keyAlgorithmECDSA:
+		curve := elliptic.P256()
+		switch size {
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		}
+		key, err := ecdsa.GenerateKey(curve, random)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrKeyGeneration, err)
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrKeyGeneration, err)
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+
+	case keyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(random)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrKeyGeneration, err)
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrKeyGeneration, err)
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+
+	default:
+		if size == 0 {
+			size = 2048
+		}
+		key, err := rsa.GenerateKey(random, int(size))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrKeyGeneration, err)
+		}
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+		return key, keyPEM, nil
+	}
+}
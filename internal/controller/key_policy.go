@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+)
+
+// enforceKeyPolicy rejects a public key the controller's key policy forbids, whether the operator
+// generated it or it came from a CSR or an external key, so per-certificate settings can't weaken it
+func (r *CertificateReconciler) enforceKeyPolicy(publicKey crypto.PublicKey) error {
+	key, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil
+	}
+	if r.DisallowRSA {
+		return fmt.Errorf("%w: RSA keys are not allowed, use ECDSA or Ed25519", ErrPolicyViolation)
+	}
+	if size := key.N.BitLen(); size < int(r.MinRSAKeySize) {
+		return fmt.Errorf("%w: %d-bit RSA key is below the minimum of %d bits", ErrPolicyViolation, size, r.MinRSAKeySize)
+	}
+	return nil
+}
+
+// ValidateKeyPolicy rejects a default key algorithm and size the key policy would refuse to issue
+func ValidateKeyPolicy(algorithm string, size, minRSAKeySize int32, disallowRSA bool) error {
+	if algorithm != "" && algorithm != keyAlgorithmRSA {
+		return nil
+	}
+	if disallowRSA {
+		return fmt.Errorf("%w: the default key is RSA, which is not allowed", ErrPolicyViolation)
+	}
+	if size == 0 {
+		size = 2048
+	}
+	if size < minRSAKeySize {
+		return fmt.Errorf("%w: the default %d-bit RSA key is below the minimum of %d bits", ErrPolicyViolation, size, minRSAKeySize)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Key policy", func() {
+	It("should reject a 2048-bit RSA key when the minimum is 3072", func() {
+		cert := newTestCertificate("weak-key")
+		cert.Spec.KeyAlgorithm = keyAlgorithmRSA
+		cert.Spec.KeySize = 2048
+		r := newFakeReconciler(cert)
+		r.MinRSAKeySize = 3072
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("PolicyViolation"))
+		Expect(ready.Message).To(ContainSubstring("2048-bit RSA key is below the minimum of 3072 bits"))
+		err = r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should issue an RSA key meeting the minimum", func() {
+		cert := newTestCertificate("strong-key")
+		cert.Spec.KeyAlgorithm = keyAlgorithmRSA
+		cert.Spec.KeySize = 3072
+		r := newFakeReconciler(cert)
+		r.MinRSAKeySize = 3072
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+	})
+
+	It("should reject RSA entirely when disallowed but issue ECDSA", func() {
+		r := newFakeReconciler()
+		r.DisallowRSA = true
+
+		rsaCert := newTestCertificate("disallowed-rsa")
+		_, err := r.generateCertificate(ctx, rsaCert)
+		Expect(err).To(MatchError(ErrPolicyViolation))
+		Expect(err.Error()).To(ContainSubstring("RSA keys are not allowed"))
+
+		ecdsaCert := newTestCertificate("allowed-ecdsa")
+		ecdsaCert.Spec.KeyAlgorithm = keyAlgorithmECDSA
+		_, err = r.generateCertificate(ctx, ecdsaCert)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate the default key against the policy", func() {
+		Expect(ValidateKeyPolicy("", 0, 3072, false)).To(MatchError(ErrPolicyViolation))
+		Expect(ValidateKeyPolicy(keyAlgorithmRSA, 4096, 3072, false)).To(Succeed())
+		Expect(ValidateKeyPolicy(keyAlgorithmRSA, 4096, 0, true)).To(MatchError(ErrPolicyViolation))
+		Expect(ValidateKeyPolicy(keyAlgorithmECDSA, 256, 3072, true)).To(Succeed())
+	})
+})
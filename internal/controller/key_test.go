@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Key algorithms", func() {
+	It("should re-issue with the new key type when the algorithm changes", func() {
+		cert := newTestCertificate("algorithm-upgrade")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data["tls.crt"]).PublicKey).To(BeAssignableToTypeOf(&rsa.PublicKey{}))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(r.needsRenewal(updated)).To(BeFalse())
+		updated.Spec.KeyAlgorithm = keyAlgorithmECDSA
+		updated.Spec.KeySize = 384
+		Expect(r.Update(ctx, updated)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		publicKey, ok := parseCertificatePEM(secret.Data["tls.crt"]).PublicKey.(*ecdsa.PublicKey)
+		Expect(ok).To(BeTrue())
+		Expect(publicKey.Curve).To(Equal(elliptic.P384()))
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	It("should record the SPKI pin of the issued key", func() {
+		cert := newTestCertificate("spki-pin")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+
+		// Pin computed from the stored private key, independent of the issued certificate
+		pair, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+		Expect(err).NotTo(HaveOccurred())
+		spki, err := x509.MarshalPKIXPublicKey(pair.PrivateKey.(*rsa.PrivateKey).Public())
+		Expect(err).NotTo(HaveOccurred())
+		sum := sha256.Sum256(spki)
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.PublicKeyPin).To(Equal(base64.StdEncoding.EncodeToString(sum[:])))
+	})
+
+	It("should issue Ed25519 certificates without key encipherment", func() {
+		cert := newTestCertificate("ed25519")
+		cert.Spec.KeyAlgorithm = keyAlgorithmEd25519
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.PublicKey).To(BeAssignableToTypeOf(ed25519.PublicKey{}))
+		Expect(parsed.KeyUsage).To(Equal(x509.KeyUsageDigitalSignature))
+
+		secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": issued.certPEM, "tls.key": issued.keyPEM}}
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	DescribeTable("rejecting unsupported key sizes",
+		func(algorithm string, size int32) {
+			cert := newTestCertificate("bad-key-size")
+			cert.Spec.KeyAlgorithm = algorithm
+			cert.Spec.KeySize = size
+			_, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		},
+		Entry("RSA 1024", keyAlgorithmRSA, int32(1024)),
+		Entry("ECDSA 512", keyAlgorithmECDSA, int32(512)),
+	)
+
+	Describe("controller defaults", func() {
+		newDefaultingReconciler := func() *CertificateReconciler {
+			r := newFakeReconciler()
+			r.DefaultKeyAlgorithm = keyAlgorithmECDSA
+			r.DefaultKeySize = 384
+			return r
+		}
+
+		It("should use the configured default for a certificate without a key algorithm", func() {
+			issued, err := newDefaultingReconciler().generateCertificate(ctx, newTestCertificate("default-key"))
+			Expect(err).NotTo(HaveOccurred())
+			key, ok := parseCertificatePEM(issued.certPEM).PublicKey.(*ecdsa.PublicKey)
+			Expect(ok).To(BeTrue())
+			Expect(key.Curve).To(Equal(elliptic.P384()))
+		})
+
+		It("should let the certificate's algorithm override the default", func() {
+			cert := newTestCertificate("default-key-override")
+			cert.Spec.KeyAlgorithm = keyAlgorithmRSA
+
+			issued, err := newDefaultingReconciler().generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			key, ok := parseCertificatePEM(issued.certPEM).PublicKey.(*rsa.PublicKey)
+			Expect(ok).To(BeTrue())
+			Expect(key.N.BitLen()).To(Equal(2048))
+		})
+
+		It("should keep a certificate that only sets a key size on RSA", func() {
+			cert := newTestCertificate("default-key-size-only")
+			cert.Spec.KeySize = 3072
+
+			issued, err := newDefaultingReconciler().generateCertificate(ctx, cert)
+			Expect(err).NotTo(HaveOccurred())
+			key, ok := parseCertificatePEM(issued.certPEM).PublicKey.(*rsa.PublicKey)
+			Expect(ok).To(BeTrue())
+			Expect(key.N.BitLen()).To(Equal(3072))
+		})
+
+		DescribeTable("validating defaults",
+			func(algorithm string, size int32, valid bool) {
+				err := ValidateKeySpec(algorithm, size)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ErrInvalidSpec))
+				}
+			},
+			Entry("unset", "", int32(0), true),
+			Entry("ECDSA P-521", keyAlgorithmECDSA, int32(521), true),
+			Entry("Ed25519", keyAlgorithmEd25519, int32(0), true),
+			Entry("RSA size for ECDSA", keyAlgorithmECDSA, int32(2048), false),
+			Entry("unknown algorithm", "DSA", int32(0), false),
+		)
+	})
+})
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// kubeconfigSecretKey is the KubeconfigSecretName key holding the rendered kubeconfig
+const kubeconfigSecretKey = "kubeconfig"
+
+// renderKubeconfig returns a kubeconfig whose only context authenticates to the configured server
+// with issued
+func renderKubeconfig(cert *certv1alpha1.Certificate, issued *issuedCertificate) ([]byte, error) {
+	options := cert.Spec.Kubeconfig
+	if options == nil || options.Server == "" {
+		return nil, fmt.Errorf("%w: kubeconfig.server is required with kubeconfigSecretName", ErrInvalidSpec)
+	}
+	if len(issued.keyPEM) == 0 {
+		return nil, fmt.Errorf("%w: kubeconfigSecretName needs the private key, which the issuer doesn't write", ErrInvalidSpec)
+	}
+
+	caPEM := []byte(options.CABundle)
+	if len(caPEM) == 0 {
+		caPEM = issued.caPEM
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[cert.Name] = &clientcmdapi.Cluster{Server: options.Server, CertificateAuthorityData: caPEM}
+	config.AuthInfos[cert.Name] = &clientcmdapi.AuthInfo{ClientCertificateData: issued.certPEM, ClientKeyData: issued.keyPEM}
+	config.Contexts[cert.Name] = &clientcmdapi.Context{Cluster: cert.Name, AuthInfo: cert.Name}
+	config.CurrentContext = cert.Name
+	return clientcmd.Write(*config)
+}
+
+// writeKubeconfigSecret writes the kubeconfig embedding issued to KubeconfigSecretName
+func (r *CertificateReconciler) writeKubeconfigSecret(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) error {
+	name := cert.Spec.KubeconfigSecretName
+	if name == cert.Spec.SecretName || name == cert.Spec.ClientCertSecretName || name == cert.Spec.PublicSecretName {
+		return fmt.Errorf("%w: kubeconfigSecretName %s is already written by the certificate", ErrInvalidSpec, name)
+	}
+
+	kubeconfig, err := renderKubeconfig(cert, issued)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cert.Namespace},
+	}
+	err = r.createOrUpdateManagedSecret(ctx, cert, secret, func() error {
+		secret.Labels = map[string]string{
+			"app.kubernetes.io/managed-by": "certificate-operator",
+			"cert.example.com/certificate": cert.Name,
+		}
+		// Replace the data outright so a previous key can't linger
+		secret.Data = map[string][]byte{kubeconfigSecretKey: kubeconfig}
+		return ctrl.SetControllerReference(cert, secret, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig secret %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Kubeconfig secret", func() {
+	It("should render a kubeconfig embedding the certificate", func() {
+		cert := newTestCertificate("kubeconfig")
+		cert.Spec.KubeconfigSecretName = "kubeconfig-admin"
+		cert.Spec.Kubeconfig = &certv1alpha1.Kubeconfig{Server: "https://kubernetes.default.svc"}
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "kubeconfig-ca", Kind: issuerKindCA}
+		r := newFakeReconciler(cert, newKeyPairSecret("kubeconfig-ca", "Cluster CA", true, 365*24*time.Hour))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		tlsSecret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, tlsSecret)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "kubeconfig-admin", Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+
+		config, err := clientcmd.Load(secret.Data[kubeconfigSecretKey])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clientcmd.ConfirmUsable(*config, "")).To(Succeed())
+		current := config.Contexts[config.CurrentContext]
+		Expect(current).NotTo(BeNil())
+		Expect(config.Clusters[current.Cluster].Server).To(Equal("https://kubernetes.default.svc"))
+		Expect(config.Clusters[current.Cluster].CertificateAuthorityData).To(Equal(tlsSecret.Data["ca.crt"]))
+		user := config.AuthInfos[current.AuthInfo]
+		Expect(user.ClientCertificateData).To(Equal(tlsSecret.Data[corev1.TLSCertKey]))
+		Expect(user.ClientKeyData).To(Equal(tlsSecret.Data[corev1.TLSPrivateKeyKey]))
+	})
+
+	It("should embed the client certificate and the configured CA bundle", func() {
+		cert := newTestCertificate("kubeconfig-client")
+		cert.Spec.ClientCertSecretName = "kubeconfig-client-auth"
+		cert.Spec.KubeconfigSecretName = "kubeconfig-client-config"
+		cert.Spec.Kubeconfig = &certv1alpha1.Kubeconfig{Server: "https://api.example.com:6443", CABundle: "server CA"}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		clientSecret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "kubeconfig-client-auth", Namespace: "default"}, clientSecret)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "kubeconfig-client-config", Namespace: "default"}, secret)).To(Succeed())
+
+		config, err := clientcmd.Load(secret.Data[kubeconfigSecretKey])
+		Expect(err).NotTo(HaveOccurred())
+		current := config.Contexts[config.CurrentContext]
+		Expect(config.Clusters[current.Cluster].CertificateAuthorityData).To(Equal([]byte("server CA")))
+		user := config.AuthInfos[current.AuthInfo]
+		Expect(user.ClientCertificateData).To(Equal(clientSecret.Data[corev1.TLSCertKey]))
+		Expect(parseCertificatePEM(user.ClientCertificateData).ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}))
+	})
+
+	It("should not take over a secret it doesn't own", func() {
+		cert := newTestCertificate("kubeconfig-taken")
+		cert.Spec.KubeconfigSecretName = "someone-elses-kubeconfig"
+		cert.Spec.Kubeconfig = &certv1alpha1.Kubeconfig{Server: "https://kubernetes.default.svc"}
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "someone-elses-kubeconfig", Namespace: "default"},
+			Data:       map[string][]byte{kubeconfigSecretKey: []byte("theirs")},
+		}
+		r := newFakeReconciler(cert, existing)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ErrSecretNotManaged))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretNotManaged"))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(existing.Data))
+		Expect(secret.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should require a server", func() {
+		cert := newTestCertificate("kubeconfig-no-server")
+		cert.Spec.KubeconfigSecretName = "kubeconfig-no-server-config"
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(MatchError(ErrInvalidSpec))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("InvalidSpec"))
+	})
+})
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// issuerKindSelfSigned is the default issuer kind, signing a certificate with its own key
+const issuerKindSelfSigned = "SelfSigned"
+
+// issuerKindUnknown labels metrics of Certificates whose issuer kind the controller doesn't know
+const issuerKindUnknown = "Unknown"
+
+// metricIssuerKinds are the issuer_kind label values other than issuerKindUnknown. The kind is
+// user supplied, so it is never used as a label as is
+var metricIssuerKinds = []string{issuerKindSelfSigned, issuerKindCA, issuerKindExternalKey, issuerKindIssuer}
+
+// issuancesTotal counts issued certificates by the kind of issuer that signed them
+var issuancesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "certmanager_issuances_total",
+		Help: "Number of certificates issued, by issuer kind",
+	},
+	[]string{"issuer_kind"},
+)
+
+// ageAtRenewal observes how old a certificate was when it was replaced, to check renewals happen
+// at the intended fraction of the lifetime
+var ageAtRenewal = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "certmanager_certificate_age_at_renewal_seconds",
+		Help: "Age of certificates when they were renewed, by issuer kind",
+		// From an hour to five years, covering short-lived certificates through CAs
+		Buckets: []float64{
+			time.Hour.Seconds(),
+			(24 * time.Hour).Seconds(),
+			(7 * 24 * time.Hour).Seconds(),
+			(30 * 24 * time.Hour).Seconds(),
+			(60 * 24 * time.Hour).Seconds(),
+			(90 * 24 * time.Hour).Seconds(),
+			(180 * 24 * time.Hour).Seconds(),
+			(365 * 24 * time.Hour).Seconds(),
+			(2 * 365 * 24 * time.Hour).Seconds(),
+			(5 * 365 * 24 * time.Hour).Seconds(),
+		},
+	},
+	[]string{"issuer_kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(issuancesTotal, ageAtRenewal)
+}
+
+// issuerKind returns the issuer kind of cert, defaulting to SelfSigned
+func issuerKind(cert *certv1alpha1.Certificate) string {
+	if cert.Spec.IssuerRef.Kind == "" {
+		return issuerKindSelfSigned
+	}
+	return cert.Spec.IssuerRef.Kind
+}
+
+// metricIssuerKind returns the issuer_kind label of cert, bounding the label to the known kinds
+func metricIssuerKind(cert *certv1alpha1.Certificate) string {
+	if kind := issuerKind(cert); slices.Contains(metricIssuerKinds, kind) {
+		return kind
+	}
+	return issuerKindUnknown
+}
+
+// countIssuance records an issuance by cert's issuer in the issuance metrics
+func countIssuance(cert *certv1alpha1.Certificate) {
+	issuancesTotal.WithLabelValues(metricIssuerKind(cert)).Inc()
+}
+
+// observeRenewalAge records the age of the certificate a renewal issued at notBefore replaces.
+// Initial issuances have no previous certificate and aren't observed
+func observeRenewalAge(cert *certv1alpha1.Certificate, notBefore time.Time) {
+	if cert.Status.NotBefore == nil {
+		return
+	}
+	ageAtRenewal.WithLabelValues(metricIssuerKind(cert)).Observe(notBefore.Sub(cert.Status.NotBefore.Time).Seconds())
+}
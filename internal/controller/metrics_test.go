@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Issuance metrics", func() {
+	It("should count a self-signed issuance under the SelfSigned issuer kind", func() {
+		cert := newTestCertificate("metered")
+		r := newFakeReconciler(cert)
+		selfSigned := issuancesTotal.WithLabelValues(issuerKindSelfSigned)
+		ca := issuancesTotal.WithLabelValues(issuerKindCA)
+		before, beforeCA := testutil.ToFloat64(selfSigned), testutil.ToFloat64(ca)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		// Nothing is issued while the certificate is current
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testutil.ToFloat64(selfSigned)).To(Equal(before + 1))
+		Expect(testutil.ToFloat64(ca)).To(Equal(beforeCA))
+	})
+
+	It("should label arbitrary issuer kinds as Unknown", func() {
+		cert := newTestCertificate("metered-kind")
+		Expect(metricIssuerKind(cert)).To(Equal(issuerKindSelfSigned))
+		cert.Spec.IssuerRef.Kind = issuerKindCA
+		Expect(metricIssuerKind(cert)).To(Equal(issuerKindCA))
+		cert.Spec.IssuerRef.Kind = "tenant-chosen-kind-1234"
+		Expect(metricIssuerKind(cert)).To(Equal(issuerKindUnknown))
+	})
+
+	It("should observe the age of the renewed certificate", func() {
+		issuedAt := time.Now().Add(-60 * 24 * time.Hour)
+		cert := newTestCertificate("renewal-age")
+		cert.Status.NotBefore = &metav1.Time{Time: issuedAt}
+		cert.Status.NotAfter = &metav1.Time{Time: issuedAt.Add(90 * 24 * time.Hour)}
+		cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		cert.Status.SecretName = cert.Spec.SecretName
+		r := newFakeReconciler(cert)
+		histogram := ageAtRenewal.WithLabelValues(issuerKindSelfSigned)
+		beforeCount, beforeSum := histogramSample(histogram)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		count, sum := histogramSample(histogram)
+		Expect(count).To(Equal(beforeCount + 1))
+		Expect(sum - beforeSum).To(BeNumerically("~", (60 * 24 * time.Hour).Seconds(), time.Minute.Seconds()))
+	})
+
+	It("should not observe an initial issuance", func() {
+		cert := newTestCertificate("renewal-age-initial")
+		r := newFakeReconciler(cert)
+		histogram := ageAtRenewal.WithLabelValues(issuerKindSelfSigned)
+		beforeCount, _ := histogramSample(histogram)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		count, _ := histogramSample(histogram)
+		Expect(count).To(Equal(beforeCount))
+	})
+})
+
+// histogramSample returns the number and sum of the observations of a histogram
+func histogramSample(observer prometheus.Observer) (uint64, float64) {
+	metric := &dto.Metric{}
+	ExpectWithOffset(1, observer.(prometheus.Metric).Write(metric)).To(Succeed())
+	return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// gatewayGVK identifies Gateway API Gateways, which are handled as unstructured
+// objects so the operator doesn't depend on the Gateway API module
+var gatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+
+// updateNetworkingRefs wires the certificate secret into the referenced Ingress and Gateway
+func (r *CertificateReconciler) updateNetworkingRefs(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if cert.Spec.IngressRef != nil {
+		if err := r.updateIngressTLS(ctx, cert); err != nil {
+			return err
+		}
+	}
+
+	if cert.Spec.GatewayRef != nil {
+		if err := r.updateGatewayListeners(ctx, cert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateIngressTLS points the TLS block covering the certificate's hosts at the secret,
+// adding a TLS block when none covers them yet
+func (r *CertificateReconciler) updateIngressTLS(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	// The manager's RBAC only allows getting Ingresses, so they can't be read from the cache
+	ingress := &networkingv1.Ingress{}
+	key := types.NamespacedName{Name: cert.Spec.IngressRef.Name, Namespace: secretNamespace(cert)}
+	if err := r.apiReader().Get(ctx, key, ingress); err != nil {
+		return fmt.Errorf("failed to get ingress %s: %w", key.Name, err)
+	}
+
+	hosts := cert.Spec.DNSNames
+	if len(hosts) == 0 {
+		hosts = []string{cert.Spec.CommonName}
+	}
+
+	original := ingress.DeepCopy()
+	matched := false
+	for i := range ingress.Spec.TLS {
+		tls := &ingress.Spec.TLS[i]
+		if tls.SecretName == cert.Spec.SecretName || hostsOverlap(tls.Hosts, hosts) {
+			tls.SecretName = cert.Spec.SecretName
+			matched = true
+		}
+	}
+	if !matched {
+		ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      hosts,
+			SecretName: cert.Spec.SecretName,
+		})
+	}
+
+	// Refs are reconciled on every pass, so only a change is written
+	if equality.Semantic.DeepEqual(original.Spec.TLS, ingress.Spec.TLS) {
+		return nil
+	}
+	if err := r.Patch(ctx, ingress, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch ingress %s: %w", key.Name, err)
+	}
+	return nil
+}
+
+// updateGatewayListeners sets the certificateRefs of the selected Gateway listeners to the secret
+func (r *CertificateReconciler) updateGatewayListeners(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(gatewayGVK)
+	key := types.NamespacedName{Name: cert.Spec.GatewayRef.Name, Namespace: secretNamespace(cert)}
+	if err := r.apiReader().Get(ctx, key, gateway); err != nil {
+		return fmt.Errorf("failed to get gateway %s: %w", key.Name, err)
+	}
+
+	listeners, _, err := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	if err != nil {
+		return fmt.Errorf("failed to read gateway listeners: %w", err)
+	}
+
+	original := gateway.DeepCopy()
+	updated := 0
+	for i := range listeners {
+		listener, ok := listeners[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(listener, "name")
+		protocol, _, _ := unstructured.NestedString(listener, "protocol")
+
+		sectionName := cert.Spec.GatewayRef.SectionName
+		if sectionName != "" && name != sectionName {
+			continue
+		}
+		if sectionName == "" && protocol != "HTTPS" && protocol != "TLS" {
+			continue
+		}
+
+		refs := []interface{}{map[string]interface{}{
+			"kind": "Secret",
+			"name": cert.Spec.SecretName,
+		}}
+		if err := unstructured.SetNestedSlice(listener, refs, "tls", "certificateRefs"); err != nil {
+			return fmt.Errorf("failed to set listener certificateRefs: %w", err)
+		}
+		listeners[i] = listener
+		updated++
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("gateway %s has no matching TLS listener", key.Name)
+	}
+
+	if err := unstructured.SetNestedSlice(gateway.Object, listeners, "spec", "listeners"); err != nil {
+		return fmt.Errorf("failed to set gateway listeners: %w", err)
+	}
+	if equality.Semantic.DeepEqual(original.Object, gateway.Object) {
+		return nil
+	}
+	if err := r.Patch(ctx, gateway, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch gateway %s: %w", key.Name, err)
+	}
+	return nil
+}
+
+// hostsOverlap reports whether any host appears in both lists
+func hostsOverlap(a, b []string) bool {
+	for _, host := range a {
+		if slices.Contains(b, host) {
+			return true
+		}
+	}
+	return false
+}
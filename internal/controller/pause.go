@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typePausedCert reports whether reconciliation is paused by PausedUntil
+const typePausedCert = "Paused"
+
+// reconcilePause records the pause state in the Paused condition and reports whether the
+// certificate is still paused, and for how long
+func (r *CertificateReconciler) reconcilePause(ctx context.Context, cert *certv1alpha1.Certificate) (bool, time.Duration) {
+	var changed bool
+	var resumeIn time.Duration
+	switch {
+	case cert.Spec.PausedUntil != nil && time.Now().Before(cert.Spec.PausedUntil.Time):
+		resumeIn = time.Until(cert.Spec.PausedUntil.Time)
+		changed = meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typePausedCert,
+			Status:             metav1.ConditionTrue,
+			Reason:             "PausedUntilDeadline",
+			Message:            fmt.Sprintf("Reconciliation is paused until %s", cert.Spec.PausedUntil.UTC().Format(time.RFC3339)),
+			LastTransitionTime: metav1.Now(),
+		})
+	case meta.IsStatusConditionTrue(cert.Status.Conditions, typePausedCert):
+		// Resuming is recorded with the rest of this reconcile's status
+		meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typePausedCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Resumed",
+			Message:            "The pause deadline has passed",
+			LastTransitionTime: metav1.Now(),
+		})
+		return false, 0
+	default:
+		return false, 0
+	}
+
+	if changed {
+		if err := r.updateStatus(ctx, cert); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to update Certificate status")
+		}
+	}
+	return true, resumeIn
+}
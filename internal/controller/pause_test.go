@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Pausing reconciliation", func() {
+	It("should not issue until the deadline and requeue at it", func() {
+		cert := newTestCertificate("paused-future")
+		pausedUntil := metav1.NewTime(time.Now().Add(time.Hour))
+		cert.Spec.PausedUntil = &pausedUntil
+		r := newFakeReconciler(cert)
+		key := client.ObjectKeyFromObject(cert)
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, key, updated)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typePausedCert)).To(BeTrue())
+		Expect(updated.Status.SerialNumber).To(BeEmpty())
+		err = r.Get(ctx, client.ObjectKey{Namespace: cert.Namespace, Name: cert.Spec.SecretName}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should resume and issue once the deadline passes", func() {
+		cert := newTestCertificate("paused-resume")
+		pausedUntil := metav1.NewTime(time.Now().Add(time.Hour))
+		cert.Spec.PausedUntil = &pausedUntil
+		r := newFakeReconciler(cert)
+		key := client.ObjectKeyFromObject(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		// Move the deadline into the past as if the pause had run out
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, key, updated)).To(Succeed())
+		pausedUntil = metav1.NewTime(time.Now().Add(-time.Minute))
+		updated.Spec.PausedUntil = &pausedUntil
+		Expect(r.Update(ctx, updated)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, key, updated)).To(Succeed())
+		Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+		paused := meta.FindStatusCondition(updated.Status.Conditions, typePausedCert)
+		Expect(paused).NotTo(BeNil())
+		Expect(paused.Status).To(Equal(metav1.ConditionFalse))
+		Expect(paused.Reason).To(Equal("Resumed"))
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: cert.Namespace, Name: cert.Spec.SecretName}, secret)).To(Succeed())
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+})
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"bytes"
+
+	corev1 "k8s.io/api/core/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// pemLineEndingCRLF selects Windows line endings in PEMFormat; LF is the default
+const pemLineEndingCRLF = "CRLF"
+
+// applyPEMFormat rewrites the PEM data of secret to the certificate's PEMFormat. Secrets of
+// certificates without one keep the LF layout with a trailing newline that encoding/pem writes
+func applyPEMFormat(cert *certv1alpha1.Certificate, secret *corev1.Secret) {
+	if cert.Spec.PEMFormat == nil {
+		return
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, caSecretKey(cert)} {
+		if data, ok := secret.Data[key]; ok {
+			secret.Data[key] = formatPEM(data, cert.Spec.PEMFormat)
+		}
+	}
+}
+
+// formatPEM returns data with the line endings and trailing newline of format. Chains read from
+// CA secrets may mix layouts, so line endings are normalized before they are rewritten
+func formatPEM(data []byte, format *certv1alpha1.PEMFormat) []byte {
+	lineEnding := []byte("\n")
+	if format.LineEnding == pemLineEndingCRLF {
+		lineEnding = []byte("\r\n")
+	}
+
+	normalized := bytes.TrimRight(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), "\n")
+	formatted := bytes.ReplaceAll(normalized, []byte("\n"), lineEnding)
+	if !format.OmitTrailingNewline {
+		formatted = append(formatted, lineEnding...)
+	}
+	return formatted
+}
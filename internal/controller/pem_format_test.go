@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("PEM format", func() {
+	const block = "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"
+
+	DescribeTable("should lay out the PEM bytes",
+		func(format certv1alpha1.PEMFormat, want string) {
+			Expect(string(formatPEM([]byte(block+block), &format))).To(Equal(want))
+		},
+		Entry("LF with a trailing newline", certv1alpha1.PEMFormat{LineEnding: "LF"},
+			"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"),
+		Entry("LF without a trailing newline", certv1alpha1.PEMFormat{LineEnding: "LF", OmitTrailingNewline: true},
+			"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----"),
+		Entry("CRLF with a trailing newline", certv1alpha1.PEMFormat{LineEnding: "CRLF"},
+			"-----BEGIN CERTIFICATE-----\r\nAAAA\r\n-----END CERTIFICATE-----\r\n-----BEGIN CERTIFICATE-----\r\nAAAA\r\n-----END CERTIFICATE-----\r\n"),
+		Entry("CRLF without a trailing newline", certv1alpha1.PEMFormat{LineEnding: "CRLF", OmitTrailingNewline: true},
+			"-----BEGIN CERTIFICATE-----\r\nAAAA\r\n-----END CERTIFICATE-----\r\n-----BEGIN CERTIFICATE-----\r\nAAAA\r\n-----END CERTIFICATE-----"),
+	)
+
+	It("should normalize mixed line endings from a CA chain", func() {
+		mixed := []byte("-----BEGIN CERTIFICATE-----\r\nAAAA\n-----END CERTIFICATE-----\r\n\n")
+		Expect(string(formatPEM(mixed, &certv1alpha1.PEMFormat{}))).To(Equal(block))
+	})
+
+	It("should write CRLF PEM data the controller still reads back", func() {
+		cert := newTestCertificate("crlf")
+		cert.Spec.PEMFormat = &certv1alpha1.PEMFormat{LineEnding: "CRLF", OmitTrailingNewline: true}
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+			data := secret.Data[key]
+			Expect(bytes.Count(data, []byte("\n"))).To(Equal(bytes.Count(data, []byte("\r\n"))))
+			Expect(data).To(HaveSuffix("-----"))
+		}
+
+		// The stored certificate still parses, so the next reconcile doesn't re-issue it
+		leaf := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).SerialNumber).To(Equal(leaf.SerialNumber))
+	})
+
+	It("should leave the default layout untouched", func() {
+		cert := newTestCertificate("default-pem")
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data[corev1.TLSCertKey]).NotTo(ContainSubstring("\r"))
+		Expect(secret.Data[corev1.TLSCertKey]).To(HaveSuffix("-----\n"))
+	})
+})
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// certificatePEMHeaders describes a certificate in PEM block headers for tooling that reads them
+func certificatePEMHeaders(issuer string, serialNumber *big.Int, notAfter time.Time) map[string]string {
+	return map[string]string{
+		"Issuer":    issuer,
+		"Serial":    fmt.Sprintf("%x", serialNumber),
+		"Not-After": notAfter.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PEM headers", func() {
+	It("should describe the certificate in PEM headers when enabled", func() {
+		cert := newTestCertificate("pem-headers")
+		cert.Spec.PEMHeaders = true
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		block, _ := pem.Decode(issued.certPEM)
+		Expect(block).NotTo(BeNil())
+
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(block.Headers).To(Equal(map[string]string{
+			"Issuer":    parsed.Issuer.String(),
+			"Serial":    fmt.Sprintf("%x", parsed.SerialNumber),
+			"Not-After": parsed.NotAfter.UTC().Format(time.RFC3339),
+		}))
+		Expect(block.Headers["Serial"]).To(Equal(issued.serialNumber))
+
+		// Go's TLS stack still loads the key pair
+		_, err = tls.X509KeyPair(issued.certPEM, issued.keyPEM)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should omit PEM headers by default", func() {
+		issued, err := newFakeReconciler().generateCertificate(ctx, newTestCertificate("no-pem-headers"))
+		Expect(err).NotTo(HaveOccurred())
+		block, _ := pem.Decode(issued.certPEM)
+		Expect(block.Headers).To(BeEmpty())
+	})
+})
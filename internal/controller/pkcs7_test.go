@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// parsePKCS7Certificates returns the certificates carried by a certs-only PKCS#7 bundle
+func parsePKCS7Certificates(der []byte) []*x509.Certificate {
+	var info pkcs7ContentInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	ExpectWithOffset(1, rest).To(BeEmpty())
+	ExpectWithOffset(1, info.ContentType.Equal(oidPKCS7SignedData)).To(BeTrue())
+
+	var signedData pkcs7SignedData
+	_, err = asn1.Unmarshal(info.Content.Bytes, &signedData)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	ExpectWithOffset(1, signedData.ContentInfo.ContentType.Equal(oidPKCS7Data)).To(BeTrue())
+	ExpectWithOffset(1, signedData.SignerInfos.Bytes).To(BeEmpty())
+
+	certs, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return certs
+}
+
+var _ = Describe("PKCS#7 bundle", func() {
+	It("should bundle the leaf and its chain when enabled", func() {
+		ca := newKeyPairSecret("p7b-ca", "P7B Root CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("p7b")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		cert.Spec.IncludePKCS7 = true
+		r := newFakeReconciler(cert, ca)
+
+		issued, err := r.generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKey(pkcs7BundleKey))
+
+		certs := parsePKCS7Certificates(secret.Data[pkcs7BundleKey])
+		Expect(certs).To(HaveLen(2))
+		Expect(certs[0].Equal(parseCertificatePEM(issued.certPEM))).To(BeTrue())
+		Expect(certs[1].Equal(parseCertificatePEM(ca.Data[corev1.TLSCertKey]))).To(BeTrue())
+	})
+
+	It("should bundle a self-signed certificate on its own", func() {
+		cert := newTestCertificate("p7b-self-signed")
+		cert.Spec.IncludePKCS7 = true
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+
+		bundle, err := encodePKCS7(issued.certPEM)
+		Expect(err).NotTo(HaveOccurred())
+		certs := parsePKCS7Certificates(bundle)
+		Expect(certs).To(HaveLen(1))
+		Expect(certs[0].Subject.CommonName).To(Equal(cert.Spec.CommonName))
+	})
+
+	It("should omit the bundle by default", func() {
+		cert := newTestCertificate("no-p7b")
+		r := newFakeReconciler(cert)
+		issued, err := r.generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).NotTo(HaveKey(pkcs7BundleKey))
+	})
+
+	It("should reject input without certificates", func() {
+		_, err := encodePKCS7([]byte("not pem"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// profileKafkaTLS packages the secret for Kafka and ZooKeeper: the PEM files plus a JKS
+	// keystore and truststore
+	profileKafkaTLS = "KafkaTLS"
+
+	keystoreKey           = "keystore.jks"
+	truststoreKey         = "truststore.jks"
+	keystorePasswordKey   = "keystore.password"
+	truststorePasswordKey = "truststore.password"
+
+	// keystoreAlias is the alias of the private key entry; truststore entries are ca-0, ca-1, ...
+	keystoreAlias = "certificate"
+)
+
+// validateProfile rejects a profile that can't be built for the certificate
+func validateProfile(cert *certv1alpha1.Certificate) error {
+	switch cert.Spec.Profile {
+	case "":
+		return nil
+	case profileKafkaTLS:
+		if cert.Spec.CSRSecretRef != nil || cert.Spec.IssuerRef.Kind == issuerKindExternalKey {
+			return fmt.Errorf("%w: the %s profile needs a private key to put in the keystore", ErrInvalidSpec, profileKafkaTLS)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown profile %q", ErrInvalidSpec, cert.Spec.Profile)
+	}
+}
+
+// applyProfile adds the entries of the certificate's profile to the secret
+func (r *CertificateReconciler) applyProfile(ctx context.Context, cert *certv1alpha1.Certificate, secret *corev1.Secret, issued *issuedCertificate) error {
+	if cert.Spec.Profile != profileKafkaTLS {
+		return nil
+	}
+
+	password, err := r.keystorePassword(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace})
+	if err != nil {
+		return err
+	}
+	keystore, truststore, err := encodeKafkaStores(r.random(), password, issued, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode %s keystores: %w", profileKafkaTLS, err)
+	}
+	secret.Data[keystoreKey] = keystore
+	secret.Data[truststoreKey] = truststore
+	secret.Data[keystorePasswordKey] = []byte(password)
+	secret.Data[truststorePasswordKey] = []byte(password)
+	return nil
+}
+
+// keystorePassword returns the password of the keystores already in the secret, so renewals
+// don't invalidate consumer configuration, or a new random password
+func (r *CertificateReconciler) keystorePassword(ctx context.Context, key types.NamespacedName) (string, error) {
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, key, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if password := existing.Data[keystorePasswordKey]; len(password) > 0 {
+		return string(password), nil
+	}
+
+	random := make([]byte, 18)
+	if _, err := io.ReadFull(r.random(), random); err != nil {
+		return "", fmt.Errorf("failed to generate keystore password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// encodeKafkaStores builds the JKS keystore holding the key and chain, and the truststore
+// holding the CA chain, or the certificate itself when it is self-signed
+func encodeKafkaStores(random io.Reader, password string, issued *issuedCertificate, created time.Time) ([]byte, []byte, error) {
+	block, _ := pem.Decode(issued.keyPEM)
+	if block == nil {
+		return nil, nil, fmt.Errorf("no private key to put in the keystore")
+	}
+	key, err := parsePrivateKeyDER(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain := certificatesDER(issued.certPEM)
+	if len(chain) == 0 {
+		return nil, nil, fmt.Errorf("no certificate to put in the keystore")
+	}
+	keystore, err := encodeJKS(random, password, []jksEntry{{alias: keystoreAlias, key: pkcs8, chain: chain}}, created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trusted := certificatesDER(issued.caPEM)
+	if len(trusted) == 0 {
+		trusted = chain[:1]
+	}
+	entries := make([]jksEntry, 0, len(trusted))
+	for i, der := range trusted {
+		entries = append(entries, jksEntry{alias: fmt.Sprintf("ca-%d", i), chain: [][]byte{der}})
+	}
+	truststore, err := encodeJKS(random, password, entries, created)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keystore, truststore, nil
+}
+
+// parsePrivateKeyDER parses a PKCS#1, SEC 1 or PKCS#8 private key block
+func parsePrivateKeyDER(block *pem.Block) (any, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+}
+
+// certificatesDER returns the DER of every certificate in a PEM bundle, in order
+func certificatesDER(bundle []byte) [][]byte {
+	var ders [][]byte
+	for rest := bundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return ders
+		}
+		if block.Type == "CERTIFICATE" {
+			ders = append(ders, block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Kafka TLS profile", func() {
+	It("should write the PEM files, keystore and truststore to the secret", func() {
+		ca := newKeyPairSecret("kafka-ca", "Kafka Root CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("kafka")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		cert.Spec.Profile = profileKafkaTLS
+		r := newFakeReconciler(cert, ca)
+
+		issued, err := r.generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.createOrUpdateSecret(ctx, cert, issued)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKey(corev1.TLSCertKey))
+		Expect(secret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+		Expect(secret.Data).To(HaveKey("ca.crt"))
+		Expect(secret.Data).To(HaveKey(keystoreKey))
+		Expect(secret.Data).To(HaveKey(truststoreKey))
+		password := string(secret.Data[keystorePasswordKey])
+		Expect(password).NotTo(BeEmpty())
+		Expect(secret.Data).To(HaveKeyWithValue(truststorePasswordKey, []byte(password)))
+
+		keystore := decodeJKS(secret.Data[keystoreKey], password)
+		Expect(keystore).To(HaveLen(1))
+		Expect(keystore[0].alias).To(Equal(keystoreAlias))
+		keyBlock, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+		key, err := parsePrivateKeyDER(keyBlock)
+		Expect(err).NotTo(HaveOccurred())
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keystore[0].key).To(Equal(pkcs8))
+		leaf := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		Expect(keystore[0].chain[0]).To(Equal(leaf.Raw))
+
+		truststore := decodeJKS(secret.Data[truststoreKey], password)
+		Expect(truststore).To(HaveLen(1))
+		Expect(truststore[0].alias).To(Equal("ca-0"))
+		Expect(truststore[0].key).To(BeNil())
+		caBlock, _ := pem.Decode(ca.Data[corev1.TLSCertKey])
+		Expect(truststore[0].chain[0]).To(Equal(caBlock.Bytes))
+	})
+
+	It("should keep the keystore password across renewals", func() {
+		cert := newTestCertificate("kafka-renewal")
+		cert.Spec.Profile = profileKafkaTLS
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, first)).To(Succeed())
+
+		issued := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, issued)).To(Succeed())
+		issued.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, issued)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		renewed := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, renewed)).To(Succeed())
+		Expect(renewed.Data[corev1.TLSCertKey]).NotTo(Equal(first.Data[corev1.TLSCertKey]))
+		Expect(renewed.Data[keystorePasswordKey]).To(Equal(first.Data[keystorePasswordKey]))
+
+		// A self-signed certificate trusts itself
+		truststore := decodeJKS(renewed.Data[truststoreKey], string(renewed.Data[keystorePasswordKey]))
+		Expect(truststore).To(HaveLen(1))
+		Expect(truststore[0].chain[0]).To(Equal(parseCertificatePEM(renewed.Data[corev1.TLSCertKey]).Raw))
+	})
+
+	It("should reject the profile when there is no private key", func() {
+		cert := newTestCertificate("kafka-external")
+		cert.Spec.Profile = profileKafkaTLS
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "kms-key", Kind: issuerKindExternalKey}
+
+		_, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
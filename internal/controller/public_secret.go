@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// publicDERKey is the public secret key holding the leaf certificate in DER
+const publicDERKey = "tls.der"
+
+// writePublicSecret writes the certificate without its private key to PublicSecretName
+func (r *CertificateReconciler) writePublicSecret(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) error {
+	if cert.Spec.PublicSecretName == cert.Spec.SecretName {
+		return fmt.Errorf("%w: publicSecretName must differ from secretName", ErrInvalidSpec)
+	}
+
+	block, _ := pem.Decode(issued.certPEM)
+	if block == nil {
+		return fmt.Errorf("issued certificate is not PEM encoded")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.Spec.PublicSecretName,
+			Namespace: cert.Namespace,
+		},
+	}
+	return r.createOrUpdateManagedSecret(ctx, cert, secret, func() error {
+		secret.Labels = map[string]string{
+			"app.kubernetes.io/managed-by": "certificate-operator",
+			"cert.example.com/certificate": cert.Name,
+		}
+		// Replace the data outright so no key material can linger
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey: issued.certPEM,
+			publicDERKey:      block.Bytes,
+		}
+		if len(issued.caPEM) > 0 {
+			secret.Data[caSecretKey(cert)] = issued.caPEM
+		}
+		return ctrl.SetControllerReference(cert, secret, r.Scheme)
+	})
+}
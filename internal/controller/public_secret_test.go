@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Public secret", func() {
+	It("should write the certificate without key material", func() {
+		cert := newTestCertificate("public")
+		cert.Spec.PublicSecretName = "public-cert"
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "public-ca", Kind: issuerKindCA}
+		r := newFakeReconciler(cert, newKeyPairSecret("public-ca", "Public CA", true, 365*24*time.Hour))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		private := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "public-tls", Namespace: "default"}, private)).To(Succeed())
+		public := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "public-cert", Namespace: "default"}, public)).To(Succeed())
+
+		Expect(public.Data).To(HaveLen(3))
+		Expect(public.Data).NotTo(HaveKey(corev1.TLSPrivateKeyKey))
+		for _, value := range public.Data {
+			Expect(string(value)).NotTo(ContainSubstring("PRIVATE KEY"))
+		}
+		Expect(public.Data[corev1.TLSCertKey]).To(Equal(private.Data[corev1.TLSCertKey]))
+		Expect(public.Data["ca.crt"]).To(Equal(private.Data["ca.crt"]))
+
+		leaf, err := x509.ParseCertificate(public.Data[publicDERKey])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaf.Equal(parseCertificatePEM(private.Data[corev1.TLSCertKey]))).To(BeTrue())
+		Expect(public.OwnerReferences).To(HaveLen(1))
+		Expect(public.OwnerReferences[0].Name).To(Equal(cert.Name))
+	})
+
+	It("should publish a public secret added after issuance", func() {
+		cert := newTestCertificate("public-later")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Spec.PublicSecretName = "public-later-cert"
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		public := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "public-later-cert", Namespace: "default"}, public)).To(Succeed())
+		Expect(public.Data).To(HaveKey(corev1.TLSCertKey))
+	})
+
+	It("should not take over a secret it doesn't own", func() {
+		cert := newTestCertificate("public-taken")
+		cert.Spec.PublicSecretName = "someone-elses"
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "someone-elses", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		}
+		r := newFakeReconciler(cert, existing)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ErrSecretNotManaged))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretNotManaged"))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(existing.Data))
+		Expect(secret.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should reject reusing the private secret name", func() {
+		cert := newTestCertificate("public-same")
+		cert.Spec.PublicSecretName = cert.Spec.SecretName
+		err := newFakeReconciler().writePublicSecret(ctx, cert, &issuedCertificate{})
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+})
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// namespaceQuota counts issuance attempts per namespace over windows of issuanceBudgetWindow, so
+// one namespace can't exhaust an issuer shared with the others. Counts are kept in memory, so a
+// restarted controller starts fresh windows. The zero value is ready to use
+type namespaceQuota struct {
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+}
+
+// quotaWindow is a namespace's current counting window
+type quotaWindow struct {
+	start     time.Time
+	issuances int
+}
+
+// reserve counts an issuance attempt in namespace when it is under limit. Otherwise it reports
+// how long until the namespace's window resets. A limit of zero or less doesn't bound issuance
+func (q *namespaceQuota) reserve(namespace string, limit int, now time.Time) (bool, time.Duration) {
+	if limit <= 0 {
+		return true, 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.windows == nil {
+		q.windows = map[string]*quotaWindow{}
+	}
+	window, ok := q.windows[namespace]
+	if !ok || now.Sub(window.start) >= issuanceBudgetWindow {
+		window = &quotaWindow{start: now}
+		q.windows[namespace] = window
+	}
+
+	if window.issuances >= limit {
+		return false, window.start.Add(issuanceBudgetWindow).Sub(now)
+	}
+	window.issuances++
+	return true, 0
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace issuance quota", func() {
+	It("should defer issuance once the namespace used its quota", func() {
+		first := newTestCertificate("quota-first")
+		second := newTestCertificate("quota-second")
+		elsewhere := newTestCertificate("quota-elsewhere")
+		elsewhere.Namespace = "other"
+		r := newFakeReconciler(first, second, elsewhere)
+		r.NamespaceIssuanceQuota = 1
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(first)})
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(second)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", issuanceBudgetWindow))
+
+		secret := &corev1.Secret{}
+		err = r.Get(ctx, client.ObjectKey{Name: second.Spec.SecretName, Namespace: "default"}, secret)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(second), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("QuotaExceeded"))
+
+		// Other namespaces have their own quota
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(elsewhere)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKey{Name: elsewhere.Spec.SecretName, Namespace: "other"}, secret)).To(Succeed())
+	})
+
+	It("should reset the quota when the window ends", func() {
+		var quota namespaceQuota
+		start := time.Now()
+
+		allowed, _ := quota.reserve("default", 2, start)
+		Expect(allowed).To(BeTrue())
+		allowed, _ = quota.reserve("default", 2, start.Add(time.Minute))
+		Expect(allowed).To(BeTrue())
+		allowed, resetIn := quota.reserve("default", 2, start.Add(10*time.Minute))
+		Expect(allowed).To(BeFalse())
+		Expect(resetIn).To(Equal(50 * time.Minute))
+
+		allowed, _ = quota.reserve("default", 2, start.Add(issuanceBudgetWindow))
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should not limit issuance without a quota", func() {
+		var quota namespaceQuota
+		for range 100 {
+			allowed, _ := quota.reserve("default", 0, time.Now())
+			Expect(allowed).To(BeTrue())
+		}
+	})
+})
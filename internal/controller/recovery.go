@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// specHashAnnotation records on the secret the issuance spec hash its certificate was issued from
+const specHashAnnotation = "cert.example.com/spec-hash"
+
+// recoverIssuedCertificate returns the certificate stored by an earlier issuance whose status
+// update was lost, so it can be recorded instead of issuing a new key. It returns nil when the
+// secret doesn't hold such a certificate or the certificate is due for renewal anyway
+func (r *CertificateReconciler) recoverIssuedCertificate(ctx context.Context, cert *certv1alpha1.Certificate) *issuedCertificate {
+	logger := log.FromContext(ctx)
+
+	// A forced renewal must always issue
+	if _, ok := cert.Annotations[renewIfBeforeAnnotation]; ok {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey(cert), secret); err != nil {
+		return nil
+	}
+	if !managesSecret(cert, secret) || secret.Annotations[specHashAnnotation] != issuanceSpecHash(cert) {
+		return nil
+	}
+
+	certPEM := secret.Data[corev1.TLSCertKey]
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		logger.Error(err, "Failed to parse certificate in secret", "secret", secret.Name)
+		return nil
+	}
+
+	// Status already records this certificate, so it isn't a lost issuance
+	serialNumber := fmt.Sprintf("%x", leaf.SerialNumber)
+	if serialNumber == cert.Status.SerialNumber {
+		return nil
+	}
+	if time.Now().After(r.calculateRenewalTime(cert, leaf.NotAfter).Time) {
+		return nil
+	}
+
+	return &issuedCertificate{
+		certPEM:      certPEM,
+		keyPEM:       secret.Data[corev1.TLSPrivateKeyKey],
+		caPEM:        secret.Data[caSecretKey(cert)],
+		notBefore:    leaf.NotBefore,
+		notAfter:     leaf.NotAfter,
+		serialNumber: serialNumber,
+		publicKeyPin: publicKeyPin(leaf.RawSubjectPublicKeyInfo),
+	}
+}
+
+// verifyStoredSerial re-reads the secret after the write and checks it still holds the issued
+// certificate, so status never records a serial that differs from what consumers load. The cache
+// usually hasn't seen the write yet, so the secret is read from the API server
+func (r *CertificateReconciler) verifyStoredSerial(ctx context.Context, cert *certv1alpha1.Certificate, issued *issuedCertificate) error {
+	secret := &corev1.Secret{}
+	if err := r.apiReader().Get(ctx, secretKey(cert), secret); err != nil {
+		return fmt.Errorf("failed to re-read secret: %w", err)
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return fmt.Errorf("secret %s holds no PEM certificate", secret.Name)
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate in secret %s: %w", secret.Name, err)
+	}
+	if stored := fmt.Sprintf("%x", leaf.SerialNumber); stored != issued.serialNumber {
+		return fmt.Errorf("secret %s holds serial %s instead of issued serial %s", secret.Name, stored, issued.serialNumber)
+	}
+	return nil
+}
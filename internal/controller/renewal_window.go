@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"time"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// expiredRenewalImmediate renews an expired certificate without waiting for the renewal window
+	expiredRenewalImmediate = "Immediate"
+
+	// expiredRenewalWindow holds an expired certificate for the renewal window like any other renewal
+	expiredRenewalWindow = "Window"
+)
+
+// renewalHeld reports whether a due renewal of cert waits for its renewal window at now, and how
+// long until the window opens. Expired certificates are only held under the Window policy, and
+// certificates that would expire before the window opens aren't held at all. A window that can't
+// be parsed doesn't hold renewals
+func renewalHeld(cert *certv1alpha1.Certificate, now time.Time) (bool, time.Duration) {
+	window := cert.Spec.RenewalWindow
+	if window == nil {
+		return false, 0
+	}
+	expired := cert.Status.NotAfter != nil && now.After(cert.Status.NotAfter.Time)
+	if expired && cert.Spec.ExpiredRenewalPolicy != expiredRenewalWindow {
+		return false, 0
+	}
+
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, 0
+	}
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil || duration >= 24*time.Hour {
+		return false, 0
+	}
+
+	now = now.UTC()
+	opens := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	// Yesterday's window may still be open past midnight
+	if now.Before(opens) {
+		opens = opens.AddDate(0, 0, -1)
+	}
+	if now.Before(opens.Add(duration)) {
+		return false, 0
+	}
+	next := opens.AddDate(0, 0, 1)
+	// Waiting for the window would let the certificate lapse, which the window is meant to prevent
+	if !expired && cert.Status.NotAfter != nil && cert.Status.NotAfter.Time.Before(next) {
+		return false, 0
+	}
+	return true, next.Sub(now)
+}
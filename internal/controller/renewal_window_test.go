@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Renewal window", func() {
+	// expiredCertificate returns a certificate that expired an hour ago, with a renewal window
+	// opening in two hours
+	expiredCertificate := func(policy string) *certv1alpha1.Certificate {
+		cert := newTestCertificate("expired")
+		cert.Spec.ExpiredRenewalPolicy = policy
+		cert.Spec.RenewalWindow = &certv1alpha1.RenewalWindow{
+			Start:    time.Now().UTC().Add(2 * time.Hour).Format("15:04"),
+			Duration: "1h",
+		}
+		cert.Status.NotAfter = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		cert.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-31 * 24 * time.Hour)}
+		return cert
+	}
+
+	It("should renew an expired certificate immediately under the Immediate policy", func() {
+		r := newFakeReconciler()
+		cert := expiredCertificate(expiredRenewalImmediate)
+		Expect(r.needsRenewal(cert)).To(BeTrue())
+		Expect(r.getRequeueTime(ctx, cert)).To(Equal(time.Minute))
+	})
+
+	It("should renew an expired certificate immediately when no policy is set", func() {
+		Expect(newFakeReconciler().needsRenewal(expiredCertificate(""))).To(BeTrue())
+	})
+
+	It("should hold an expired certificate until the window opens under the Window policy", func() {
+		r := newFakeReconciler()
+		cert := expiredCertificate(expiredRenewalWindow)
+		Expect(r.needsRenewal(cert)).To(BeFalse())
+		Expect(r.getRequeueTime(ctx, cert)).To(BeNumerically("~", 2*time.Hour, time.Minute))
+	})
+
+	It("should renew an expired certificate under the Window policy while the window is open", func() {
+		cert := expiredCertificate(expiredRenewalWindow)
+		cert.Spec.RenewalWindow.Start = time.Now().UTC().Add(-30 * time.Minute).Format("15:04")
+		Expect(newFakeReconciler().needsRenewal(cert)).To(BeTrue())
+	})
+
+	It("should hold a due certificate that hasn't expired for the window under either policy", func() {
+		cert := expiredCertificate(expiredRenewalImmediate)
+		cert.Status.NotAfter = &metav1.Time{Time: time.Now().Add(24 * time.Hour)}
+		Expect(newFakeReconciler().needsRenewal(cert)).To(BeFalse())
+	})
+
+	It("should not hold a renewal when the certificate would expire before the window opens", func() {
+		r := newFakeReconciler()
+		cert := expiredCertificate(expiredRenewalWindow)
+		cert.Status.NotAfter = &metav1.Time{Time: time.Now().Add(time.Hour)}
+		Expect(r.needsRenewal(cert)).To(BeTrue())
+		held, _ := renewalHeld(cert, time.Now())
+		Expect(held).To(BeFalse())
+	})
+
+	DescribeTable("should open the window daily in UTC",
+		func(start, duration, now string, wantHeld bool, wantOpensIn time.Duration) {
+			cert := newTestCertificate("windowed")
+			cert.Spec.RenewalWindow = &certv1alpha1.RenewalWindow{Start: start, Duration: duration}
+			at, err := time.Parse(time.RFC3339, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			held, opensIn := renewalHeld(cert, at)
+			Expect(held).To(Equal(wantHeld))
+			Expect(opensIn).To(Equal(wantOpensIn))
+		},
+		Entry("before the window", "02:00", "2h", "2026-01-01T01:00:00Z", true, time.Hour),
+		Entry("inside the window", "02:00", "2h", "2026-01-01T03:00:00Z", false, time.Duration(0)),
+		Entry("after the window", "02:00", "2h", "2026-01-01T05:00:00Z", true, 21*time.Hour),
+		Entry("past midnight in a window opened the day before", "23:00", "3h", "2026-01-02T01:00:00Z", false, time.Duration(0)),
+		Entry("in another time zone", "02:00", "1h", "2026-01-01T03:30:00+02:00", true, 30*time.Minute),
+		Entry("with an unparseable window", "2am", "1h", "2026-01-01T05:00:00Z", false, time.Duration(0)),
+	)
+})
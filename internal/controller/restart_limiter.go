@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"sync"
+)
+
+// namespaceLimiter bounds concurrent work per namespace. Each namespace gets its own slots,
+// so a busy namespace queues behind itself without holding up others. The zero value is ready to use
+type namespaceLimiter struct {
+	mu    sync.Mutex
+	inUse map[string]int
+}
+
+// tryAcquire takes a slot in the namespace without waiting, and returns the function releasing
+// it. It reports false when every slot is taken, so the caller requeues instead of parking a
+// reconcile worker. A limit of zero or less doesn't bound concurrency
+func (l *namespaceLimiter) tryAcquire(namespace string, limit int) (func(), bool) {
+	if limit <= 0 {
+		return func() {}, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inUse == nil {
+		l.inUse = map[string]int{}
+	}
+	if l.inUse[namespace] >= limit {
+		return nil, false
+	}
+	l.inUse[namespace]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.inUse[namespace]--; l.inUse[namespace] <= 0 {
+				delete(l.inUse, namespace)
+			}
+		})
+	}, true
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Per-namespace restart limit", func() {
+	// restartConcurrently restarts the consumers of count certificates in parallel and
+	// returns the highest number of deployment updates in flight at once. Restarts over the
+	// limit are deferred rather than waited for
+	restartConcurrently := func(count, limit int) int32 {
+		var objs []client.Object
+		var certs []*certv1alpha1.Certificate
+		for i := range count {
+			cert := newTestCertificate(fmt.Sprintf("restart-%d", i))
+			certs = append(certs, cert)
+			objs = append(objs, cert)
+			objs = append(objs, newConsumerDeployment(fmt.Sprintf("consumer-%d", i), cert.Spec.SecretName))
+		}
+
+		r := newFakeReconciler(objs...)
+		r.MaxRestartsPerNamespace = limit
+		var inFlight, peak atomic.Int32
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					current := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						seen := peak.Load()
+						if current <= seen || peak.CompareAndSwap(seen, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		})
+
+		var wg sync.WaitGroup
+		for _, cert := range certs {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+			}()
+		}
+		wg.Wait()
+		return peak.Load()
+	}
+
+	It("should not exceed the configured parallelism within a namespace", func() {
+		Expect(restartConcurrently(6, 2)).To(BeNumerically("<=", 2))
+	})
+
+	It("should serialize restarts with a limit of one", func() {
+		Expect(restartConcurrently(4, 1)).To(Equal(int32(1)))
+	})
+
+	It("should not hold up other namespaces", func() {
+		var limiter namespaceLimiter
+		release, ok := limiter.tryAcquire("busy", 1)
+		Expect(ok).To(BeTrue())
+
+		otherRelease, ok := limiter.tryAcquire("quiet", 1)
+		Expect(ok).To(BeTrue())
+		otherRelease()
+
+		_, ok = limiter.tryAcquire("busy", 1)
+		Expect(ok).To(BeFalse())
+		release()
+		release()
+		_, ok = limiter.tryAcquire("busy", 1)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should defer a restart over the limit and retry it", func() {
+		cert := newTestCertificate("restart-deferred")
+		cert.Spec.RestartDeployments = true
+		// Already restarted for an earlier serial, so only the deferred restart rolls it
+		deploy := newConsumerDeployment("deferred-consumer", cert.Spec.SecretName)
+		deploy.Spec.Template.Annotations = map[string]string{consumerSerialAnnotation: "earlier"}
+		r := newFakeReconciler(cert, deploy)
+		r.MaxRestartsPerNamespace = 1
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		release, ok := r.restartLimiter.tryAcquire("default", 1)
+		Expect(ok).To(BeTrue())
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(restartRetryDelay))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeRestartPendingCert)).To(BeTrue())
+		current := &appsv1.Deployment{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), current)).To(Succeed())
+		Expect(current.Spec.Template.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, "earlier"))
+
+		release()
+		result, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", restartRetryDelay))
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, typeRestartPendingCert)).To(BeTrue())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), current)).To(Succeed())
+		Expect(current.Spec.Template.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, updated.Status.SerialNumber))
+	})
+})
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"time"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// restartLifetimeGained reports whether a certificate expiring at notAfter extends the recorded
+// one by at least RestartMinLifetimeGain. Without a threshold, or a previous certificate, any
+// issuance counts. An unparseable threshold is ignored like other invalid durations
+func restartLifetimeGained(cert *certv1alpha1.Certificate, notAfter time.Time) bool {
+	if cert.Spec.RestartMinLifetimeGain == "" || cert.Status.NotAfter == nil {
+		return true
+	}
+	threshold, err := time.ParseDuration(cert.Spec.RestartMinLifetimeGain)
+	if err != nil {
+		return true
+	}
+	return notAfter.Sub(cert.Status.NotAfter.Time) >= threshold
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Minimum lifetime gain before restart", func() {
+	var r *CertificateReconciler
+	var cert *certv1alpha1.Certificate
+
+	// reissue makes the certificate due with the given recorded expiry and reconciles it, returning
+	// the serial the consumer was last restarted for
+	reissue := func(recordedNotAfter time.Time) string {
+		updated := &certv1alpha1.Certificate{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		updated.Status.NotAfter = &metav1.Time{Time: recordedNotAfter}
+		updated.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		ExpectWithOffset(1, r.Status().Update(ctx, updated)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		deploy := &appsv1.Deployment{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: "api", Namespace: "default"}, deploy)).To(Succeed())
+		return deploy.Spec.Template.Annotations[consumerSerialAnnotation]
+	}
+
+	BeforeEach(func() {
+		cert = newTestCertificate("lifetime-gain")
+		cert.Spec.RestartDeployments = true
+		cert.Spec.RestartMinLifetimeGain = "24h"
+		r = newFakeReconciler(cert, newConsumerDeployment("api", cert.Spec.SecretName))
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should restart on the first issuance", func() {
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Status.RestartedWorkloads).To(ConsistOf("api"))
+	})
+
+	It("should skip the restart when a re-issue barely extends the lifetime", func() {
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		firstSerial := updated.Status.SerialNumber
+
+		restartedFor := reissue(updated.Status.NotAfter.Add(-time.Hour))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Status.SerialNumber).NotTo(Equal(firstSerial))
+		Expect(restartedFor).To(Equal(firstSerial))
+	})
+
+	It("should restart when the re-issue extends the lifetime by at least the threshold", func() {
+		restartedFor := reissue(time.Now().Add(time.Hour))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(restartedFor).To(Equal(updated.Status.SerialNumber))
+	})
+
+	It("should restart on every re-issue without a threshold", func() {
+		Expect(restartLifetimeGained(newTestCertificate("no-threshold"), time.Now())).To(BeTrue())
+	})
+})
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	// restartStrategyRollingAnnotation rolls deployments by annotating their pod template
+	restartStrategyRollingAnnotation = "RollingAnnotation"
+	// restartStrategyDeletePods evicts the pods mounting the secret directly
+	restartStrategyDeletePods = "DeletePods"
+)
+
+// Annotations on a deployment whose pods are being evicted, recording the serial they are evicted
+// for and when that started. Pods created since then already load that certificate
+const (
+	evictingSerialAnnotation = "cert.example.com/evicting-for-serial"
+	evictingSinceAnnotation  = "cert.example.com/evicting-since"
+)
+
+// restartedAtAnnotation records on a deployment's pod template when the operator last rolled it.
+// Deployments rolled before consumerSerialAnnotation existed carry only this one
+const restartedAtAnnotation = "cert.example.com/restartedAt"
+
+// restartDeployment restarts the pods of a deployment consuming the certificate's secret with
+// the certificate's restart strategy, and records the serial they were restarted for. It reports
+// whether the restart is still in progress and needs another call once the deployment settles
+func (r *CertificateReconciler) restartDeployment(ctx context.Context, cert *certv1alpha1.Certificate, deploy *appsv1.Deployment) (bool, error) {
+	if cert.Spec.RestartStrategy != restartStrategyDeletePods {
+		if deploy.Spec.Template.Annotations == nil {
+			deploy.Spec.Template.Annotations = make(map[string]string)
+		}
+		deploy.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
+		deploy.Spec.Template.Annotations[consumerSerialAnnotation] = cert.Status.SerialNumber
+		return false, r.Update(ctx, deploy)
+	}
+
+	if deploy.Annotations == nil {
+		deploy.Annotations = make(map[string]string)
+	}
+	if deploy.Annotations[evictingSerialAnnotation] != cert.Status.SerialNumber {
+		deploy.Annotations[evictingSerialAnnotation] = cert.Status.SerialNumber
+		deploy.Annotations[evictingSinceAnnotation] = time.Now().Format(time.RFC3339)
+		if err := r.Update(ctx, deploy); err != nil {
+			return false, err
+		}
+	}
+	since, err := time.Parse(time.RFC3339, deploy.Annotations[evictingSinceAnnotation])
+	if err != nil {
+		return false, fmt.Errorf("invalid %s annotation on deployment %s: %w", evictingSinceAnnotation, deploy.Name, err)
+	}
+
+	pending, err := r.evictConsumerPod(ctx, cert, deploy, since)
+	if err != nil || pending {
+		return pending, err
+	}
+	// Changing the pod template would roll the deployment again, so the serial goes on the
+	// deployment itself. It is only recorded once every pod is replaced, so a deployment left half
+	// restarted is picked up again as a new consumer
+	delete(deploy.Annotations, evictingSerialAnnotation)
+	delete(deploy.Annotations, evictingSinceAnnotation)
+	deploy.Annotations[consumerSerialAnnotation] = cert.Status.SerialNumber
+	return false, r.Update(ctx, deploy)
+}
+
+// evictConsumerPod evicts one of the deployment's pods that mount the certificate's secret and
+// were created before since, and reports whether any are left. No ready pod is evicted until every
+// replica is ready, so each replacement is up before the next pod goes, and an eviction refused
+// by a PodDisruptionBudget is left for a later call rather than waited out
+func (r *CertificateReconciler) evictConsumerPod(ctx context.Context, cert *certv1alpha1.Certificate, deploy *appsv1.Deployment, since time.Time) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector on deployment %s: %w", deploy.Name, err)
+	}
+	// Read pods uncached, so the operator doesn't watch every pod in the cluster and sees the
+	// replacements of the pods it just evicted
+	pods := &corev1.PodList{}
+	if err := r.apiReader().List(ctx, pods, client.InNamespace(deploy.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, fmt.Errorf("failed to list pods of deployment %s: %w", deploy.Name, err)
+	}
+
+	secretName := consumerSecretName(cert)
+	var stale *corev1.Pod
+	ready := int32(0)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil {
+			return true, nil
+		}
+		if podReady(pod) {
+			ready++
+		}
+		if stale == nil && !pod.CreationTimestamp.After(since) && podSpecUsesSecret(&pod.Spec, secretName) {
+			stale = pod
+		}
+	}
+	if stale == nil {
+		return false, nil
+	}
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	// Evicting a pod that isn't ready costs no availability, e.g. one stuck crash-looping
+	if ready < replicas && podReady(stale) {
+		return true, nil
+	}
+
+	log.FromContext(ctx).Info("Evicting pod", "deployment", deploy.Name, "pod", stale.Name)
+	err = r.SubResource("eviction").Create(ctx, stale, &policyv1.Eviction{})
+	switch {
+	case errors.IsTooManyRequests(err):
+		log.FromContext(ctx).Info("Eviction refused by a PodDisruptionBudget", "deployment", deploy.Name, "pod", stale.Name)
+	case err != nil && !errors.IsNotFound(err):
+		return false, fmt.Errorf("failed to evict pod %s of deployment %s: %w", stale.Name, deploy.Name, err)
+	}
+	return true, nil
+}
+
+// podReady reports whether the pod's Ready condition is true
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podSpecUsesSecret checks if a pod spec mounts or references a specific secret
+func podSpecUsesSecret(spec *corev1.PodSpec, secretName string) bool {
+	// Check volumes
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == secretName {
+			return true
+		}
+	}
+
+	// Check environment variables from secrets
+	for _, container := range spec.Containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
+				return true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				if env.ValueFrom.SecretKeyRef.Name == secretName {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
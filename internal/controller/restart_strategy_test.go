@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Restart strategy", func() {
+	secretVolume := func(secretName string) []corev1.Volume {
+		return []corev1.Volume{{
+			Name:         "tls",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+		}}
+	}
+	pod := func(name, app, secretName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{Volumes: secretVolume(secretName)},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			}},
+		}
+	}
+	// replacement creates a ready pod the way the deployment's ReplicaSet does after an eviction
+	replacement := func(r *CertificateReconciler, name, app, secretName string) {
+		replaced := pod(name, app, secretName)
+		replaced.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Minute))
+		ExpectWithOffset(1, r.Create(ctx, replaced)).To(Succeed())
+	}
+	podExists := func(r *CertificateReconciler, name string) bool {
+		err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &corev1.Pod{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return true
+	}
+
+	It("should roll deployments by annotating the pod template by default", func() {
+		cert := newTestCertificate("rolling")
+		cert.Status.SerialNumber = "01"
+		deploy := newConsumerDeployment("rolling-web", cert.Spec.SecretName)
+		r := newFakeReconciler(cert, deploy, pod("rolling-web-1", "rolling-web", cert.Spec.SecretName))
+
+		Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+		Expect(deploy.Spec.Template.Annotations).To(HaveKey("cert.example.com/restartedAt"))
+		Expect(deploy.Spec.Template.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, "01"))
+		Expect(podExists(r, "rolling-web-1")).To(BeTrue())
+		Expect(cert.Status.RestartedWorkloads).To(ConsistOf("rolling-web"))
+	})
+
+	It("should evict the deployment's pods mounting the secret one at a time", func() {
+		cert := newTestCertificate("delete-pods")
+		cert.Spec.RestartDeployments = true
+		cert.Spec.RestartStrategy = restartStrategyDeletePods
+		cert.Status.SerialNumber = "02"
+		deploy := newConsumerDeployment("delete-pods-web", cert.Spec.SecretName)
+		deploy.Spec.Replicas = ptr.To(int32(3))
+		r := newFakeReconciler(cert, deploy,
+			pod("delete-pods-web-1", "delete-pods-web", cert.Spec.SecretName),
+			pod("delete-pods-web-2", "delete-pods-web", cert.Spec.SecretName),
+			// Selected by the deployment but not using the secret
+			pod("delete-pods-web-sidecar", "delete-pods-web", "other-tls"),
+			// Using the secret but owned by something else
+			pod("delete-pods-job", "job", cert.Spec.SecretName),
+		)
+
+		Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+		Expect(podExists(r, "delete-pods-web-1")).To(BeFalse())
+		Expect(podExists(r, "delete-pods-web-2")).To(BeTrue())
+		Expect(restartPending(cert)).To(BeTrue())
+		Expect(meta.FindStatusCondition(cert.Status.Conditions, typeRestartPendingCert).Reason).To(Equal("EvictionInProgress"))
+
+		By("waiting for the replacement to become ready")
+		Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+		Expect(podExists(r, "delete-pods-web-2")).To(BeTrue())
+
+		replacement(r, "delete-pods-web-3", "delete-pods-web", cert.Spec.SecretName)
+		Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+		Expect(podExists(r, "delete-pods-web-2")).To(BeFalse())
+		Expect(restartPending(cert)).To(BeTrue())
+
+		replacement(r, "delete-pods-web-4", "delete-pods-web", cert.Spec.SecretName)
+		Expect(r.restartDeployments(ctx, cert)).To(Succeed())
+		Expect(restartPending(cert)).To(BeFalse())
+		Expect(podExists(r, "delete-pods-web-3")).To(BeTrue())
+		Expect(podExists(r, "delete-pods-web-4")).To(BeTrue())
+		Expect(podExists(r, "delete-pods-web-sidecar")).To(BeTrue())
+		Expect(podExists(r, "delete-pods-job")).To(BeTrue())
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+		Expect(deploy.Spec.Template.Annotations).To(BeEmpty())
+		Expect(deploy.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, "02"))
+		Expect(deploy.Annotations).NotTo(HaveKey(evictingSinceAnnotation))
+		Expect(cert.Status.RestartedWorkloads).To(ConsistOf("delete-pods-web"))
+
+		// A deployment restarted this way isn't a new consumer
+		Expect(r.isNewConsumer(deploy, cert)).To(BeFalse())
+	})
+
+	It("should evict a pod that isn't ready without waiting for it", func() {
+		cert := newTestCertificate("delete-pods-unready")
+		cert.Spec.RestartStrategy = restartStrategyDeletePods
+		cert.Status.SerialNumber = "05"
+		deploy := newConsumerDeployment("unready-web", cert.Spec.SecretName)
+		crashing := pod("unready-web-1", "unready-web", cert.Spec.SecretName)
+		crashing.Status.Conditions[0].Status = corev1.ConditionFalse
+		r := newFakeReconciler(cert, deploy, crashing)
+
+		pending, err := r.restartDeployment(ctx, cert, deploy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(BeTrue())
+		Expect(podExists(r, "unready-web-1")).To(BeFalse())
+	})
+
+	Context("with a PodDisruptionBudget", func() {
+		// pdbClient refuses evictions while budget is exhausted, the way the API server does when
+		// a PodDisruptionBudget allows no disruption
+		pdbClient := func(r *CertificateReconciler, refusals int) *[]string {
+			var evicted []string
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					if subResourceName == "eviction" {
+						if refusals > 0 {
+							refusals--
+							return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+						}
+						evicted = append(evicted, obj.GetName())
+					}
+					return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+				},
+			})
+			return &evicted
+		}
+
+		It("should leave a refused eviction for a later reconcile instead of waiting", func() {
+			cert := newTestCertificate("pdb")
+			cert.Spec.RestartStrategy = restartStrategyDeletePods
+			cert.Status.SerialNumber = "03"
+			deploy := newConsumerDeployment("pdb-web", cert.Spec.SecretName)
+			r := newFakeReconciler(cert, deploy, pod("pdb-web-1", "pdb-web", cert.Spec.SecretName))
+			evicted := pdbClient(r, 1)
+
+			pending, err := r.restartDeployment(ctx, cert, deploy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			Expect(*evicted).To(BeEmpty())
+			Expect(podExists(r, "pdb-web-1")).To(BeTrue())
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+			Expect(deploy.Annotations).NotTo(HaveKey(consumerSerialAnnotation))
+			Expect(r.isNewConsumer(deploy, cert)).To(BeTrue())
+
+			By("retrying once the budget allows it")
+			pending, err = r.restartDeployment(ctx, cert, deploy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeTrue())
+			Expect(*evicted).To(ConsistOf("pdb-web-1"))
+
+			replacement(r, "pdb-web-2", "pdb-web", cert.Spec.SecretName)
+			pending, err = r.restartDeployment(ctx, cert, deploy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeFalse())
+			Expect(deploy.Annotations).To(HaveKeyWithValue(consumerSerialAnnotation, "03"))
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// ErrInvalidIPAddress means an entry of IPAddresses is not an IP address
+var ErrInvalidIPAddress = errors.New("invalid IP address")
+
+// validateIPAddresses rejects a spec with IP addresses that don't parse, which would otherwise be
+// left out of the certificate
+func validateIPAddresses(cert *certv1alpha1.Certificate) error {
+	var invalid []string
+	for _, ipStr := range cert.Spec.IPAddresses {
+		if net.ParseIP(ipStr) == nil {
+			invalid = append(invalid, strconv.Quote(ipStr))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w in ipAddresses: %s", ErrInvalidIPAddress, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// certificateIPAddresses parses the spec's IP addresses, which validateIPAddresses checked
+func certificateIPAddresses(cert *certv1alpha1.Certificate) []net.IP {
+	var ipAddresses []net.IP
+	for _, ipStr := range cert.Spec.IPAddresses {
+		if ip := net.ParseIP(ipStr); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		}
+	}
+	return ipAddresses
+}
+
+// normalizeSANs de-duplicates and sorts the template's SANs so the same names always produce
+// the same certificate. DNS names compare case-insensitively and are stored lower-cased
+func normalizeSANs(template *x509.Certificate) {
+	dnsNames := make([]string, 0, len(template.DNSNames))
+	for _, name := range template.DNSNames {
+		dnsNames = append(dnsNames, strings.ToLower(name))
+	}
+	slices.Sort(dnsNames)
+	template.DNSNames = slices.Compact(dnsNames)
+
+	ips := make([]net.IP, 0, len(template.IPAddresses))
+	for _, ip := range template.IPAddresses {
+		// Compare IPv4 addresses by their 16-byte form so both encodings collapse into one
+		ips = append(ips, ip.To16())
+	}
+	slices.SortFunc(ips, func(a, b net.IP) int { return bytes.Compare(a, b) })
+	ips = slices.CompactFunc(ips, net.IP.Equal)
+	for i, ip := range ips {
+		if v4 := ip.To4(); v4 != nil {
+			ips[i] = v4
+		}
+	}
+	template.IPAddresses = ips
+}
+
+// storedSANsMismatch reports whether the certificate stored in the secret has different SANs
+// than the spec asks for. It backs up the spec hash by checking what consumers actually load
+func (r *CertificateReconciler) storedSANsMismatch(ctx context.Context, cert *certv1alpha1.Certificate) bool {
+	// CSR names are added to the spec's, so the stored set legitimately differs
+	if cert.Spec.CSRSecretRef != nil || cert.Status.SerialNumber == "" {
+		return false
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey(cert), secret); err != nil {
+		return false
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return false
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	if !sansMismatch(cert, leaf) {
+		return false
+	}
+	log.FromContext(ctx).Info("Stored certificate SANs differ from the spec",
+		"dnsNames", leaf.DNSNames, "wantDNSNames", certificateDNSNames(cert))
+	return true
+}
+
+// sansMismatch reports whether leaf has different SANs than the spec asks for
+func sansMismatch(cert *certv1alpha1.Certificate, leaf *x509.Certificate) bool {
+	want := x509.Certificate{DNSNames: certificateDNSNames(cert), IPAddresses: certificateIPAddresses(cert)}
+	normalizeSANs(&want)
+	stored := x509.Certificate{DNSNames: leaf.DNSNames, IPAddresses: leaf.IPAddresses}
+	normalizeSANs(&stored)
+	return !slices.Equal(want.DNSNames, stored.DNSNames) || !slices.EqualFunc(want.IPAddresses, stored.IPAddresses, net.IP.Equal)
+}
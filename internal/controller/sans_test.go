@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("SAN normalization", func() {
+	It("should de-duplicate and sort DNS names and IP addresses", func() {
+		cert := newTestCertificate("sans")
+		cert.Spec.DNSNames = []string{"b.example.com", "a.example.com", "B.example.com", "a.example.com"}
+		cert.Spec.IPAddresses = []string{"10.0.0.2", "::1", "10.0.0.1", "::ffff:10.0.0.2", "10.0.0.1"}
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		parsed := parseCertificatePEM(issued.certPEM)
+
+		Expect(parsed.DNSNames).To(Equal([]string{"a.example.com", "b.example.com"}))
+		Expect(parsed.IPAddresses).To(HaveLen(3))
+		Expect(parsed.IPAddresses[0].Equal(net.ParseIP("::1"))).To(BeTrue())
+		Expect(parsed.IPAddresses[1].Equal(net.ParseIP("10.0.0.1"))).To(BeTrue())
+		Expect(parsed.IPAddresses[2].Equal(net.ParseIP("10.0.0.2"))).To(BeTrue())
+	})
+
+	It("should produce the same SANs regardless of input order", func() {
+		first := newTestCertificate("sans-first")
+		first.Spec.DNSNames = []string{"x.example.com", "y.example.com", "z.example.com"}
+		first.Spec.IPAddresses = []string{"192.168.0.1", "10.0.0.1"}
+		second := newTestCertificate("sans-second")
+		second.Spec.DNSNames = []string{"z.example.com", "x.example.com", "y.example.com", "x.example.com"}
+		second.Spec.IPAddresses = []string{"10.0.0.1", "192.168.0.1"}
+
+		r := newFakeReconciler()
+		firstIssued, err := r.generateCertificate(ctx, first)
+		Expect(err).NotTo(HaveOccurred())
+		secondIssued, err := r.generateCertificate(ctx, second)
+		Expect(err).NotTo(HaveOccurred())
+
+		firstParsed, secondParsed := parseCertificatePEM(firstIssued.certPEM), parseCertificatePEM(secondIssued.certPEM)
+		Expect(secondParsed.DNSNames).To(Equal(firstParsed.DNSNames))
+		Expect(secondParsed.IPAddresses).To(Equal(firstParsed.IPAddresses))
+	})
+
+	Context("When the stored certificate's SANs drift from the spec", func() {
+		// issue issues cert and returns the reconciler and the issued serial number
+		issue := func(cert *certv1alpha1.Certificate) (*CertificateReconciler, string) {
+			r := newFakeReconciler(cert)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			return r, updated.Status.SerialNumber
+		}
+
+		// reissueWith applies edit without the spec hash, so only the stored SANs can trigger re-issuance
+		reissueWith := func(r *CertificateReconciler, cert *certv1alpha1.Certificate, edit func(*certv1alpha1.Certificate)) string {
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			edit(updated)
+			ExpectWithOffset(1, r.Update(ctx, updated)).To(Succeed())
+			updated.Status.SpecHash = ""
+			ExpectWithOffset(1, r.Status().Update(ctx, updated)).To(Succeed())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			return updated.Status.SerialNumber
+		}
+
+		newDriftCertificate := func(name string) *certv1alpha1.Certificate {
+			cert := newTestCertificate(name)
+			cert.Spec.DNSNames = []string{"a.example.com", "b.example.com"}
+			cert.Spec.IPAddresses = []string{"10.0.0.1"}
+			return cert
+		}
+
+		It("should re-issue when a SAN is added", func() {
+			cert := newDriftCertificate("sans-added")
+			r, serial := issue(cert)
+			Expect(reissueWith(r, cert, func(c *certv1alpha1.Certificate) {
+				c.Spec.DNSNames = append(c.Spec.DNSNames, "c.example.com")
+			})).NotTo(Equal(serial))
+		})
+
+		It("should re-issue when a SAN is removed", func() {
+			cert := newDriftCertificate("sans-removed")
+			r, serial := issue(cert)
+			Expect(reissueWith(r, cert, func(c *certv1alpha1.Certificate) {
+				c.Spec.IPAddresses = nil
+			})).NotTo(Equal(serial))
+		})
+
+		It("should not re-issue for the same SANs in another order", func() {
+			cert := newDriftCertificate("sans-same")
+			r, serial := issue(cert)
+			Expect(reissueWith(r, cert, func(c *certv1alpha1.Certificate) {
+				c.Spec.DNSNames = []string{"B.example.com", "a.example.com", "a.example.com"}
+			})).To(Equal(serial))
+		})
+	})
+
+	Context("When the CommonName is empty", func() {
+		It("should issue a SAN-only certificate without a CN", func() {
+			cert := newTestCertificate("san-only")
+			cert.Spec.CommonName = ""
+			cert.Spec.DNSNames = []string{"san-only.example.com"}
+			r := newFakeReconciler(cert)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			parsed := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+			Expect(parsed.Subject.CommonName).To(BeEmpty())
+			Expect(parsed.Subject.String()).NotTo(ContainSubstring("CN="))
+			Expect(parsed.DNSNames).To(Equal([]string{"san-only.example.com"}))
+			Expect(verifySecretKeyPair(secret)).To(Succeed())
+		})
+
+		It("should reject a certificate with neither a CN nor SANs", func() {
+			cert := newTestCertificate("no-identity")
+			cert.Spec.CommonName = ""
+
+			_, err := newFakeReconciler().generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrInvalidSpec))
+		})
+	})
+
+	It("should reject a malformed IP address instead of dropping it", func() {
+		cert := newTestCertificate("bad-ip")
+		cert.Spec.IPAddresses = []string{"10.0.0.1", "10.0.0.256"}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		err = r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("InvalidIPAddress"))
+		Expect(ready.Message).To(ContainSubstring(`"10.0.0.256"`))
+		Expect(ready.Message).NotTo(ContainSubstring(`"10.0.0.1"`))
+
+		_, err = r.generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidIPAddress))
+	})
+})
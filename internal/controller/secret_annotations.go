@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations describing the stored certificate, for tools such as reflector and reloader that
+// react to secrets without parsing their data
+const (
+	notAfterAnnotation   = "cert.example.com/not-after"
+	serialAnnotation     = "cert.example.com/serial"
+	commonNameAnnotation = "cert.example.com/common-name"
+
+	// formatVersionAnnotation tells consumers which layout of the secret's data to expect, so
+	// they can adapt to it or fail fast on one they don't know
+	formatVersionAnnotation = "cert.example.com/format-version"
+)
+
+// secretFormatVersion is the current layout of issued secrets. Bump it whenever the keys the
+// operator writes, or their encoding, change. Version 1: tls.crt holds the PEM leaf followed by
+// its issuer chain, tls.key the PEM private key unless the certificate was signed from a CSR,
+// and ca.crt, which SecretKeys may rename, the PEM CA chain of CA-issued certificates or a
+// rotated self-signed CA followed by its cross-signed certificate. bundle.p7b, keystore.jks,
+// truststore.jks and their passwords are added when requested
+const secretFormatVersion = "1"
+
+// applyCertificateAnnotations sets the certificate metadata and format version annotations on
+// obj from issued. The common name annotation is removed for SAN-only certificates
+func applyCertificateAnnotations(obj metav1.Object, issued *issuedCertificate) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[notAfterAnnotation] = issued.notAfter.UTC().Format(time.RFC3339)
+	annotations[serialAnnotation] = issued.serialNumber
+	annotations[formatVersionAnnotation] = secretFormatVersion
+
+	delete(annotations, commonNameAnnotation)
+	if block, _ := pem.Decode(issued.certPEM); block != nil {
+		if leaf, err := x509.ParseCertificate(block.Bytes); err == nil && leaf.Subject.CommonName != "" {
+			annotations[commonNameAnnotation] = leaf.Subject.CommonName
+		}
+	}
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Secret certificate annotations", func() {
+	It("should annotate the secret with the certificate metadata and update it on renewal", func() {
+		cert := newTestCertificate("annotated")
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		expectAnnotations := func() *certv1alpha1.Certificate {
+			updated := &certv1alpha1.Certificate{}
+			ExpectWithOffset(1, r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+			secret := &corev1.Secret{}
+			ExpectWithOffset(1, r.Get(ctx, secretKey, secret)).To(Succeed())
+			ExpectWithOffset(1, secret.Annotations).To(HaveKeyWithValue(serialAnnotation, updated.Status.SerialNumber))
+			ExpectWithOffset(1, secret.Annotations).To(HaveKeyWithValue(commonNameAnnotation, "annotated.example.com"))
+			ExpectWithOffset(1, secret.Annotations).To(HaveKeyWithValue(notAfterAnnotation,
+				updated.Status.NotAfter.UTC().Format(time.RFC3339)))
+			return updated
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := expectAnnotations()
+
+		// Make the certificate due so the next reconcile renews it
+		first.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(r.Status().Update(ctx, first)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		renewed := expectAnnotations()
+		Expect(renewed.Status.SerialNumber).NotTo(Equal(first.Status.SerialNumber))
+	})
+
+	It("should omit the common name of a SAN-only certificate", func() {
+		cert := newTestCertificate("annotated-san-only")
+		cert.Spec.CommonName = ""
+		cert.Spec.DNSNames = []string{"san-only.example.com"}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Annotations).To(HaveKey(serialAnnotation))
+		Expect(secret.Annotations).NotTo(HaveKey(commonNameAnnotation))
+	})
+
+	Describe("format version", func() {
+		// layouts lists the data keys of each secret format version, so a layout change that
+		// doesn't bump secretFormatVersion fails here
+		layouts := map[string]struct{ selfSigned, caIssued, optional []string }{
+			"1": {
+				selfSigned: []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+				caIssued:   []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"},
+				optional:   []string{"bundle.p7b", "keystore.jks", "truststore.jks", "keystore.password", "truststore.password"},
+			},
+		}
+
+		issue := func(cert *certv1alpha1.Certificate, objs ...client.Object) *corev1.Secret {
+			r := newFakeReconciler(append(objs, cert)...)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{}
+			ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+			ExpectWithOffset(1, secret.Annotations).To(HaveKeyWithValue(formatVersionAnnotation, secretFormatVersion))
+			return secret
+		}
+
+		It("should stamp the current version on a self-signed secret matching its layout", func() {
+			Expect(layouts).To(HaveKey(secretFormatVersion))
+			secret := issue(newTestCertificate("format-self-signed"))
+			Expect(secret.Data).To(HaveLen(len(layouts[secretFormatVersion].selfSigned)))
+			for _, key := range layouts[secretFormatVersion].selfSigned {
+				Expect(secret.Data).To(HaveKey(key))
+			}
+		})
+
+		It("should stamp the current version on a CA-issued secret matching its layout", func() {
+			ca := newKeyPairSecret("format-ca", "Format CA", true, 365*24*time.Hour)
+			cert := newTestCertificate("format-ca-issued")
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+			cert.Spec.IncludePKCS7 = true
+			cert.Spec.Profile = profileKafkaTLS
+
+			secret := issue(cert, ca)
+			layout := layouts[secretFormatVersion]
+			for _, key := range layout.caIssued {
+				Expect(secret.Data).To(HaveKey(key))
+			}
+			for key := range secret.Data {
+				Expect(append(append([]string{}, layout.caIssued...), layout.optional...)).To(ContainElement(key))
+			}
+		})
+	})
+})
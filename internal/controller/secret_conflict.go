@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// secretNameOwner returns the Certificate entitled to cert's secret when another Certificate, in
+// any namespace, claims the same SecretName and SecretNamespace and was created first, or nil when cert is. Creation
+// ties go to the Certificate whose name sorts first, so both sides agree on the outcome
+func (r *CertificateReconciler) secretNameOwner(ctx context.Context, cert *certv1alpha1.Certificate) (*certv1alpha1.Certificate, error) {
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates); err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	var owner *certv1alpha1.Certificate
+	for i := range certificates.Items {
+		other := &certificates.Items[i]
+		if client.ObjectKeyFromObject(other) == client.ObjectKeyFromObject(cert) || secretKey(other) != secretKey(cert) {
+			continue
+		}
+		if createdBefore(other, cert) && (owner == nil || createdBefore(other, owner)) {
+			owner = other
+		}
+	}
+	return owner, nil
+}
+
+// createdBefore orders Certificates by creation time, then namespace and name
+func createdBefore(a, b *certv1alpha1.Certificate) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// certificatesForSecretName maps a Certificate to the others claiming its SecretName, so a
+// Certificate that lost the secret is reconciled as soon as the owner releases it
+func (r *CertificateReconciler) certificatesForSecretName(ctx context.Context, obj client.Object) []reconcile.Request {
+	cert, ok := obj.(*certv1alpha1.Certificate)
+	if !ok {
+		return nil
+	}
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, other := range certificates.Items {
+		if client.ObjectKeyFromObject(&other) != client.ObjectKeyFromObject(cert) && secretKey(&other) == secretKey(cert) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&other)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Secret name conflicts", func() {
+	It("should keep the secret with the older Certificate and mark the newer one", func() {
+		older := newTestCertificate("conflict-older")
+		older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		newer := newTestCertificate("conflict-newer")
+		newer.CreationTimestamp = metav1.NewTime(time.Now())
+		newer.Spec.SecretName = older.Spec.SecretName
+		r := newFakeReconciler(older, newer)
+		secretKey := client.ObjectKey{Name: older.Spec.SecretName, Namespace: "default"}
+
+		// Reconcile the newer Certificate first; it must not take the secret either way
+		for _, cert := range []*certv1alpha1.Certificate{newer, older, newer} {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		winner := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(older), winner)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(winner.Status.Conditions, typeReadyCert)).To(BeTrue())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Annotations).To(HaveKeyWithValue(serialAnnotation, winner.Status.SerialNumber))
+
+		loser := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(newer), loser)).To(Succeed())
+		ready := meta.FindStatusCondition(loser.Status.Conditions, typeReadyCert)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("SecretNameConflict"))
+		Expect(ready.Message).To(ContainSubstring("claimed by Certificate conflict-older"))
+		Expect(loser.Status.SerialNumber).To(BeEmpty())
+
+		// The warning is emitted once, not on every reconcile
+		var conflicts int
+		for len(r.Recorder.(*record.FakeRecorder).Events) > 0 {
+			if event := <-r.Recorder.(*record.FakeRecorder).Events; event == "Warning SecretNameConflict "+ready.Message {
+				conflicts++
+			}
+		}
+		Expect(conflicts).To(Equal(1))
+
+		// Changes to the owner reach the Certificate waiting for its secret
+		Expect(r.certificatesForSecretName(ctx, winner)).To(ConsistOf(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(newer)}))
+	})
+
+	It("should break creation time ties by name", func() {
+		// Timestamps are stored with second precision
+		created := metav1.NewTime(time.Now().Truncate(time.Second))
+		a := newTestCertificate("conflict-tie-a")
+		b := newTestCertificate("conflict-tie-b")
+		a.CreationTimestamp, b.CreationTimestamp = created, created
+		b.Spec.SecretName = a.Spec.SecretName
+		r := newFakeReconciler(a, b)
+
+		owner, err := r.secretNameOwner(ctx, b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner.Name).To(Equal(a.Name))
+		owner, err = r.secretNameOwner(ctx, a)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner).To(BeNil())
+	})
+})
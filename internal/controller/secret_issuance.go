@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// CertificateSpecAnnotation holds, as JSON, the Certificate spec a placeholder secret is issued
+// from, e.g. {"commonName":"app.example.com","dnsNames":["app.example.com"]}. secretName is
+// implied by the secret itself
+const CertificateSpecAnnotation = "cert.example.com/certificate-spec"
+
+// SecretIssuerReconciler fills placeholder secrets carrying CertificateSpecAnnotation with a
+// certificate, for teams that would rather drop a secret than create a Certificate. Issuance goes
+// through Certificates, so templates, issuers, issuer and wildcard policies and the namespace
+// quota apply. CA and approval specs, which need a Certificate, are rejected
+type SecretIssuerReconciler struct {
+	client.Client
+
+	// Certificates issues the certificates
+	Certificates *CertificateReconciler
+}
+
+// Reconcile issues or renews the certificate of the secret named by req
+func (r *SecretIssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	value, ok := secret.Annotations[CertificateSpecAnnotation]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	cert, err := certificateForPlaceholder(secret, value)
+	if err != nil {
+		// Retrying can't succeed; the next change to the annotation triggers a reconcile
+		logger.Error(err, "Invalid certificate spec on secret")
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidSpec", "Not issuing: %v", err)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Certificates.applyTemplate(ctx, cert); err != nil {
+		logger.Error(err, "Failed to apply certificate template for secret")
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, "TemplateUnavailable", "Failed to apply certificate template: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Certificates.applyIssuer(ctx, cert); err != nil {
+		logger.Error(err, "Failed to resolve issuer for secret")
+		reason, retryable := issuanceFailure(err)
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, reason, "Failed to resolve issuer: %v", err)
+		if !retryable {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Issuer secrets aren't watched, so even an invalid policy is retried with backoff
+	if err := r.Certificates.applyIssuerPolicy(ctx, cert); err != nil {
+		logger.Error(err, "Failed to apply issuer policy for secret")
+		reason, _ := issuanceFailure(err)
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, reason, "Failed to apply issuer policy: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	err = validatePlaceholder(cert)
+	if err == nil {
+		err = r.Certificates.applyWildcardPolicy(cert)
+	}
+	if err != nil {
+		// Retrying can't succeed; the next change to the annotation triggers a reconcile
+		logger.Error(err, "Certificate spec on secret violates policy")
+		reason, _ := issuanceFailure(err)
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, reason, "Not issuing: %v", err)
+		return ctrl.Result{}, nil
+	}
+
+	if renewalTime, current := r.storedRenewalTime(secret, cert); current {
+		return ctrl.Result{RequeueAfter: r.Certificates.clampRequeue(time.Until(renewalTime))}, nil
+	}
+
+	// Placeholders count against the same namespace quota as Certificates
+	if allowed, resetIn := r.Certificates.namespaceQuota.reserve(cert.Namespace, r.Certificates.NamespaceIssuanceQuota, time.Now()); !allowed {
+		logger.Info("Namespace issuance quota exceeded", "namespace", cert.Namespace, "resetIn", resetIn)
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, "QuotaExceeded",
+			"Namespace %s used its quota of %d issuances per hour; next issuance allowed in %s",
+			cert.Namespace, r.Certificates.NamespaceIssuanceQuota, resetIn.Round(time.Second))
+		return ctrl.Result{RequeueAfter: resetIn}, nil
+	}
+
+	issued, err := r.Certificates.generateCertificateWithTimeout(ctx, cert)
+	if err != nil {
+		logger.Error(err, "Failed to issue certificate for secret")
+		reason, retryable := issuanceFailure(err)
+		r.Certificates.Recorder.Eventf(secret, corev1.EventTypeWarning, reason, "Failed to issue certificate: %v", err)
+		if !retryable {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	// The placeholder's type can't be changed, so only its data is filled in
+	secret.Data[corev1.TLSCertKey] = issued.certPEM
+	if len(issued.keyPEM) > 0 {
+		secret.Data[corev1.TLSPrivateKeyKey] = issued.keyPEM
+	}
+	if len(issued.caPEM) > 0 {
+		secret.Data[caSecretKey(cert)] = issued.caPEM
+	} else {
+		delete(secret.Data, caSecretKey(cert))
+	}
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, specHashAnnotation, issuanceSpecHash(cert))
+	applyCertificateAnnotations(secret, issued)
+	if err := r.Update(ctx, secret); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	countIssuance(cert)
+	r.Certificates.Recorder.Eventf(secret, corev1.EventTypeNormal, "CertificateIssued",
+		"Issued certificate with serial %s, expiring %s", issued.serialNumber, issued.notAfter.UTC().Format(time.RFC3339))
+	logger.Info("Certificate issued into secret", "secret", secret.Name, "notAfter", issued.notAfter)
+
+	renewalTime := r.Certificates.calculateRenewalTime(cert, issued.notAfter)
+	return ctrl.Result{RequeueAfter: r.Certificates.clampRequeue(time.Until(renewalTime.Time))}, nil
+}
+
+// certificateForPlaceholder builds the in-memory Certificate a placeholder secret is issued from
+func certificateForPlaceholder(secret *corev1.Secret, value string) (*certv1alpha1.Certificate, error) {
+	cert := &certv1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: secret.Namespace},
+	}
+	if err := json.Unmarshal([]byte(value), &cert.Spec); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a valid certificate spec: %w", ErrInvalidSpec, CertificateSpecAnnotation, err)
+	}
+	cert.Spec.SecretName = secret.Name
+	cert.Spec.SecretNamespace = ""
+	if cert.Spec.CommonName == "" && len(cert.Spec.DNSNames) == 0 && len(cert.Spec.IPAddresses) == 0 {
+		return nil, fmt.Errorf("%w: at least one of commonName, dnsNames or ipAddresses is required", ErrInvalidSpec)
+	}
+	return cert, nil
+}
+
+// validatePlaceholder rejects a spec a placeholder can't honour. A CA would be minted without
+// the review a Certificate for one gets, and approval has no status to record it on
+func validatePlaceholder(cert *certv1alpha1.Certificate) error {
+	if cert.Spec.IsCA {
+		return fmt.Errorf("%w: isCA is only issued for Certificates", ErrPolicyViolation)
+	}
+	if cert.Spec.RequireApproval {
+		return fmt.Errorf("%w: requireApproval is only supported on Certificates", ErrInvalidSpec)
+	}
+	return validateIPAddresses(cert)
+}
+
+// storedRenewalTime returns when the certificate stored in secret is due for renewal, and whether
+// it was issued from the current spec and isn't due yet
+func (r *SecretIssuerReconciler) storedRenewalTime(secret *corev1.Secret, cert *certv1alpha1.Certificate) (time.Time, bool) {
+	if secret.Annotations[specHashAnnotation] != issuanceSpecHash(cert) {
+		return time.Time{}, false
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, false
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false
+	}
+	renewalTime := r.Certificates.calculateRenewalTime(cert, leaf.NotAfter).Time
+	return renewalTime, time.Now().Before(renewalTime)
+}
+
+// hasCertificateSpec reports whether obj asks to be filled with a certificate
+func hasCertificateSpec(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[CertificateSpecAnnotation]
+	return ok
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SecretIssuerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("secret-issuer").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(hasCertificateSpec))).
+		Complete(r)
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Secret issuer", func() {
+	newPlaceholder := func(name, spec string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{CertificateSpecAnnotation: spec},
+		}}
+	}
+
+	It("should issue a certificate into an annotated secret", func() {
+		placeholder := newPlaceholder("placeholder-tls", `{"commonName":"app.example.com","dnsNames":["app.example.com"]}`)
+		certificates := newFakeReconciler(placeholder)
+		r := &SecretIssuerReconciler{Client: certificates.Client, Certificates: certificates}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(placeholder)}
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+		leaf := parseCertificatePEM(secret.Data[corev1.TLSCertKey])
+		Expect(leaf.Subject.CommonName).To(Equal("app.example.com"))
+		Expect(leaf.DNSNames).To(ConsistOf("app.example.com"))
+		Expect(secret.Annotations).To(HaveKeyWithValue(serialAnnotation, leaf.SerialNumber.Text(16)))
+
+		// The stored certificate is current, so it isn't issued again
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).SerialNumber).To(Equal(leaf.SerialNumber))
+	})
+
+	It("should re-issue when the annotated spec changes", func() {
+		placeholder := newPlaceholder("placeholder-changed", `{"commonName":"old.example.com"}`)
+		certificates := newFakeReconciler(placeholder)
+		r := &SecretIssuerReconciler{Client: certificates.Client, Certificates: certificates}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(placeholder)}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		secret.Annotations[CertificateSpecAnnotation] = `{"commonName":"new.example.com"}`
+		Expect(r.Update(ctx, secret)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).Subject.CommonName).To(Equal("new.example.com"))
+	})
+
+	It("should leave a secret with an invalid spec unfilled", func() {
+		placeholder := newPlaceholder("placeholder-invalid", `{"duration":"90d"`)
+		certificates := newFakeReconciler(placeholder)
+		r := &SecretIssuerReconciler{Client: certificates.Client, Certificates: certificates}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(placeholder)}
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		Expect(secret.Data).To(BeEmpty())
+		Expect(certificates.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("InvalidSpec")))
+	})
+
+	It("should reject specs a placeholder can't honour", func() {
+		for name, spec := range map[string]string{
+			"placeholder-ca":       `{"commonName":"root","isCA":true}`,
+			"placeholder-approval": `{"commonName":"app.example.com","requireApproval":true}`,
+			"placeholder-ip":       `{"commonName":"app.example.com","ipAddresses":["not-an-ip"]}`,
+		} {
+			placeholder := newPlaceholder(name, spec)
+			certificates := newFakeReconciler(placeholder)
+			r := &SecretIssuerReconciler{Client: certificates.Client, Certificates: certificates}
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(placeholder)}
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred(), name)
+			Expect(result.RequeueAfter).To(BeZero(), name)
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+			Expect(secret.Data).To(BeEmpty(), name)
+			Expect(certificates.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Not issuing")), name)
+		}
+	})
+
+	It("should count issuances against the namespace quota", func() {
+		placeholder := newPlaceholder("placeholder-quota", `{"commonName":"app.example.com"}`)
+		certificates := newFakeReconciler(placeholder)
+		certificates.NamespaceIssuanceQuota = 1
+		certificates.namespaceQuota.reserve("default", 1, time.Now())
+		r := &SecretIssuerReconciler{Client: certificates.Client, Certificates: certificates}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(placeholder)}
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+		Expect(secret.Data).To(BeEmpty())
+		Expect(certificates.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("QuotaExceeded")))
+	})
+
+	It("should only watch annotated secrets", func() {
+		Expect(hasCertificateSpec(newPlaceholder("annotated", "{}"))).To(BeTrue())
+		Expect(hasCertificateSpec(&corev1.Secret{})).To(BeFalse())
+	})
+})
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// defaultCASecretKey is the secret key the CA bundle is written under unless SecretKeys.CA is set
+const defaultCASecretKey = "ca.crt"
+
+// caSecretKey returns the secret key the certificate's CA bundle is written under
+func caSecretKey(cert *certv1alpha1.Certificate) string {
+	if cert.Spec.SecretKeys == nil || cert.Spec.SecretKeys.CA == "" {
+		return defaultCASecretKey
+	}
+	return cert.Spec.SecretKeys.CA
+}
+
+// validateSecretKeys rejects a CA key that would overwrite another entry of the secret
+func validateSecretKeys(cert *certv1alpha1.Certificate) error {
+	switch key := caSecretKey(cert); key {
+	case corev1.TLSCertKey, corev1.TLSPrivateKeyKey, pkcs7BundleKey, publicDERKey,
+		keystoreKey, truststoreKey, keystorePasswordKey, truststorePasswordKey:
+		return fmt.Errorf("%w: secretKeys.ca must not be %s", ErrInvalidSpec, key)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Secret keys", func() {
+	It("should write the CA bundle under the configured key", func() {
+		ca := newKeyPairSecret("bundle-ca", "Bundle Root CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("custom-ca-key")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		cert.Spec.SecretKeys = &certv1alpha1.SecretKeys{CA: "ca-bundle.crt"}
+		cert.Spec.SelfTest = true
+		r := newFakeReconciler(cert, ca)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("ca-bundle.crt", ca.Data[corev1.TLSCertKey]))
+		Expect(secret.Data).NotTo(HaveKey("ca.crt"))
+		Expect(secret.Data).To(HaveKey(corev1.TLSCertKey))
+		Expect(secret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+		Expect(verifySecretKeyPairWithCA(secret, "ca-bundle.crt")).To(Succeed())
+	})
+
+	It("should reject a CA key that overwrites the certificate", func() {
+		cert := newTestCertificate("clashing-ca-key")
+		cert.Spec.SecretKeys = &certv1alpha1.SecretKeys{CA: corev1.TLSCertKey}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("InvalidSpec"))
+	})
+})
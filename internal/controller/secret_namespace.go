@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// certificateNamespaceLabel records the namespace of the Certificate that wrote a secret to
+// another namespace, where an owner reference can't point back to it
+const certificateNamespaceLabel = "cert.example.com/certificate-namespace"
+
+// secretNamespace returns the namespace SecretName is written to
+func secretNamespace(cert *certv1alpha1.Certificate) string {
+	if cert.Spec.SecretNamespace != "" {
+		return cert.Spec.SecretNamespace
+	}
+	return cert.Namespace
+}
+
+// statusSecretNamespace returns the namespace the secret recorded in status was written to
+func statusSecretNamespace(cert *certv1alpha1.Certificate) string {
+	if cert.Status.SecretNamespace != "" {
+		return cert.Status.SecretNamespace
+	}
+	return cert.Namespace
+}
+
+// secretKey returns the key of the SecretName secret
+func secretKey(cert *certv1alpha1.Certificate) types.NamespacedName {
+	return types.NamespacedName{Name: cert.Spec.SecretName, Namespace: secretNamespace(cert)}
+}
+
+// writesCrossNamespace reports whether the SecretName secret lives outside the Certificate's namespace
+func writesCrossNamespace(cert *certv1alpha1.Certificate) bool {
+	return secretNamespace(cert) != cert.Namespace
+}
+
+// checkSecretNamespace rejects a SecretNamespace the operator isn't configured to write secrets to
+func (r *CertificateReconciler) checkSecretNamespace(cert *certv1alpha1.Certificate) error {
+	if !writesCrossNamespace(cert) || slices.Contains(r.AllowedSecretNamespaces, "*") ||
+		slices.Contains(r.AllowedSecretNamespaces, cert.Spec.SecretNamespace) {
+		return nil
+	}
+	return fmt.Errorf("%w: secretNamespace %s is not one of the namespaces secrets may be written to",
+		ErrPolicyViolation, cert.Spec.SecretNamespace)
+}
+
+// managesSecret reports whether secret was written for cert, by owner reference in the
+// Certificate's namespace or by label elsewhere
+func managesSecret(cert *certv1alpha1.Certificate, secret *corev1.Secret) bool {
+	if secret.Namespace == cert.Namespace {
+		return metav1.IsControlledBy(secret, cert)
+	}
+	return secret.Labels["app.kubernetes.io/managed-by"] == "certificate-operator" &&
+		secret.Labels["cert.example.com/certificate"] == cert.Name &&
+		secret.Labels[certificateNamespaceLabel] == cert.Namespace
+}
+
+// createOrUpdateManagedSecret is controllerutil.CreateOrUpdate for a secret written for cert. It
+// refuses to take over an existing secret cert doesn't manage, which would otherwise be
+// overwritten and then garbage collected with the Certificate
+func (r *CertificateReconciler) createOrUpdateManagedSecret(ctx context.Context, cert *certv1alpha1.Certificate,
+	secret *corev1.Secret, mutate controllerutil.MutateFn) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.ResourceVersion != "" && !managesSecret(cert, secret) {
+			return fmt.Errorf("%w: %s/%s", ErrSecretNotManaged, secret.Namespace, secret.Name)
+		}
+		return mutate()
+	})
+	return err
+}
+
+// deleteCrossNamespaceSecret deletes a secret written to another namespace, which garbage
+// collection can't remove with the Certificate. Secrets not managed for cert are left alone
+func (r *CertificateReconciler) deleteCrossNamespaceSecret(ctx context.Context, cert *certv1alpha1.Certificate,
+	key types.NamespacedName) error {
+	if key.Name == "" || key.Namespace == cert.Namespace {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !managesSecret(cert, secret) {
+		return nil
+	}
+	if err := r.Delete(ctx, secret, client.Preconditions{UID: &secret.UID}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	return nil
+}
+
+// cleanupCrossNamespaceSecrets deletes the secrets a Certificate being deleted wrote to other namespaces
+func (r *CertificateReconciler) cleanupCrossNamespaceSecrets(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if err := r.deleteCrossNamespaceSecret(ctx, cert, secretKey(cert)); err != nil {
+		return err
+	}
+	// The spec may have moved on from a secret that was never replaced
+	return r.deleteCrossNamespaceSecret(ctx, cert,
+		types.NamespacedName{Name: cert.Status.SecretName, Namespace: statusSecretNamespace(cert)})
+}
+
+// certificateForSecret maps a secret written to another namespace back to its Certificate, which
+// Owns can't do without an owner reference
+func (r *CertificateReconciler) certificateForSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	namespace, name := labels[certificateNamespaceLabel], labels["cert.example.com/certificate"]
+	if namespace == "" || name == "" || labels["app.kubernetes.io/managed-by"] != "certificate-operator" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Secret namespace", func() {
+	It("should write the secret to the target namespace, tracked by label", func() {
+		cert := newTestCertificate("cross-ns")
+		cert.Spec.SecretNamespace = "app"
+		r := newFakeReconciler(cert)
+		r.AllowedSecretNamespaces = []string{"app"}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "cross-ns-tls", Namespace: "app"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKey(corev1.TLSCertKey))
+		Expect(secret.OwnerReferences).To(BeEmpty())
+		Expect(secret.Labels).To(HaveKeyWithValue("cert.example.com/certificate", "cross-ns"))
+		Expect(secret.Labels).To(HaveKeyWithValue(certificateNamespaceLabel, "default"))
+		Expect(r.Get(ctx, client.ObjectKey{Name: "cross-ns-tls", Namespace: "default"}, &corev1.Secret{})).
+			To(Satisfy(errors.IsNotFound))
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.SecretNamespace).To(Equal("app"))
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+
+		// The label stands in for the owner reference, so the next reconcile doesn't re-issue
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(r.Get(ctx, client.ObjectKey{Name: "cross-ns-tls", Namespace: "app"}, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).SerialNumber.Text(16)).
+			To(Equal(updated.Status.SerialNumber))
+	})
+
+	It("should reject a secret namespace the operator doesn't allow", func() {
+		cert := newTestCertificate("cross-ns-denied")
+		cert.Spec.SecretNamespace = "kube-system"
+		r := newFakeReconciler(cert)
+		r.AllowedSecretNamespaces = []string{"app"}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "kube-system"}, &corev1.Secret{})).
+			To(Satisfy(errors.IsNotFound))
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("PolicyViolation"))
+
+		By("allowing any namespace")
+		r.AllowedSecretNamespaces = []string{"*"}
+		Expect(r.checkSecretNamespace(cert)).To(Succeed())
+	})
+
+	It("should refuse to overwrite a secret it doesn't manage", func() {
+		cert := newTestCertificate("foreign-secret")
+		foreign := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cert.Spec.SecretName, Namespace: "default"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("theirs")},
+		}
+		r := newFakeReconciler(cert, foreign)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ErrSecretNotManaged))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(foreign), foreign)).To(Succeed())
+		Expect(foreign.Data).To(HaveKeyWithValue(corev1.TLSCertKey, []byte("theirs")))
+		Expect(foreign.OwnerReferences).To(BeEmpty())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert).Reason).To(Equal("SecretNotManaged"))
+	})
+
+	It("should keep labels others added to a managed secret", func() {
+		cert := newTestCertificate("labelled-secret")
+		existing := newOwnedSecret(cert)
+		existing.Labels = map[string]string{"team": "payments"}
+		r := newFakeReconciler(cert, existing)
+
+		Expect(r.createOrUpdateSecret(ctx, cert, &issuedCertificate{certPEM: []byte("cert"), keyPEM: []byte("key")})).To(Succeed())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(existing), existing)).To(Succeed())
+		Expect(existing.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(existing.Labels).To(HaveKeyWithValue("cert.example.com/certificate", "labelled-secret"))
+	})
+
+	It("should map the secret back to its Certificate", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cross-ns-tls", Namespace: "app", Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "certificate-operator",
+			"cert.example.com/certificate": "cross-ns",
+			certificateNamespaceLabel:      "ops",
+		}}}
+		Expect(newFakeReconciler().certificateForSecret(ctx, secret)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKey{Name: "cross-ns", Namespace: "ops"}}))
+
+		delete(secret.Labels, certificateNamespaceLabel)
+		Expect(newFakeReconciler().certificateForSecret(ctx, secret)).To(BeEmpty())
+	})
+
+	It("should delete the secret with the Certificate", func() {
+		cert := newTestCertificate("cross-ns-delete")
+		cert.Spec.SecretNamespace = "app"
+		cert.Finalizers = []string{DefaultFinalizer}
+		cert.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		managed := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cross-ns-delete-tls", Namespace: "app", Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "certificate-operator",
+			"cert.example.com/certificate": "cross-ns-delete",
+			certificateNamespaceLabel:      "default",
+		}}}
+		r := newFakeReconciler(cert, managed)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(managed), &corev1.Secret{})).To(Satisfy(errors.IsNotFound))
+	})
+
+	It("should leave a secret in the target namespace it doesn't manage", func() {
+		cert := newTestCertificate("cross-ns-foreign")
+		cert.Spec.SecretNamespace = "app"
+		foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cross-ns-foreign-tls", Namespace: "app"}}
+		r := newFakeReconciler(foreign)
+
+		Expect(r.cleanupCrossNamespaceSecrets(ctx, cert)).To(Succeed())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(foreign), &corev1.Secret{})).To(Succeed())
+	})
+})
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// secretTemplateKeysAnnotation records, as JSON, the label and annotation keys SecretTemplate last
+// set on the secret, e.g. {"labels":["team"]}, so keys dropped from the template are removed
+const secretTemplateKeysAnnotation = "cert.example.com/secret-template-keys"
+
+// secretTemplateKeys are the keys SecretTemplate set on a secret
+type secretTemplateKeys struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// applySecretTemplate sets the SecretTemplate labels and annotations on the secret, removes the
+// ones it set before that the template no longer has, and reports whether anything changed.
+// Keys owned by the operator can't be overridden by the template
+func applySecretTemplate(cert *certv1alpha1.Certificate, secret *corev1.Secret) bool {
+	var previous, current secretTemplateKeys
+	if value, ok := secret.Annotations[secretTemplateKeysAnnotation]; ok {
+		// An unreadable record only means stale keys can't be removed
+		_ = json.Unmarshal([]byte(value), &previous)
+	}
+	var labels, annotations map[string]string
+	if template := cert.Spec.SecretTemplate; template != nil {
+		labels, annotations = template.Labels, template.Annotations
+	}
+
+	changed := false
+	apply := func(values map[string]string, applied []string, target *map[string]string) []string {
+		for _, key := range applied {
+			if _, ok := values[key]; !ok && !isOperatorKey(key) {
+				if _, ok := (*target)[key]; ok {
+					delete(*target, key)
+					changed = true
+				}
+			}
+		}
+		var keys []string
+		for key, value := range values {
+			if isOperatorKey(key) {
+				continue
+			}
+			keys = append(keys, key)
+			if current, ok := (*target)[key]; ok && current == value {
+				continue
+			}
+			if *target == nil {
+				*target = map[string]string{}
+			}
+			(*target)[key] = value
+			changed = true
+		}
+		slices.Sort(keys)
+		return keys
+	}
+	current.Labels = apply(labels, previous.Labels, &secret.Labels)
+	current.Annotations = apply(annotations, previous.Annotations, &secret.Annotations)
+
+	if len(current.Labels) == 0 && len(current.Annotations) == 0 {
+		if _, ok := secret.Annotations[secretTemplateKeysAnnotation]; ok {
+			delete(secret.Annotations, secretTemplateKeysAnnotation)
+			changed = true
+		}
+		return changed
+	}
+	// Marshalling a struct of string slices can't fail
+	record, _ := json.Marshal(current)
+	if secret.Annotations[secretTemplateKeysAnnotation] != string(record) {
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, secretTemplateKeysAnnotation, string(record))
+		changed = true
+	}
+	return changed
+}
+
+// isOperatorKey reports whether a label or annotation key is managed by the operator itself
+func isOperatorKey(key string) bool {
+	return key == "app.kubernetes.io/managed-by" || strings.HasPrefix(key, "cert.example.com/")
+}
+
+// syncSecretMetadata patches SecretTemplate drift, including keys removed from the template, onto
+// the existing secret without touching its data
+func (r *CertificateReconciler) syncSecretMetadata(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	secret := &corev1.Secret{}
+	key := secretKey(cert)
+	if err := r.Get(ctx, key, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	if !applySecretTemplate(cert, secret) {
+		return nil
+	}
+	if err := r.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("failed to patch secret %s metadata: %w", key.Name, err)
+	}
+	return nil
+}
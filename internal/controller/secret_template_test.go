@@ -0,0 +1,93 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Secret template", func() {
+	It("should update secret metadata without re-issuing", func() {
+		cert := newTestCertificate("secret-template")
+		cert.Spec.SecretTemplate = &certv1alpha1.SecretTemplate{
+			Labels:      map[string]string{"team": "payments"},
+			Annotations: map[string]string{"reflector/allowed": "true"},
+		}
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(secret.Annotations).To(HaveKeyWithValue("reflector/allowed", "true"))
+		serial := parseCertificatePEM(secret.Data["tls.crt"]).SerialNumber
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Spec.SecretTemplate.Labels["team"] = "checkout"
+		Expect(r.Update(ctx, updated)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue("team", "checkout"))
+		Expect(parseCertificatePEM(secret.Data["tls.crt"]).SerialNumber).To(Equal(serial))
+	})
+
+	It("should remove keys dropped from the template", func() {
+		cert := newTestCertificate("secret-template-removed")
+		cert.Spec.SecretTemplate = &certv1alpha1.SecretTemplate{
+			Labels:      map[string]string{"team": "payments", "tier": "gold"},
+			Annotations: map[string]string{"reflector/allowed": "true"},
+		}
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+		secretKey := client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		secret.Labels["owner"] = "someone-else"
+		Expect(r.Update(ctx, secret)).To(Succeed())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		delete(updated.Spec.SecretTemplate.Labels, "tier")
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(secret.Labels).NotTo(HaveKey("tier"))
+		Expect(secret.Labels).To(HaveKeyWithValue("owner", "someone-else"))
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		updated.Spec.SecretTemplate = nil
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Labels).NotTo(HaveKey("team"))
+		Expect(secret.Labels).To(HaveKeyWithValue("owner", "someone-else"))
+		Expect(secret.Annotations).NotTo(HaveKey("reflector/allowed"))
+		Expect(secret.Annotations).NotTo(HaveKey(secretTemplateKeysAnnotation))
+	})
+
+	It("should not let the template override operator keys", func() {
+		cert := newTestCertificate("secret-template-reserved")
+		cert.Spec.SecretTemplate = &certv1alpha1.SecretTemplate{
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "helm", "cert.example.com/certificate": "other"},
+		}
+		secret := &corev1.Secret{}
+		Expect(applySecretTemplate(cert, secret)).To(BeFalse())
+		Expect(secret.Labels).To(BeEmpty())
+	})
+})
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// selects reports whether obj's labels match the reconciler's CertificateSelector. Every object
+// matches when no selector is configured
+func (r *CertificateReconciler) selects(obj client.Object) bool {
+	return r.CertificateSelector == nil || r.CertificateSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// selectorPredicate filters Certificate events down to the selected certificates
+func (r *CertificateReconciler) selectorPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(r.selects)
+}
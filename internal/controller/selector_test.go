@@ -0,0 +1,58 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Certificate selector", func() {
+	It("should only reconcile certificates matching the selector", func() {
+		prod := newTestCertificate("selected-prod")
+		prod.Labels = map[string]string{"tier": "prod"}
+		staging := newTestCertificate("excluded-staging")
+		staging.Labels = map[string]string{"tier": "staging"}
+		unlabelled := newTestCertificate("excluded-unlabelled")
+		r := newFakeReconciler(prod, staging, unlabelled)
+		r.CertificateSelector = labels.SelectorFromSet(labels.Set{"tier": "prod"})
+
+		for _, cert := range []*certv1alpha1.Certificate{prod, staging, unlabelled} {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: prod.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		for _, cert := range []*certv1alpha1.Certificate{staging, unlabelled} {
+			err := r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			// Excluded certificates are left untouched, finalizer included
+			updated := &certv1alpha1.Certificate{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+			Expect(updated.Finalizers).To(BeEmpty())
+			Expect(updated.Status.Conditions).To(BeEmpty())
+		}
+	})
+
+	It("should filter certificate events by the selector", func() {
+		r := newFakeReconciler()
+		r.CertificateSelector = labels.SelectorFromSet(labels.Set{"tier": "prod"})
+		prod := newTestCertificate("event-prod")
+		prod.Labels = map[string]string{"tier": "prod"}
+
+		Expect(r.selectorPredicate().Create(event.CreateEvent{Object: prod})).To(BeTrue())
+		Expect(r.selectorPredicate().Create(event.CreateEvent{Object: newTestCertificate("event-other")})).To(BeFalse())
+	})
+
+	It("should select every certificate without a selector", func() {
+		Expect(newFakeReconciler().selects(newTestCertificate("anything"))).To(BeTrue())
+	})
+})
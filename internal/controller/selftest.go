@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// selfTestSecret re-reads the certificate secret and verifies its contents
+func (r *CertificateReconciler) selfTestSecret(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey(cert), secret); err != nil {
+		return fmt.Errorf("failed to read back secret: %w", err)
+	}
+	return verifySecretKeyPairWithCA(secret, caSecretKey(cert))
+}
+
+// verifySecretKeyPair checks that tls.key matches tls.crt and, when ca.crt is
+// present, that the chain in tls.crt verifies against it
+func verifySecretKeyPair(secret *corev1.Secret) error {
+	return verifySecretKeyPairWithCA(secret, defaultCASecretKey)
+}
+
+// verifySecretKeyPairWithCA is verifySecretKeyPair with the CA bundle read from caKey
+func verifySecretKeyPairWithCA(secret *corev1.Secret, caKey string) error {
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("invalid key pair: %w", err)
+	}
+
+	caPEM, ok := secret.Data[caKey]
+	if !ok {
+		return nil
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("%s contains no certificates", caKey)
+	}
+
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse leaf certificate: %w", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, der := range keyPair.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("failed to parse chain certificate: %w", err)
+		}
+		intermediates.AddCert(intermediate)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("chain verification failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Certificate self-test", func() {
+	generate := func(name string) ([]byte, []byte) {
+		issued, err := newFakeReconciler().generateCertificate(ctx, newTestCertificate(name))
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return issued.certPEM, issued.keyPEM
+	}
+
+	It("should accept a matching key pair", func() {
+		certPEM, keyPEM := generate("matching")
+		secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}}
+		Expect(verifySecretKeyPair(secret)).To(Succeed())
+	})
+
+	It("should reject a mismatched key pair", func() {
+		certPEM, _ := generate("first")
+		_, otherKeyPEM := generate("second")
+		secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM, "tls.key": otherKeyPEM}}
+		Expect(verifySecretKeyPair(secret)).To(MatchError(ContainSubstring("invalid key pair")))
+	})
+
+	It("should reject a chain that doesn't verify against ca.crt", func() {
+		certPEM, keyPEM := generate("leaf")
+		otherCertPEM, _ := generate("unrelated")
+		secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM, "ca.crt": otherCertPEM}}
+		Expect(verifySecretKeyPair(secret)).To(MatchError(ContainSubstring("chain verification failed")))
+	})
+
+	It("should mark the certificate not ready when the stored pair is broken", func() {
+		cert := newTestCertificate("broken-pair")
+		cert.Spec.SelfTest = true
+		_, otherKeyPEM := generate("other")
+
+		r := newFakeReconciler(cert)
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					// Simulate an encoding bug that stores the wrong key
+					secret.Data["tls.key"] = otherKeyPEM
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("SelfTestFailed"))
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, typeAvailableCert)).To(BeNil())
+	})
+})
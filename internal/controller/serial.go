@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+const (
+	serialSourceRandom     = "Random"
+	serialSourceProvided   = "Provided"
+	serialSourceSequential = "Sequential"
+
+	// sequentialSerialKey is the counter ConfigMap key holding the next serial number
+	sequentialSerialKey = "next"
+)
+
+// maxSerialNumber is the largest serial that fits the 20 octets allowed by RFC 5280
+var maxSerialNumber = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 159), big.NewInt(1))
+
+// serialNumber picks the certificate serial number according to the spec's source
+func (r *CertificateReconciler) serialNumber(ctx context.Context, cert *certv1alpha1.Certificate) (*big.Int, error) {
+	source := cert.Spec.SerialNumberSource
+	if source == nil || source.Type == "" || source.Type == serialSourceRandom {
+		serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+		return retryEntropy(ErrSerialNumber, func() (*big.Int, error) {
+			serialNumber, err := rand.Int(r.random(), serialNumberLimit)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrSerialNumber, err)
+			}
+			return serialNumber, nil
+		})
+	}
+
+	switch source.Type {
+	case serialSourceProvided:
+		return parseSerialNumber(source.Value)
+	case serialSourceSequential:
+		if source.ConfigMapName == "" {
+			return nil, fmt.Errorf("%w: sequential serial numbers require configMapName", ErrInvalidSpec)
+		}
+		return r.nextSequentialSerial(ctx, types.NamespacedName{Name: source.ConfigMapName, Namespace: cert.Namespace})
+	default:
+		return nil, fmt.Errorf("%w: unknown serial number source %q", ErrInvalidSpec, source.Type)
+	}
+}
+
+// nextSequentialSerial reserves the next serial number from the counter ConfigMap
+func (r *CertificateReconciler) nextSequentialSerial(ctx context.Context, key types.NamespacedName) (*big.Int, error) {
+	var serialNumber *big.Int
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		counter := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, counter); err != nil {
+			return err
+		}
+
+		next, err := parseSerialNumber(counter.Data[sequentialSerialKey])
+		if err != nil {
+			return err
+		}
+
+		counter.Data[sequentialSerialKey] = fmt.Sprintf("%x", new(big.Int).Add(next, big.NewInt(1)))
+		if err := r.Update(ctx, counter); err != nil {
+			return err
+		}
+		serialNumber = next
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to reserve sequential serial from %s: %w", ErrSerialNumber, key.Name, err)
+	}
+	return serialNumber, nil
+}
+
+// parseSerialNumber parses a hex encoded serial number and checks it is valid for X.509
+func parseSerialNumber(value string) (*big.Int, error) {
+	serialNumber, ok := new(big.Int).SetString(value, 16)
+	if !ok {
+		return nil, fmt.Errorf("%w: serial number %q is not hex encoded", ErrInvalidSpec, value)
+	}
+	if serialNumber.Sign() <= 0 || serialNumber.Cmp(maxSerialNumber) > 0 {
+		return nil, fmt.Errorf("%w: serial number %q must be positive and at most 20 octets", ErrInvalidSpec, value)
+	}
+	return serialNumber, nil
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Serial number source", func() {
+	It("should issue a certificate with the provided serial", func() {
+		cert := newTestCertificate("provided-serial")
+		cert.Spec.SerialNumberSource = &certv1alpha1.SerialNumberSource{Type: serialSourceProvided, Value: "1a2b3c"}
+		r := newFakeReconciler()
+
+		issued, err := r.generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued.serialNumber).To(Equal("1a2b3c"))
+		Expect(parseCertificatePEM(issued.certPEM).SerialNumber).To(Equal(big.NewInt(0x1a2b3c)))
+	})
+
+	It("should reject a provided serial that isn't valid", func() {
+		cert := newTestCertificate("bad-serial")
+		cert.Spec.SerialNumberSource = &certv1alpha1.SerialNumberSource{Type: serialSourceProvided, Value: "not-hex"}
+		r := newFakeReconciler()
+
+		_, err := r.generateCertificate(ctx, cert)
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should reserve sequential serials from the counter ConfigMap", func() {
+		counter := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "serials", Namespace: "default"},
+			Data:       map[string]string{sequentialSerialKey: "ff"},
+		}
+		cert := newTestCertificate("sequential-serial")
+		cert.Spec.SerialNumberSource = &certv1alpha1.SerialNumberSource{Type: serialSourceSequential, ConfigMapName: "serials"}
+		r := newFakeReconciler(counter)
+
+		first, err := r.serialNumber(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := r.serialNumber(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(Equal(big.NewInt(0xff)))
+		Expect(second).To(Equal(big.NewInt(0x100)))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(counter), counter)).To(Succeed())
+		Expect(counter.Data).To(HaveKeyWithValue(sequentialSerialKey, "101"))
+	})
+})
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"slices"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// defaultClusterDomain is the cluster DNS domain used when a ServiceRef doesn't set one
+const defaultClusterDomain = "cluster.local"
+
+// certificateDNSNames returns the spec's DNS names followed by the names derived from the
+// ServiceRef, without duplicates
+func certificateDNSNames(cert *certv1alpha1.Certificate) []string {
+	if cert.Spec.ServiceRef == nil {
+		return cert.Spec.DNSNames
+	}
+
+	names := slices.Clone(cert.Spec.DNSNames)
+	for _, name := range serviceDNSNames(cert.Spec.ServiceRef, cert.Namespace) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// serviceDNSNames returns the names a Service in namespace is reachable at through cluster DNS
+func serviceDNSNames(ref *certv1alpha1.ServiceRef, namespace string) []string {
+	clusterDomain := ref.ClusterDomain
+	if clusterDomain == "" {
+		clusterDomain = defaultClusterDomain
+	}
+
+	service := ref.Name
+	return []string{
+		service,
+		service + "." + namespace,
+		service + "." + namespace + ".svc",
+		service + "." + namespace + ".svc." + clusterDomain,
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Service DNS names", func() {
+	It("should derive the cluster DNS names of the referenced service", func() {
+		cert := newTestCertificate("service-sans")
+		cert.Spec.ServiceRef = &certv1alpha1.ServiceRef{Name: "api"}
+
+		Expect(certificateDNSNames(cert)).To(Equal([]string{
+			"api", "api.default", "api.default.svc", "api.default.svc.cluster.local",
+		}))
+	})
+
+	It("should use the overridden cluster domain and keep explicit names first", func() {
+		cert := newTestCertificate("service-domain")
+		cert.Namespace = "payments"
+		cert.Spec.DNSNames = []string{"api.example.com", "api.payments"}
+		cert.Spec.ServiceRef = &certv1alpha1.ServiceRef{Name: "api", ClusterDomain: "corp.internal"}
+
+		Expect(certificateDNSNames(cert)).To(Equal([]string{
+			"api.example.com", "api.payments", "api", "api.payments.svc", "api.payments.svc.corp.internal",
+		}))
+	})
+
+	It("should put the derived names in the issued certificate", func() {
+		cert := newTestCertificate("service-issued")
+		cert.Spec.ServiceRef = &certv1alpha1.ServiceRef{Name: "db"}
+
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parseCertificatePEM(issued.certPEM).DNSNames).To(ContainElement("db.default.svc.cluster.local"))
+	})
+})
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// statusFieldOwner is the field manager recorded for the controller's status patches
+const statusFieldOwner = "certificate-operator"
+
+// statusBaseKey carries the status a reconcile read, which its status patches are computed against
+type statusBaseKey struct{}
+
+// withStatusBase records the certificate's status as read, so updateStatus only sends what the
+// reconcile changed
+func withStatusBase(ctx context.Context, cert *certv1alpha1.Certificate) context.Context {
+	return context.WithValue(ctx, statusBaseKey{}, &statusBase{status: *cert.Status.DeepCopy()})
+}
+
+// statusBase is the last status the reconcile read or wrote
+type statusBase struct {
+	status certv1alpha1.CertificateStatus
+}
+
+// updateStatus writes the status changes made since the reconcile read the certificate as a merge
+// patch, so fields other actors set in the meantime, such as an approver's Approved condition,
+// aren't clobbered and only changed conditions can conflict. Without a recorded base it falls
+// back to an update
+func (r *CertificateReconciler) updateStatus(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	base, ok := ctx.Value(statusBaseKey{}).(*statusBase)
+	if !ok {
+		return r.Status().Update(ctx, cert)
+	}
+
+	original := cert.DeepCopy()
+	original.Status = *base.status.DeepCopy()
+	owner := client.FieldOwner(statusFieldOwner)
+
+	if equality.Semantic.DeepEqual(base.status.Conditions, cert.Status.Conditions) {
+		if err := r.Status().Patch(ctx, cert, client.MergeFrom(original), owner); err != nil {
+			return err
+		}
+		base.status = *cert.Status.DeepCopy()
+		return nil
+	}
+
+	// A merge patch replaces the whole conditions list, so merge in the conditions other actors
+	// changed since the read and only apply the patch to the version that was merged with
+	ours := cert.Status.Conditions
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &certv1alpha1.Certificate{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(cert), latest); err != nil {
+			return err
+		}
+		cert.Status.Conditions = mergeConditions(base.status.Conditions, latest.Status.Conditions, ours)
+		original.ResourceVersion = latest.ResourceVersion
+		return r.Status().Patch(ctx, cert, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}), owner)
+	})
+	if err != nil {
+		return err
+	}
+	base.status = *cert.Status.DeepCopy()
+	return nil
+}
+
+// fail marks the certificate not Ready for reason, with err as the message, and records it in
+// status. A failed status write is only logged, so callers return the result err calls for
+func (r *CertificateReconciler) fail(ctx context.Context, cert *certv1alpha1.Certificate, reason string, err error) {
+	meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeReadyCert,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.updateStatus(ctx, cert); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update Certificate status")
+	}
+}
+
+// mergeConditions three-way merges conditions: ours holds the reconcile's changes to base, and
+// latest holds the changes of other actors. Conditions the reconcile left alone take their
+// latest value, including ones added since the read
+func mergeConditions(base, latest, ours []metav1.Condition) []metav1.Condition {
+	merged := append([]metav1.Condition(nil), ours...)
+	for _, condition := range latest {
+		original := meta.FindStatusCondition(base, condition.Type)
+		current := meta.FindStatusCondition(ours, condition.Type)
+		switch {
+		case original == nil && current == nil:
+			// Added by another actor
+			merged = append(merged, condition)
+		case original != nil && current != nil && equality.Semantic.DeepEqual(*original, *current):
+			// Left alone by the reconcile, so another actor's change wins
+			*meta.FindStatusCondition(merged, condition.Type) = condition
+		}
+	}
+	return merged
+}
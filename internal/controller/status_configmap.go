@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// syncStatusConfigMap mirrors the certificate's status into its dashboard ConfigMap
+func (r *CertificateReconciler) syncStatusConfigMap(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.Spec.StatusConfigMapName,
+			Namespace: cert.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = map[string]string{
+			"app.kubernetes.io/managed-by": "certificate-operator",
+			"cert.example.com/certificate": cert.Name,
+		}
+		configMap.Data = map[string]string{
+			"certificate":  cert.Name,
+			"serialNumber": cert.Status.SerialNumber,
+			"notAfter":     formatStatusTime(cert.Status.NotAfter),
+			"renewalTime":  formatStatusTime(cert.Status.RenewalTime),
+			"fingerprint":  cert.Status.Fingerprint,
+		}
+		return ctrl.SetControllerReference(cert, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync status configmap: %w", err)
+	}
+	return nil
+}
+
+// certificateFingerprint returns the hex SHA-256 fingerprint of a PEM encoded certificate
+func certificateFingerprint(certPEM []byte) string {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(block.Bytes))
+}
+
+// publicKeyPin returns the base64-encoded SHA-256 of a DER SubjectPublicKeyInfo, as used for HPKP pins
+func publicKeyPin(spki []byte) string {
+	sum := sha256.Sum256(spki)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// formatStatusTime renders an optional status timestamp as RFC3339
+func formatStatusTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status patches", func() {
+	It("should keep status fields another actor sets during a reconcile", func() {
+		cert := newTestCertificate("status-concurrent")
+		r := newFakeReconciler(cert)
+		// Another actor records its own condition while the secret is being written
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Secret); ok {
+					other := &certv1alpha1.Certificate{}
+					Expect(c.Get(ctx, client.ObjectKeyFromObject(cert), other)).To(Succeed())
+					meta.SetStatusCondition(&other.Status.Conditions, metav1.Condition{
+						Type:   "ExternalCheck",
+						Status: metav1.ConditionTrue,
+						Reason: "Scanned",
+					})
+					Expect(c.Status().Update(ctx, other)).To(Succeed())
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, typeReadyCert)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, "ExternalCheck")).To(BeTrue())
+		Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+	})
+
+	It("should not revert a field it didn't change", func() {
+		cert := newTestCertificate("status-untouched")
+		r := newFakeReconciler(cert)
+		key := client.ObjectKeyFromObject(cert)
+		Expect(r.Get(ctx, key, cert)).To(Succeed())
+		statusCtx := withStatusBase(ctx, cert)
+
+		// Another actor writes the status after it was read
+		other := cert.DeepCopy()
+		other.Status.RestartedWorkloads = []string{"deployment/web"}
+		Expect(r.Status().Update(ctx, other)).To(Succeed())
+
+		cert.Status.SerialNumber = "abc"
+		Expect(r.updateStatus(statusCtx, cert)).To(Succeed())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, key, updated)).To(Succeed())
+		Expect(updated.Status.SerialNumber).To(Equal("abc"))
+		Expect(updated.Status.RestartedWorkloads).To(ConsistOf("deployment/web"))
+	})
+
+	DescribeTable("merging conditions",
+		func(base, latest, ours []metav1.Condition, expected []string) {
+			var got []string
+			for _, condition := range mergeConditions(base, latest, ours) {
+				got = append(got, condition.Type+"="+string(condition.Status))
+			}
+			Expect(got).To(ConsistOf(expected))
+		},
+		Entry("keeps a condition added by another actor",
+			nil,
+			[]metav1.Condition{{Type: typeApprovedCert, Status: metav1.ConditionTrue}},
+			[]metav1.Condition{{Type: typeReadyCert, Status: metav1.ConditionTrue}},
+			[]string{"Ready=True", "Approved=True"}),
+		Entry("takes another actor's change to a condition left alone",
+			[]metav1.Condition{{Type: typeApprovedCert, Status: metav1.ConditionFalse}},
+			[]metav1.Condition{{Type: typeApprovedCert, Status: metav1.ConditionTrue}},
+			[]metav1.Condition{{Type: typeApprovedCert, Status: metav1.ConditionFalse}},
+			[]string{"Approved=True"}),
+		Entry("keeps the reconcile's change to a condition",
+			[]metav1.Condition{{Type: typeReadyCert, Status: metav1.ConditionFalse}},
+			[]metav1.Condition{{Type: typeReadyCert, Status: metav1.ConditionUnknown}},
+			[]metav1.Condition{{Type: typeReadyCert, Status: metav1.ConditionTrue}},
+			[]string{"Ready=True"}),
+		Entry("keeps a condition the reconcile removed removed",
+			[]metav1.Condition{{Type: typeValidityClampedCert, Status: metav1.ConditionTrue}},
+			[]metav1.Condition{{Type: typeValidityClampedCert, Status: metav1.ConditionTrue}},
+			nil,
+			nil),
+	)
+})
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// applyTemplate merges the referenced CertificateTemplate under the certificate's spec in memory.
+// The merged spec must never be written back, so the certificate is only patched afterwards
+func (r *CertificateReconciler) applyTemplate(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if cert.Spec.TemplateRef == nil {
+		return nil
+	}
+
+	template := &certv1alpha1.CertificateTemplate{}
+	key := types.NamespacedName{Name: cert.Spec.TemplateRef.Name, Namespace: cert.Namespace}
+	if err := r.Get(ctx, key, template); err != nil {
+		return fmt.Errorf("failed to get certificate template %s: %w", key.Name, err)
+	}
+
+	mergeTemplate(&cert.Spec, &template.Spec)
+	return nil
+}
+
+// mergeTemplate fills the fields left unset in spec from the template
+func mergeTemplate(spec *certv1alpha1.CertificateSpec, template *certv1alpha1.CertificateTemplateSpec) {
+	if template.Subject != nil {
+		if spec.Subject == nil {
+			spec.Subject = &certv1alpha1.Subject{}
+		}
+		if len(spec.Subject.Organizations) == 0 {
+			spec.Subject.Organizations = template.Subject.Organizations
+		}
+		if len(spec.Subject.Countries) == 0 {
+			spec.Subject.Countries = template.Subject.Countries
+		}
+		if len(spec.Subject.OrganizationalUnits) == 0 {
+			spec.Subject.OrganizationalUnits = template.Subject.OrganizationalUnits
+		}
+	}
+
+	if spec.Duration == "" {
+		spec.Duration = template.Duration
+	}
+	if spec.RenewBefore == "" {
+		spec.RenewBefore = template.RenewBefore
+	}
+	if spec.IssuerRef.Name == "" && template.IssuerRef != nil {
+		spec.IssuerRef = *template.IssuerRef
+	}
+}
+
+// certificatesForTemplate maps a CertificateTemplate to the Certificates referencing it
+func (r *CertificateReconciler) certificatesForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	certificates := &certv1alpha1.CertificateList{}
+	if err := r.List(ctx, certificates, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cert := range certificates.Items {
+		if cert.Spec.TemplateRef != nil && cert.Spec.TemplateRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cert)})
+		}
+	}
+	return requests
+}
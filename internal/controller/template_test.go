@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Certificate templates", func() {
+	var template *certv1alpha1.CertificateTemplate
+
+	BeforeEach(func() {
+		template = &certv1alpha1.CertificateTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+			Spec: certv1alpha1.CertificateTemplateSpec{
+				Subject: &certv1alpha1.Subject{
+					Organizations: []string{"Fleet Corp"},
+					Countries:     []string{"US"},
+				},
+				Duration: "48h",
+			},
+		}
+	})
+
+	issue := func(cert *certv1alpha1.Certificate, objs ...client.Object) (*CertificateReconciler, *corev1.Secret) {
+		r := newFakeReconciler(append(objs, cert)...)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		ExpectWithOffset(1, r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		return r, secret
+	}
+
+	It("should apply template values to the issued certificate", func() {
+		cert := newTestCertificate("templated")
+		cert.Spec.TemplateRef = &certv1alpha1.TemplateRef{Name: template.Name}
+
+		r, secret := issue(cert, template)
+		parsed := parseCertificatePEM(secret.Data["tls.crt"])
+		Expect(parsed.Subject.Organization).To(Equal([]string{"Fleet Corp"}))
+		Expect(parsed.Subject.Country).To(Equal([]string{"US"}))
+		Expect(parsed.NotAfter.Sub(parsed.NotBefore)).To(Equal(48 * time.Hour))
+
+		// The merged values are not written back to the Certificate
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		Expect(updated.Spec.Duration).To(BeEmpty())
+		Expect(updated.Spec.Subject).To(BeNil())
+	})
+
+	It("should let per-certificate fields override the template", func() {
+		cert := newTestCertificate("templated-override")
+		cert.Spec.TemplateRef = &certv1alpha1.TemplateRef{Name: template.Name}
+		cert.Spec.Subject = &certv1alpha1.Subject{Organizations: []string{"Team A"}}
+		cert.Spec.Duration = "24h"
+
+		_, secret := issue(cert, template)
+		parsed := parseCertificatePEM(secret.Data["tls.crt"])
+		Expect(parsed.Subject.Organization).To(Equal([]string{"Team A"}))
+		Expect(parsed.Subject.Country).To(Equal([]string{"US"}))
+		Expect(parsed.NotAfter.Sub(parsed.NotBefore)).To(Equal(24 * time.Hour))
+	})
+
+	It("should report a missing template", func() {
+		cert := newTestCertificate("templated-missing")
+		cert.Spec.TemplateRef = &certv1alpha1.TemplateRef{Name: "absent"}
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).To(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("TemplateUnavailable"))
+	})
+
+	It("should enqueue the certificates referencing a template", func() {
+		referencing := newTestCertificate("references-template")
+		referencing.Spec.TemplateRef = &certv1alpha1.TemplateRef{Name: template.Name}
+		other := newTestCertificate("no-template")
+		r := newFakeReconciler(referencing, other)
+
+		Expect(r.certificatesForTemplate(ctx, template)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(referencing)},
+		))
+	})
+})
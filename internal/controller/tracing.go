@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// tracerName identifies the spans of the controller
+const tracerName = "github.com/namansharma18899/certificate-management-operator/internal/controller"
+
+// Span attributes describing the certificate and the outcome of the traced step
+const (
+	namespaceAttribute  = attribute.Key("certificate.namespace")
+	nameAttribute       = attribute.Key("certificate.name")
+	issuerKindAttribute = attribute.Key("certificate.issuer_kind")
+	outcomeAttribute    = attribute.Key("outcome")
+)
+
+// tracer returns the tracer of the configured provider, or of the global one
+func (r *CertificateReconciler) tracer() trace.Tracer {
+	if r.TracerProvider != nil {
+		return r.TracerProvider.Tracer(tracerName)
+	}
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+// startSpan starts a span named after the traced step, with the certificate's attributes
+func (r *CertificateReconciler) startSpan(ctx context.Context, name string, cert *certv1alpha1.Certificate) (context.Context, trace.Span) {
+	ctx, span := r.tracer().Start(ctx, name)
+	if cert != nil {
+		setCertificateAttributes(span, cert)
+	}
+	return ctx, span
+}
+
+// setCertificateAttributes identifies the certificate and its issuer kind on span
+func setCertificateAttributes(span trace.Span, cert *certv1alpha1.Certificate) {
+	span.SetAttributes(
+		namespaceAttribute.String(cert.Namespace),
+		nameAttribute.String(cert.Name),
+		issuerKindAttribute.String(issuerKind(cert)),
+	)
+}
+
+// endSpan records the outcome of the traced step and ends span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetAttributes(outcomeAttribute.String("error"))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(outcomeAttribute.String("success"))
+	}
+	span.End()
+}
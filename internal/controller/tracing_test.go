@@ -0,0 +1,44 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Tracing", func() {
+	It("should produce spans for the reconcile, the issuance and the secret write", func() {
+		exporter := tracetest.NewInMemoryExporter()
+		cert := newTestCertificate("traced")
+		r := newFakeReconciler(cert)
+		r.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		spans := map[string]tracetest.SpanStub{}
+		for _, span := range exporter.GetSpans() {
+			spans[span.Name] = span
+		}
+		Expect(spans).To(HaveKey("Reconcile"))
+		Expect(spans).To(HaveKey("generateCertificate"))
+		Expect(spans).To(HaveKey("createOrUpdateSecret"))
+
+		reconcileSpan := spans["Reconcile"]
+		for _, name := range []string{"generateCertificate", "createOrUpdateSecret"} {
+			Expect(spans[name].Parent.SpanID()).To(Equal(reconcileSpan.SpanContext.SpanID()))
+			Expect(spans[name].Attributes).To(ContainElements(
+				issuerKindAttribute.String("SelfSigned"),
+				outcomeAttribute.String("success"),
+			))
+		}
+		Expect(reconcileSpan.Attributes).To(ContainElements(
+			nameAttribute.String("traced"),
+			issuerKindAttribute.String("SelfSigned"),
+			outcomeAttribute.String("success"),
+		))
+	})
+})
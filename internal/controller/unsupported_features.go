@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// typeUnsupportedFeatureCert warns that the spec requests features the issuer kind can't honour.
+// The certificate is still issued, without them
+const typeUnsupportedFeatureCert = "UnsupportedFeature"
+
+// unsupportedFeatures lists the requested features that have no effect with the certificate's
+// issuer kind, each with the reason why
+func unsupportedFeatures(cert *certv1alpha1.Certificate) []string {
+	kind := issuerKind(cert)
+	if kind == issuerKindCA {
+		return nil
+	}
+
+	var unsupported []string
+	// Self-signed and ExternalKey certificates are their own issuer
+	if cert.Spec.SubmitToCTLogs {
+		unsupported = append(unsupported, "submitToCTLogs (CT logs only accept certificates chaining to a trusted CA)")
+	}
+	if len(cert.Spec.OCSPServers) > 0 {
+		unsupported = append(unsupported, "ocspServers (a self-signed certificate can't be revoked by an issuer)")
+	}
+	if len(cert.Spec.IssuingCertificateURLs) > 0 {
+		unsupported = append(unsupported, "issuingCertificateURLs (a self-signed certificate has no issuer to download)")
+	}
+	if cert.Spec.IssuerRef.CertKey != "" || cert.Spec.IssuerRef.PrivateKeyKey != "" {
+		unsupported = append(unsupported, "issuerRef.certKey and privateKeyKey (only CA issuers load a keypair from a secret)")
+	}
+	if _, ok := cert.Annotations[pinCAFingerprintAnnotation]; ok {
+		unsupported = append(unsupported, pinCAFingerprintAnnotation+" (only CA issuers have CA versions to pin)")
+	}
+	return unsupported
+}
+
+// reconcileUnsupportedFeatures sets the UnsupportedFeature condition and reports whether it
+// changed. Certificates that never requested an unsupported feature get no condition
+func reconcileUnsupportedFeatures(cert *certv1alpha1.Certificate) bool {
+	unsupported := unsupportedFeatures(cert)
+	if len(unsupported) == 0 {
+		if meta.FindStatusCondition(cert.Status.Conditions, typeUnsupportedFeatureCert) == nil {
+			return false
+		}
+		return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+			Type:               typeUnsupportedFeatureCert,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AllFeaturesSupported",
+			Message:            "Every requested feature is supported by the issuer",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	return meta.SetStatusCondition(&cert.Status.Conditions, metav1.Condition{
+		Type:               typeUnsupportedFeatureCert,
+		Status:             metav1.ConditionTrue,
+		Reason:             "IncompatibleIssuer",
+		Message:            fmt.Sprintf("Ignored with a %s issuer: %s", issuerKind(cert), strings.Join(unsupported, "; ")),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// syncUnsupportedFeatures records unsupported features in status when they changed, with a
+// Warning event so they show up in kubectl describe
+func (r *CertificateReconciler) syncUnsupportedFeatures(ctx context.Context, cert *certv1alpha1.Certificate) error {
+	if !reconcileUnsupportedFeatures(cert) {
+		return nil
+	}
+	if condition := meta.FindStatusCondition(cert.Status.Conditions, typeUnsupportedFeatureCert); condition.Status == metav1.ConditionTrue {
+		r.Recorder.Event(cert, corev1.EventTypeWarning, typeUnsupportedFeatureCert, condition.Message)
+	}
+	if err := r.updateStatus(ctx, cert); err != nil {
+		return fmt.Errorf("failed to record unsupported features: %w", err)
+	}
+	return nil
+}
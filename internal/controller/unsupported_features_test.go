@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Unsupported features", func() {
+	It("should warn about features a self-signed issuer ignores and clear the warning once removed", func() {
+		cert := newTestCertificate("unsupported")
+		cert.Spec.SubmitToCTLogs = true
+		cert.Spec.OCSPServers = []string{"http://ocsp.example.com"}
+		r := newFakeReconciler(cert)
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		warning := meta.FindStatusCondition(updated.Status.Conditions, typeUnsupportedFeatureCert)
+		Expect(warning).NotTo(BeNil())
+		Expect(warning.Status).To(Equal(metav1.ConditionTrue))
+		Expect(warning.Reason).To(Equal("IncompatibleIssuer"))
+		Expect(warning.Message).To(HavePrefix("Ignored with a SelfSigned issuer: submitToCTLogs"))
+		Expect(warning.Message).To(ContainSubstring("ocspServers"))
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Warning UnsupportedFeature")))
+
+		// The certificate is still issued
+		Expect(updated.Status.SerialNumber).NotTo(BeEmpty())
+
+		updated.Spec.SubmitToCTLogs = false
+		updated.Spec.OCSPServers = nil
+		Expect(r.Update(ctx, updated)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		warning = meta.FindStatusCondition(updated.Status.Conditions, typeUnsupportedFeatureCert)
+		Expect(warning.Status).To(Equal(metav1.ConditionFalse))
+		Expect(warning.Reason).To(Equal("AllFeaturesSupported"))
+	})
+
+	It("should not warn about features the CA issuer supports", func() {
+		ca := newKeyPairSecret("unsupported-ca", "Test Root CA", true, 365*24*time.Hour)
+		cert := newTestCertificate("unsupported-ca-issued")
+		cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: ca.Name, Kind: issuerKindCA}
+		cert.Spec.OCSPServers = []string{"http://ocsp.example.com"}
+		cert.Spec.IssuingCertificateURLs = []string{"http://ca.example.com/ca.crt"}
+
+		Expect(unsupportedFeatures(cert)).To(BeEmpty())
+		Expect(reconcileUnsupportedFeatures(cert)).To(BeFalse())
+	})
+
+	DescribeTable("incompatible combinations",
+		func(mutate func(*certv1alpha1.Certificate), feature string) {
+			cert := newTestCertificate("unsupported-table")
+			mutate(cert)
+			Expect(unsupportedFeatures(cert)).To(ContainElement(HavePrefix(feature)))
+		},
+		Entry("CT submission with an external key", func(c *certv1alpha1.Certificate) {
+			c.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "kms-key", Kind: issuerKindExternalKey}
+			c.Spec.SubmitToCTLogs = true
+		}, "submitToCTLogs"),
+		Entry("CA issuer URLs when self-signed", func(c *certv1alpha1.Certificate) {
+			c.Spec.IssuingCertificateURLs = []string{"http://ca.example.com/ca.crt"}
+		}, "issuingCertificateURLs"),
+		Entry("CA secret keys when self-signed", func(c *certv1alpha1.Certificate) {
+			c.Spec.IssuerRef.CertKey = "ca.crt"
+		}, "issuerRef.certKey"),
+		Entry("a CA pin when self-signed", func(c *certv1alpha1.Certificate) {
+			c.Annotations = map[string]string{pinCAFingerprintAnnotation: "abc"}
+		}, pinCAFingerprintAnnotation),
+	)
+})
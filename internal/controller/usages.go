@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// keyUsageNames maps the key usages accepted in Spec.Usages
+var keyUsageNames = map[string]x509.KeyUsage{
+	"digital signature": x509.KeyUsageDigitalSignature,
+	"key encipherment":  x509.KeyUsageKeyEncipherment,
+	"cert sign":         x509.KeyUsageCertSign,
+	"crl sign":          x509.KeyUsageCRLSign,
+}
+
+// extKeyUsageNames maps the extended key usages accepted in Spec.Usages
+var extKeyUsageNames = map[string]x509.ExtKeyUsage{
+	"server auth":      x509.ExtKeyUsageServerAuth,
+	"client auth":      x509.ExtKeyUsageClientAuth,
+	"code signing":     x509.ExtKeyUsageCodeSigning,
+	"email protection": x509.ExtKeyUsageEmailProtection,
+}
+
+// certificateUsages returns the key usages of the certificate written to SecretName. A zero key
+// usage leaves the default for the key type and IsCA in place
+func certificateUsages(cert *certv1alpha1.Certificate) (x509.KeyUsage, []x509.ExtKeyUsage, error) {
+	if len(cert.Spec.Usages) == 0 {
+		return 0, serverExtKeyUsage(cert), nil
+	}
+
+	var keyUsage x509.KeyUsage
+	var extKeyUsage []x509.ExtKeyUsage
+	for _, name := range cert.Spec.Usages {
+		if usage, ok := keyUsageNames[name]; ok {
+			keyUsage |= usage
+		} else if usage, ok := extKeyUsageNames[name]; ok {
+			if !slices.Contains(extKeyUsage, usage) {
+				extKeyUsage = append(extKeyUsage, usage)
+			}
+		} else {
+			return 0, nil, fmt.Errorf("%w: unknown usage %q", ErrInvalidSpec, name)
+		}
+	}
+	if err := validateUsagesForCA(cert, keyUsage, extKeyUsage); err != nil {
+		return 0, nil, err
+	}
+	return keyUsage, extKeyUsage, nil
+}
+
+// validateUsagesForCA rejects CA signing usages on leaves, and leaf authentication usages on
+// CAs unless AllowCALeafUsages opts in
+func validateUsagesForCA(cert *certv1alpha1.Certificate, keyUsage x509.KeyUsage, extKeyUsage []x509.ExtKeyUsage) error {
+	if !cert.Spec.IsCA {
+		if keyUsage&(x509.KeyUsageCertSign|x509.KeyUsageCRLSign) != 0 {
+			return fmt.Errorf("%w: cert sign and crl sign require isCA", ErrInvalidUsageForCA)
+		}
+		return nil
+	}
+	if cert.Spec.AllowCALeafUsages {
+		return nil
+	}
+	for _, usage := range extKeyUsage {
+		if usage == x509.ExtKeyUsageServerAuth || usage == x509.ExtKeyUsageClientAuth {
+			return fmt.Errorf("%w: server auth and client auth on a CA certificate require allowCALeafUsages", ErrInvalidUsageForCA)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"crypto/x509"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Key usages", func() {
+	newUsageCertificate := func(name string, isCA bool, usages ...string) *certv1alpha1.Certificate {
+		cert := newTestCertificate(name)
+		cert.Spec.IsCA = isCA
+		cert.Spec.Usages = usages
+		return cert
+	}
+
+	It("should issue with the requested usages", func() {
+		cert := newUsageCertificate("usages", false, "digital signature", "client auth")
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.KeyUsage).To(Equal(x509.KeyUsageDigitalSignature))
+		Expect(parsed.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}))
+	})
+
+	It("should keep CA certificates free of extended key usages by default", func() {
+		issued, err := newFakeReconciler().generateCertificate(ctx, newUsageCertificate("usages-ca-default", true))
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.KeyUsage & x509.KeyUsageCertSign).NotTo(BeZero())
+		Expect(parsed.ExtKeyUsage).To(BeEmpty())
+	})
+
+	DescribeTable("rejected combinations",
+		func(isCA bool, usages ...string) {
+			_, err := newFakeReconciler().generateCertificate(ctx, newUsageCertificate("usages-rejected", isCA, usages...))
+			Expect(err).To(MatchError(ErrInvalidUsageForCA))
+		},
+		Entry("cert sign on a leaf", false, "digital signature", "cert sign", "server auth"),
+		Entry("crl sign on a leaf", false, "crl sign"),
+		Entry("server auth on a CA", true, "cert sign", "server auth"),
+		Entry("client auth on a CA", true, "cert sign", "crl sign", "client auth"),
+	)
+
+	It("should allow leaf usages on a CA when explicitly permitted", func() {
+		cert := newUsageCertificate("usages-ca-allowed", true, "cert sign", "digital signature", "server auth")
+		cert.Spec.AllowCALeafUsages = true
+		issued, err := newFakeReconciler().generateCertificate(ctx, cert)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed := parseCertificatePEM(issued.certPEM)
+		Expect(parsed.IsCA).To(BeTrue())
+		Expect(parsed.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}))
+	})
+
+	It("should reject unknown usages as an invalid spec", func() {
+		_, err := newFakeReconciler().generateCertificate(ctx, newUsageCertificate("usages-unknown", false, "timestamping"))
+		Expect(err).To(MatchError(ErrInvalidSpec))
+	})
+
+	It("should report InvalidUsageForCA in the Ready condition", func() {
+		cert := newUsageCertificate("usages-ready", false, "cert sign")
+		r := newFakeReconciler(cert)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cert), updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("InvalidUsageForCA"))
+		Expect(updated.Status.SerialNumber).To(BeEmpty())
+	})
+})
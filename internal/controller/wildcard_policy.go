@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+// Wildcard policies enforced on the DNS names of every Certificate
+const (
+	// WildcardPolicyAllow issues wildcard DNS names as requested
+	WildcardPolicyAllow = "Allow"
+	// WildcardPolicyReject refuses to issue certificates with wildcard DNS names
+	WildcardPolicyReject = "Reject"
+	// WildcardPolicyExpand replaces each wildcard DNS name with the configured host labels under
+	// its domain, e.g. *.example.com with www.example.com and api.example.com
+	WildcardPolicyExpand = "Expand"
+)
+
+// applyWildcardPolicy enforces the controller's wildcard policy on the certificate's DNS names in
+// memory, like applyTemplate, so the expanded names are what issuance and SAN checks see
+func (r *CertificateReconciler) applyWildcardPolicy(cert *certv1alpha1.Certificate) error {
+	// Imported certificates aren't issued by the operator, so there is nothing to enforce
+	if cert.Spec.ImportFromSecret != "" {
+		return nil
+	}
+	names, err := r.wildcardPolicyNames(cert.Spec.DNSNames)
+	if err != nil {
+		return err
+	}
+	cert.Spec.DNSNames = names
+	return nil
+}
+
+// enforceWildcardPolicy enforces the wildcard policy on the names of the certificate template
+// about to be signed. Besides the spec's DNS names, these include the common name, the SANs
+// merged from a CSR and those of additional certificates
+func (r *CertificateReconciler) enforceWildcardPolicy(template *x509.Certificate) error {
+	if r.WildcardPolicy != WildcardPolicyReject && r.WildcardPolicy != WildcardPolicyExpand {
+		return nil
+	}
+	// A common name holds a single host, so there is nothing to expand it into
+	if strings.HasPrefix(template.Subject.CommonName, "*.") {
+		return fmt.Errorf("%w: wildcard common name %s is not allowed, use a host name or leave it empty",
+			ErrPolicyViolation, template.Subject.CommonName)
+	}
+	names, err := r.wildcardPolicyNames(template.DNSNames)
+	if err != nil {
+		return err
+	}
+	template.DNSNames = names
+	return nil
+}
+
+// wildcardPolicyNames returns the DNS names allowed by the wildcard policy, expanding wildcards
+// when the policy is Expand
+func (r *CertificateReconciler) wildcardPolicyNames(names []string) ([]string, error) {
+	if r.WildcardPolicy != WildcardPolicyReject && r.WildcardPolicy != WildcardPolicyExpand {
+		return names, nil
+	}
+
+	var wildcards []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "*.") {
+			wildcards = append(wildcards, name)
+		}
+	}
+	if len(wildcards) == 0 {
+		return names, nil
+	}
+	if r.WildcardPolicy == WildcardPolicyReject || len(r.WildcardExpansionLabels) == 0 {
+		return nil, fmt.Errorf("%w: wildcard DNS names %s are not allowed, list each host explicitly",
+			ErrPolicyViolation, strings.Join(wildcards, ", "))
+	}
+
+	expanded := make([]string, 0, len(names))
+	for _, name := range names {
+		domain, wildcard := strings.CutPrefix(name, "*.")
+		if !wildcard {
+			if !slices.Contains(expanded, name) {
+				expanded = append(expanded, name)
+			}
+			continue
+		}
+		for _, label := range r.WildcardExpansionLabels {
+			if host := label + "." + domain; !slices.Contains(expanded, host) {
+				expanded = append(expanded, host)
+			}
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	certv1alpha1 "github.com/namansharma18899/certificate-management-operator/api/v1alpha1"
+)
+
+var _ = Describe("Wildcard policy", func() {
+	It("should reject a wildcard DNS name when the policy is Reject", func() {
+		cert := newTestCertificate("wildcard-rejected")
+		cert.Spec.DNSNames = []string{"app.example.com", "*.example.com"}
+		r := newFakeReconciler(cert)
+		r.WildcardPolicy = WildcardPolicyReject
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, typeReadyCert)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("PolicyViolation"))
+		Expect(ready.Message).To(ContainSubstring("wildcard DNS names *.example.com are not allowed"))
+		Expect(updated.Status.SerialNumber).To(BeEmpty())
+		err = r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should issue wildcard DNS names by default", func() {
+		cert := newTestCertificate("wildcard-allowed")
+		cert.Spec.DNSNames = []string{"*.example.com"}
+
+		Expect(newFakeReconciler().applyWildcardPolicy(cert)).To(Succeed())
+		Expect(cert.Spec.DNSNames).To(Equal([]string{"*.example.com"}))
+	})
+
+	It("should expand wildcards into the configured hosts", func() {
+		cert := newTestCertificate("wildcard-expanded")
+		cert.Spec.DNSNames = []string{"www.example.com", "*.example.com", "*.internal.example.com"}
+		r := newFakeReconciler(cert)
+		r.WildcardPolicy = WildcardPolicyExpand
+		r.WildcardExpansionLabels = []string{"www", "api"}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: cert.Spec.SecretName, Namespace: "default"}, secret)).To(Succeed())
+		Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).DNSNames).To(ConsistOf(
+			"www.example.com", "api.example.com", "www.internal.example.com", "api.internal.example.com"))
+
+		// The stored spec keeps the wildcard, and the expanded certificate isn't re-issued
+		updated := &certv1alpha1.Certificate{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Spec.DNSNames).To(ContainElement("*.example.com"))
+		serial := updated.Status.SerialNumber
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.SerialNumber).To(Equal(serial))
+	})
+
+	It("should reject wildcards when there are no hosts to expand them into", func() {
+		cert := newTestCertificate("wildcard-no-labels")
+		cert.Spec.DNSNames = []string{"*.example.com"}
+		r := newFakeReconciler()
+		r.WildcardPolicy = WildcardPolicyExpand
+
+		Expect(r.applyWildcardPolicy(cert)).To(MatchError(ErrPolicyViolation))
+	})
+
+	Context("with names that don't come from the spec's DNS names", func() {
+		rejecting := func(objs ...client.Object) *CertificateReconciler {
+			r := newFakeReconciler(objs...)
+			r.WildcardPolicy = WildcardPolicyReject
+			return r
+		}
+
+		It("should reject a wildcard common name", func() {
+			cert := newTestCertificate("wildcard-cn")
+			cert.Spec.CommonName = "*.example.com"
+			r := rejecting()
+			Expect(r.applyWildcardPolicy(cert)).To(Succeed())
+
+			_, err := r.generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrPolicyViolation))
+			Expect(err.Error()).To(ContainSubstring("wildcard common name *.example.com"))
+		})
+
+		It("should reject wildcard SANs requested by a CSR", func() {
+			cert := newTestCertificate("wildcard-csr")
+			cert.Spec.DNSNames = []string{"spec.example.com"}
+			cert.Spec.IssuerRef = certv1alpha1.IssuerRef{Name: "wildcard-csr-ca", Kind: issuerKindCA}
+			cert.Spec.CSRSecretRef = &certv1alpha1.CSRSecretRef{Name: "wildcard-csr-request"}
+			csrSecret, _ := newCSRSecret("wildcard-csr-request", []string{"*.example.com"}, nil)
+			r := rejecting(csrSecret, newKeyPairSecret("wildcard-csr-ca", "CSR CA", true, 365*24*time.Hour))
+
+			_, err := r.generateCertificate(ctx, cert)
+			Expect(err).To(MatchError(ErrPolicyViolation))
+			Expect(err.Error()).To(ContainSubstring("wildcard DNS names *.example.com are not allowed"))
+		})
+
+		It("should expand the wildcards of additional certificates", func() {
+			cert := newTestCertificate("wildcard-additional")
+			cert.Spec.AdditionalCertificates = []certv1alpha1.AdditionalCertificate{
+				{DNSNames: []string{"*.example.com"}, SecretName: "wildcard-additional-extra-tls"},
+			}
+			r := newFakeReconciler(cert)
+			r.WildcardPolicy = WildcardPolicyExpand
+			r.WildcardExpansionLabels = []string{"www", "api"}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cert)})
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: "wildcard-additional-extra-tls", Namespace: "default"}, secret)).To(Succeed())
+			Expect(parseCertificatePEM(secret.Data[corev1.TLSCertKey]).DNSNames).To(ConsistOf("www.example.com", "api.example.com"))
+		})
+	})
+})
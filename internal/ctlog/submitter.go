@@ -0,0 +1,123 @@
+// Package ctlog submits certificates to certificate transparency logs through the RFC 6962
+// add-chain API.
+package ctlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// logIDLength is the size of a log ID, the SHA-256 hash of the log's public key
+const logIDLength = 32
+
+// Submitter submits chains to every configured log. It implements controller.CTSubmitter
+type Submitter struct {
+	// LogURLs are the base URLs of the logs, e.g. https://ct.example.com/2025h1
+	LogURLs []string
+
+	// Client sends the requests. Defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// addChainResponse is the SCT a log returns from add-chain, with binary fields base64 encoded
+type addChainResponse struct {
+	Version    uint8  `json:"sct_version"`
+	ID         []byte `json:"id"`
+	Timestamp  uint64 `json:"timestamp"`
+	Extensions string `json:"extensions"`
+	Signature  []byte `json:"signature"`
+}
+
+// Submit sends the DER chain, leaf first, to every log and returns their SCTs in the RFC 6962
+// serialization. It fails if any log does, since a partial set may not satisfy CT policies
+func (s *Submitter) Submit(ctx context.Context, chain [][]byte) ([][]byte, error) {
+	if len(s.LogURLs) == 0 {
+		return nil, errors.New("no CT logs are configured")
+	}
+	body, err := json.Marshal(map[string][][]byte{"chain": chain})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chain: %w", err)
+	}
+
+	var scts [][]byte
+	var errs []error
+	for _, logURL := range s.LogURLs {
+		sct, err := s.addChain(ctx, logURL, body)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", logURL, err))
+			continue
+		}
+		scts = append(scts, sct)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return scts, nil
+}
+
+// addChain submits the encoded chain to one log and returns its serialized SCT
+func (s *Submitter) addChain(ctx context.Context, logURL string, body []byte) ([]byte, error) {
+	endpoint := strings.TrimSuffix(logURL, "/") + "/ct/v1/add-chain"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("add-chain returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	var sct addChainResponse
+	if err := json.Unmarshal(data, &sct); err != nil {
+		return nil, fmt.Errorf("failed to decode SCT: %w", err)
+	}
+	return serializeSCT(sct)
+}
+
+// serializeSCT encodes the SCT as the SignedCertificateTimestamp structure of RFC 6962 section
+// 3.2. The signature is already a TLS-encoded digitally-signed struct
+func serializeSCT(sct addChainResponse) ([]byte, error) {
+	if len(sct.ID) != logIDLength {
+		return nil, fmt.Errorf("SCT log ID is %d bytes, not %d", len(sct.ID), logIDLength)
+	}
+	extensions, err := base64.StdEncoding.DecodeString(sct.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCT extensions: %w", err)
+	}
+	if len(extensions) > 0xffff {
+		return nil, errors.New("SCT extensions are too long")
+	}
+	if len(sct.Signature) == 0 {
+		return nil, errors.New("SCT has no signature")
+	}
+
+	out := []byte{sct.Version}
+	out = append(out, sct.ID...)
+	out = binary.BigEndian.AppendUint64(out, sct.Timestamp)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(extensions)))
+	out = append(out, extensions...)
+	return append(out, sct.Signature...), nil
+}
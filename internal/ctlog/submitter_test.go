@@ -0,0 +1,80 @@
+package ctlog
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CT log submitter", func() {
+	logID := bytes.Repeat([]byte{0xab}, logIDLength)
+	// A TLS-encoded digitally-signed struct: SHA-256, ECDSA, then the length-prefixed signature
+	signature := []byte{4, 3, 0, 3, 1, 2, 3}
+
+	// newLog serves add-chain, recording the submitted chains
+	newLog := func(status int, submitted *[][][]byte) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.Method).To(Equal(http.MethodPost))
+			Expect(req.URL.Path).To(Equal("/log/ct/v1/add-chain"))
+			var body struct {
+				Chain [][]byte `json:"chain"`
+			}
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			*submitted = append(*submitted, body.Chain)
+
+			if status != http.StatusOK {
+				http.Error(w, "unknown root", status)
+				return
+			}
+			Expect(json.NewEncoder(w).Encode(map[string]any{
+				"sct_version": 0,
+				"id":          base64.StdEncoding.EncodeToString(logID),
+				"timestamp":   1700000000000,
+				"extensions":  "",
+				"signature":   base64.StdEncoding.EncodeToString(signature),
+			})).To(Succeed())
+		}))
+		DeferCleanup(server.Close)
+		return server
+	}
+
+	It("should submit the chain to every log and serialize their SCTs", func() {
+		var submitted [][][]byte
+		first, second := newLog(http.StatusOK, &submitted), newLog(http.StatusOK, &submitted)
+		submitter := &Submitter{LogURLs: []string{first.URL + "/log", second.URL + "/log/"}}
+
+		chain := [][]byte{[]byte("leaf"), []byte("intermediate")}
+		scts, err := submitter.Submit(ctx, chain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(submitted).To(Equal([][][]byte{chain, chain}))
+		Expect(scts).To(HaveLen(2))
+
+		expected := append([]byte{0}, logID...)
+		expected = binary.BigEndian.AppendUint64(expected, 1700000000000)
+		expected = append(expected, 0, 0)
+		expected = append(expected, signature...)
+		Expect(scts[0]).To(Equal(expected))
+	})
+
+	It("should fail when a log rejects the chain", func() {
+		var submitted [][][]byte
+		good, bad := newLog(http.StatusOK, &submitted), newLog(http.StatusBadRequest, &submitted)
+		submitter := &Submitter{LogURLs: []string{good.URL + "/log", bad.URL + "/log"}}
+
+		_, err := submitter.Submit(ctx, [][]byte{[]byte("leaf")})
+		Expect(err).To(MatchError(ContainSubstring("unknown root")))
+		Expect(submitted).To(HaveLen(2))
+	})
+
+	It("should fail without logs", func() {
+		_, err := (&Submitter{}).Submit(ctx, [][]byte{[]byte("leaf")})
+		Expect(err).To(HaveOccurred())
+	})
+})
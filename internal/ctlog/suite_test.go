@@ -0,0 +1,17 @@
+package ctlog
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx = context.Background()
+
+func TestCTLog(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "CT Log Suite")
+}
@@ -0,0 +1,96 @@
+// Package inspect prints the certificates stored in a cluster secret for debugging.
+package inspect
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Secret writes the details of each certificate under key in the named secret to w, leaf first
+func Secret(ctx context.Context, reader client.Reader, name types.NamespacedName, key string, w io.Writer) error {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, name, secret); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return fmt.Errorf("secret %s has no %s key", name, key)
+	}
+
+	var certs []*x509.Certificate
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate in %s/%s: %w", name, key, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("no PEM certificates in %s/%s", name, key)
+	}
+
+	for i, cert := range certs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		writeCertificate(w, cert)
+	}
+	return nil
+}
+
+func writeCertificate(w io.Writer, cert *x509.Certificate) {
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	fmt.Fprintf(w, "Subject:     %s\n", cert.Subject)
+	fmt.Fprintf(w, "Issuer:      %s\n", cert.Issuer)
+	fmt.Fprintf(w, "DNS Names:   %s\n", strings.Join(cert.DNSNames, ", "))
+	fmt.Fprintf(w, "IPs:         %s\n", strings.Join(ips, ", "))
+	fmt.Fprintf(w, "Not Before:  %s\n", cert.NotBefore.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Not After:   %s\n", cert.NotAfter.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Serial:      %x\n", cert.SerialNumber)
+	fmt.Fprintf(w, "Fingerprint: %x\n", sha256.Sum256(cert.Raw))
+	fmt.Fprintf(w, "CA:          %t\n", cert.IsCA)
+}
+
+// NewCommand returns the inspect subcommand. newClient is only called when the command runs,
+// so building the command needs no cluster access
+func NewCommand(newClient func() (client.Client, error)) *cobra.Command {
+	var namespace, key string
+	cmd := &cobra.Command{
+		Use:   "inspect SECRET",
+		Short: "Print the certificates stored in a secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			return Secret(cmd.Context(), c, types.NamespacedName{Name: args[0], Namespace: namespace}, key, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace of the secret.")
+	cmd.Flags().StringVar(&key, "key", corev1.TLSCertKey, "The secret key holding the PEM certificates.")
+	return cmd
+}
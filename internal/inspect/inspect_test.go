@@ -0,0 +1,99 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Inspect", func() {
+	notBefore := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+
+	newCertificatePEM := func() ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(0x1a2b),
+			Subject:      pkix.Name{CommonName: "api.example.com", Organization: []string{"Example"}},
+			DNSNames:     []string{"api.example.com", "www.example.com"},
+			IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), der
+	}
+
+	newSecret := func(data map[string][]byte) client.Client {
+		return fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-tls", Namespace: "prod"},
+			Data:       data,
+		}).Build()
+	}
+
+	It("should print the certificate details", func() {
+		certPEM, der := newCertificatePEM()
+		c := newSecret(map[string][]byte{corev1.TLSCertKey: certPEM})
+
+		var out bytes.Buffer
+		Expect(Secret(context.Background(), c, types.NamespacedName{Name: "api-tls", Namespace: "prod"}, corev1.TLSCertKey, &out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Subject:     CN=api.example.com,O=Example\n"))
+		Expect(out.String()).To(ContainSubstring("DNS Names:   api.example.com, www.example.com\n"))
+		Expect(out.String()).To(ContainSubstring("IPs:         10.0.0.1\n"))
+		Expect(out.String()).To(ContainSubstring("Not Before:  2026-01-02T03:04:05Z\n"))
+		Expect(out.String()).To(ContainSubstring("Not After:   " + notAfter.Format(time.RFC3339) + "\n"))
+		Expect(out.String()).To(ContainSubstring("Serial:      1a2b\n"))
+		Expect(out.String()).To(ContainSubstring(fmt.Sprintf("Fingerprint: %x\n", sha256.Sum256(der))))
+	})
+
+	It("should print every certificate in a chain", func() {
+		leaf, _ := newCertificatePEM()
+		issuer, _ := newCertificatePEM()
+		c := newSecret(map[string][]byte{corev1.TLSCertKey: append(leaf, issuer...)})
+
+		var out bytes.Buffer
+		Expect(Secret(context.Background(), c, types.NamespacedName{Name: "api-tls", Namespace: "prod"}, corev1.TLSCertKey, &out)).To(Succeed())
+		Expect(bytes.Count(out.Bytes(), []byte("Fingerprint:"))).To(Equal(2))
+	})
+
+	It("should fail for a missing secret or key", func() {
+		c := newSecret(map[string][]byte{"other": []byte("x")})
+
+		var out bytes.Buffer
+		Expect(Secret(context.Background(), c, types.NamespacedName{Name: "missing", Namespace: "prod"}, corev1.TLSCertKey, &out)).NotTo(Succeed())
+		Expect(Secret(context.Background(), c, types.NamespacedName{Name: "api-tls", Namespace: "prod"}, corev1.TLSCertKey, &out)).NotTo(Succeed())
+		Expect(Secret(context.Background(), c, types.NamespacedName{Name: "api-tls", Namespace: "prod"}, "other", &out)).NotTo(Succeed())
+	})
+
+	It("should run as a subcommand", func() {
+		certPEM, _ := newCertificatePEM()
+		c := newSecret(map[string][]byte{corev1.TLSCertKey: certPEM})
+
+		cmd := NewCommand(func() (client.Client, error) { return c, nil })
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"api-tls", "-n", "prod"})
+		Expect(cmd.ExecuteContext(context.Background())).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Serial:      1a2b\n"))
+	})
+})